@@ -0,0 +1,415 @@
+/*
+ * NIFs for the functions of C-KZG-4844.
+ *
+ * The operations take from a millisecond to tens of milliseconds, longer than
+ * a NIF may run on a normal scheduler, so they run on the dirty CPU
+ * schedulers. Their binary arguments are kept alive by the calling process
+ * until they return, and the settings are only read, so they may run at once
+ * in several processes.
+ */
+#include <errno.h>
+#include <stdbool.h>
+#include <stdio.h>
+#include <string.h>
+
+#include "c_kzg_4844.h"
+#include "erl_nif.h"
+
+/* Sizes of the trusted setup points, which the header does not export. */
+#define BYTES_PER_G1 48
+#define BYTES_PER_G2 96
+
+typedef struct {
+    KZGSettings settings;
+    bool loaded;
+} settings_resource;
+
+static ErlNifResourceType *settings_type;
+
+static ERL_NIF_TERM atom_ok;
+static ERL_NIF_TERM atom_error;
+static ERL_NIF_TERM atom_badargs;
+static ERL_NIF_TERM atom_internal;
+static ERL_NIF_TERM atom_malloc;
+static ERL_NIF_TERM atom_true;
+static ERL_NIF_TERM atom_false;
+
+static void settings_dtor(ErlNifEnv *env, void *obj) {
+    settings_resource *res = obj;
+    if (res->loaded) free_trusted_setup(&res->settings);
+}
+
+static int load(ErlNifEnv *env, void **priv_data, ERL_NIF_TERM load_info) {
+    settings_type = enif_open_resource_type(
+        env, NULL, "KZGSettings", settings_dtor, ERL_NIF_RT_CREATE, NULL
+    );
+    if (settings_type == NULL) return 1;
+
+    atom_ok = enif_make_atom(env, "ok");
+    atom_error = enif_make_atom(env, "error");
+    atom_badargs = enif_make_atom(env, "badargs");
+    atom_internal = enif_make_atom(env, "internal");
+    atom_malloc = enif_make_atom(env, "malloc");
+    atom_true = enif_make_atom(env, "true");
+    atom_false = enif_make_atom(env, "false");
+    return 0;
+}
+
+static ERL_NIF_TERM make_ok(ErlNifEnv *env, ERL_NIF_TERM value) {
+    return enif_make_tuple2(env, atom_ok, value);
+}
+
+/* Returns {:error, reason} for a C_KZG_RET other than C_KZG_OK. */
+static ERL_NIF_TERM make_error(ErlNifEnv *env, C_KZG_RET ret) {
+    ERL_NIF_TERM reason;
+    switch (ret) {
+    case C_KZG_BADARGS:
+        reason = atom_badargs;
+        break;
+    case C_KZG_MALLOC:
+        reason = atom_malloc;
+        break;
+    default:
+        reason = atom_internal;
+        break;
+    }
+    return enif_make_tuple2(env, atom_error, reason);
+}
+
+static ERL_NIF_TERM make_bool(bool b) {
+    return b ? atom_true : atom_false;
+}
+
+/*
+ * Reads a binary argument. Raises ArgumentError if it is not a binary, and
+ * returns {:error, :badargs} if it does not have the given size.
+ */
+#define GET_BINARY(env, term, bin, expected) \
+    do { \
+        if (!enif_inspect_binary(env, term, &(bin))) \
+            return enif_make_badarg(env); \
+        if ((bin).size != (expected)) return make_error(env, C_KZG_BADARGS); \
+    } while (0)
+
+#define GET_SETTINGS(env, term, res) \
+    do { \
+        void *obj; \
+        if (!enif_get_resource(env, term, settings_type, &obj)) \
+            return enif_make_badarg(env); \
+        (res) = obj; \
+    } while (0)
+
+/* Wraps loaded settings in a resource, or returns the error of ret. */
+static ERL_NIF_TERM make_settings(
+    ErlNifEnv *env, settings_resource *res, C_KZG_RET ret
+) {
+    ERL_NIF_TERM term;
+    if (ret != C_KZG_OK) {
+        enif_release_resource(res);
+        return make_error(env, ret);
+    }
+    res->loaded = true;
+    term = enif_make_resource(env, res);
+    enif_release_resource(res);
+    return make_ok(env, term);
+}
+
+/* Names the common reasons fopen fails, as the File module does. */
+static const char *errno_name(int err) {
+    switch (err) {
+    case ENOENT:
+        return "enoent";
+    case EACCES:
+        return "eacces";
+    case EISDIR:
+        return "eisdir";
+    default:
+        return "eio";
+    }
+}
+
+static ERL_NIF_TERM load_trusted_setup_file_nif(
+    ErlNifEnv *env, int argc, const ERL_NIF_TERM argv[]
+) {
+    ErlNifBinary path;
+    char *path_str;
+    FILE *fp;
+    settings_resource *res;
+    C_KZG_RET ret;
+
+    if (!enif_inspect_binary(env, argv[0], &path)) return enif_make_badarg(env);
+    path_str = enif_alloc(path.size + 1);
+    if (path_str == NULL) return make_error(env, C_KZG_MALLOC);
+    memcpy(path_str, path.data, path.size);
+    path_str[path.size] = '\0';
+    fp = fopen(path_str, "r");
+    enif_free(path_str);
+    if (fp == NULL) {
+        return enif_make_tuple2(
+            env, atom_error, enif_make_atom(env, errno_name(errno))
+        );
+    }
+
+    res = enif_alloc_resource(settings_type, sizeof(settings_resource));
+    if (res == NULL) {
+        fclose(fp);
+        return make_error(env, C_KZG_MALLOC);
+    }
+    res->loaded = false;
+    ret = load_trusted_setup_file(&res->settings, fp);
+    fclose(fp);
+    return make_settings(env, res, ret);
+}
+
+static ERL_NIF_TERM load_trusted_setup_nif(
+    ErlNifEnv *env, int argc, const ERL_NIF_TERM argv[]
+) {
+    ErlNifBinary g1, g2;
+    settings_resource *res;
+    C_KZG_RET ret;
+
+    if (!enif_inspect_binary(env, argv[0], &g1) ||
+        !enif_inspect_binary(env, argv[1], &g2))
+        return enif_make_badarg(env);
+    if (g1.size % BYTES_PER_G1 != 0 || g2.size % BYTES_PER_G2 != 0)
+        return make_error(env, C_KZG_BADARGS);
+
+    res = enif_alloc_resource(settings_type, sizeof(settings_resource));
+    if (res == NULL) return make_error(env, C_KZG_MALLOC);
+    res->loaded = false;
+    ret = load_trusted_setup(
+        &res->settings,
+        g1.data,
+        g1.size / BYTES_PER_G1,
+        g2.data,
+        g2.size / BYTES_PER_G2
+    );
+    return make_settings(env, res, ret);
+}
+
+static ERL_NIF_TERM blob_to_kzg_commitment_nif(
+    ErlNifEnv *env, int argc, const ERL_NIF_TERM argv[]
+) {
+    ErlNifBinary blob;
+    settings_resource *res;
+    ERL_NIF_TERM out;
+    KZGCommitment *commitment;
+    C_KZG_RET ret;
+
+    GET_BINARY(env, argv[0], blob, BYTES_PER_BLOB);
+    GET_SETTINGS(env, argv[1], res);
+
+    commitment = (KZGCommitment *)enif_make_new_binary(
+        env, BYTES_PER_COMMITMENT, &out
+    );
+    if (commitment == NULL) return make_error(env, C_KZG_MALLOC);
+    ret = blob_to_kzg_commitment(
+        commitment, (const Blob *)blob.data, &res->settings
+    );
+    if (ret != C_KZG_OK) return make_error(env, ret);
+    return make_ok(env, out);
+}
+
+static ERL_NIF_TERM compute_kzg_proof_nif(
+    ErlNifEnv *env, int argc, const ERL_NIF_TERM argv[]
+) {
+    ErlNifBinary blob, z;
+    settings_resource *res;
+    ERL_NIF_TERM proof_out, y_out;
+    KZGProof *proof;
+    Bytes32 *y;
+    C_KZG_RET ret;
+
+    GET_BINARY(env, argv[0], blob, BYTES_PER_BLOB);
+    GET_BINARY(env, argv[1], z, BYTES_PER_FIELD_ELEMENT);
+    GET_SETTINGS(env, argv[2], res);
+
+    proof = (KZGProof *)enif_make_new_binary(env, BYTES_PER_PROOF, &proof_out);
+    y = (Bytes32 *)enif_make_new_binary(env, BYTES_PER_FIELD_ELEMENT, &y_out);
+    if (proof == NULL || y == NULL) return make_error(env, C_KZG_MALLOC);
+    ret = compute_kzg_proof(
+        proof,
+        y,
+        (const Blob *)blob.data,
+        (const Bytes32 *)z.data,
+        &res->settings
+    );
+    if (ret != C_KZG_OK) return make_error(env, ret);
+    return make_ok(env, enif_make_tuple2(env, proof_out, y_out));
+}
+
+static ERL_NIF_TERM compute_blob_kzg_proof_nif(
+    ErlNifEnv *env, int argc, const ERL_NIF_TERM argv[]
+) {
+    ErlNifBinary blob, commitment;
+    settings_resource *res;
+    ERL_NIF_TERM out;
+    KZGProof *proof;
+    C_KZG_RET ret;
+
+    GET_BINARY(env, argv[0], blob, BYTES_PER_BLOB);
+    GET_BINARY(env, argv[1], commitment, BYTES_PER_COMMITMENT);
+    GET_SETTINGS(env, argv[2], res);
+
+    proof = (KZGProof *)enif_make_new_binary(env, BYTES_PER_PROOF, &out);
+    if (proof == NULL) return make_error(env, C_KZG_MALLOC);
+    ret = compute_blob_kzg_proof(
+        proof,
+        (const Blob *)blob.data,
+        (const Bytes48 *)commitment.data,
+        &res->settings
+    );
+    if (ret != C_KZG_OK) return make_error(env, ret);
+    return make_ok(env, out);
+}
+
+static ERL_NIF_TERM verify_kzg_proof_nif(
+    ErlNifEnv *env, int argc, const ERL_NIF_TERM argv[]
+) {
+    ErlNifBinary commitment, z, y, proof;
+    settings_resource *res;
+    bool ok;
+    C_KZG_RET ret;
+
+    GET_BINARY(env, argv[0], commitment, BYTES_PER_COMMITMENT);
+    GET_BINARY(env, argv[1], z, BYTES_PER_FIELD_ELEMENT);
+    GET_BINARY(env, argv[2], y, BYTES_PER_FIELD_ELEMENT);
+    GET_BINARY(env, argv[3], proof, BYTES_PER_PROOF);
+    GET_SETTINGS(env, argv[4], res);
+
+    ret = verify_kzg_proof(
+        &ok,
+        (const Bytes48 *)commitment.data,
+        (const Bytes32 *)z.data,
+        (const Bytes32 *)y.data,
+        (const Bytes48 *)proof.data,
+        &res->settings
+    );
+    if (ret != C_KZG_OK) return make_error(env, ret);
+    return make_ok(env, make_bool(ok));
+}
+
+static ERL_NIF_TERM verify_blob_kzg_proof_nif(
+    ErlNifEnv *env, int argc, const ERL_NIF_TERM argv[]
+) {
+    ErlNifBinary blob, commitment, proof;
+    settings_resource *res;
+    bool ok;
+    C_KZG_RET ret;
+
+    GET_BINARY(env, argv[0], blob, BYTES_PER_BLOB);
+    GET_BINARY(env, argv[1], commitment, BYTES_PER_COMMITMENT);
+    GET_BINARY(env, argv[2], proof, BYTES_PER_PROOF);
+    GET_SETTINGS(env, argv[3], res);
+
+    ret = verify_blob_kzg_proof(
+        &ok,
+        (const Blob *)blob.data,
+        (const Bytes48 *)commitment.data,
+        (const Bytes48 *)proof.data,
+        &res->settings
+    );
+    if (ret != C_KZG_OK) return make_error(env, ret);
+    return make_ok(env, make_bool(ok));
+}
+
+/*
+ * Copies a list of n binaries of the given size into out, which has room for
+ * them. Returns 0 if an element is not a binary, -1 if it has another size.
+ */
+static int copy_list(
+    ErlNifEnv *env, ERL_NIF_TERM list, size_t size, uint8_t *out
+) {
+    ERL_NIF_TERM head;
+    ErlNifBinary bin;
+    while (enif_get_list_cell(env, list, &head, &list)) {
+        if (!enif_inspect_binary(env, head, &bin)) return 0;
+        if (bin.size != size) return -1;
+        memcpy(out, bin.data, size);
+        out += size;
+    }
+    return 1;
+}
+
+static ERL_NIF_TERM verify_blob_kzg_proof_batch_nif(
+    ErlNifEnv *env, int argc, const ERL_NIF_TERM argv[]
+) {
+    unsigned n, commitments_count, proofs_count;
+    settings_resource *res;
+    Blob *blobs = NULL;
+    Bytes48 *commitments = NULL;
+    Bytes48 *proofs = NULL;
+    ERL_NIF_TERM result;
+    bool ok;
+    C_KZG_RET ret;
+    int copied;
+
+    if (!enif_get_list_length(env, argv[0], &n) ||
+        !enif_get_list_length(env, argv[1], &commitments_count) ||
+        !enif_get_list_length(env, argv[2], &proofs_count))
+        return enif_make_badarg(env);
+    GET_SETTINGS(env, argv[3], res);
+    if (commitments_count != n || proofs_count != n)
+        return make_error(env, C_KZG_BADARGS);
+
+    if (n > 0) {
+        blobs = enif_alloc(n * sizeof(Blob));
+        commitments = enif_alloc(n * sizeof(Bytes48));
+        proofs = enif_alloc(n * sizeof(Bytes48));
+        if (blobs == NULL || commitments == NULL || proofs == NULL) {
+            result = make_error(env, C_KZG_MALLOC);
+            goto out;
+        }
+    }
+
+    copied = copy_list(env, argv[0], BYTES_PER_BLOB, (uint8_t *)blobs);
+    if (copied == 1)
+        copied = copy_list(
+            env, argv[1], BYTES_PER_COMMITMENT, (uint8_t *)commitments
+        );
+    if (copied == 1)
+        copied = copy_list(env, argv[2], BYTES_PER_PROOF, (uint8_t *)proofs);
+    if (copied == 0) {
+        result = enif_make_badarg(env);
+        goto out;
+    }
+    if (copied == -1) {
+        result = make_error(env, C_KZG_BADARGS);
+        goto out;
+    }
+
+    ret = verify_blob_kzg_proof_batch(
+        &ok, blobs, commitments, proofs, n, &res->settings
+    );
+    if (ret != C_KZG_OK) {
+        result = make_error(env, ret);
+        goto out;
+    }
+    result = make_ok(env, make_bool(ok));
+
+out:
+    if (blobs != NULL) enif_free(blobs);
+    if (commitments != NULL) enif_free(commitments);
+    if (proofs != NULL) enif_free(proofs);
+    return result;
+}
+
+static ErlNifFunc nif_funcs[] = {
+    {"load_trusted_setup_file", 1, load_trusted_setup_file_nif,
+     ERL_NIF_DIRTY_JOB_CPU_BOUND},
+    {"load_trusted_setup", 2, load_trusted_setup_nif,
+     ERL_NIF_DIRTY_JOB_CPU_BOUND},
+    {"blob_to_kzg_commitment", 2, blob_to_kzg_commitment_nif,
+     ERL_NIF_DIRTY_JOB_CPU_BOUND},
+    {"compute_kzg_proof", 3, compute_kzg_proof_nif,
+     ERL_NIF_DIRTY_JOB_CPU_BOUND},
+    {"compute_blob_kzg_proof", 3, compute_blob_kzg_proof_nif,
+     ERL_NIF_DIRTY_JOB_CPU_BOUND},
+    {"verify_kzg_proof", 5, verify_kzg_proof_nif, ERL_NIF_DIRTY_JOB_CPU_BOUND},
+    {"verify_blob_kzg_proof", 4, verify_blob_kzg_proof_nif,
+     ERL_NIF_DIRTY_JOB_CPU_BOUND},
+    {"verify_blob_kzg_proof_batch", 4, verify_blob_kzg_proof_batch_nif,
+     ERL_NIF_DIRTY_JOB_CPU_BOUND},
+};
+
+ERL_NIF_INIT(Elixir.CKZG, nif_funcs, load, NULL, NULL, NULL)
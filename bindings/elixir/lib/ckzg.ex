@@ -0,0 +1,98 @@
+defmodule CKZG do
+  @moduledoc """
+  Bindings for the KZG functions of EIP-4844, implemented by C-KZG-4844.
+
+  Values are binaries: blobs of `bytes_per_blob/0` bytes, commitments and
+  proofs of 48 bytes, and field elements of 32 bytes. The functions return
+  `{:ok, result}`, or `{:error, reason}`, where the reason is `:badargs` for
+  invalid inputs, `:malloc` if the library could not allocate memory and
+  `:internal` for unexpected errors. They raise `ArgumentError` if an argument
+  has the wrong type.
+
+  The functions run as dirty CPU NIFs, so that they do not block the normal
+  schedulers, and may be called from several processes at once with the same
+  settings.
+  """
+
+  @on_load :load_nif
+
+  @typedoc "A loaded trusted setup, which is freed when it is garbage collected."
+  @opaque settings :: reference()
+
+  @type error :: {:error, :badargs | :malloc | :internal}
+
+  @doc false
+  def load_nif do
+    path = Path.join(:code.priv_dir(:ckzg), "ckzg_nif")
+    :erlang.load_nif(String.to_charlist(path), 0)
+  end
+
+  @doc "The number of bytes in a blob."
+  def bytes_per_blob, do: 131_072
+
+  @doc "The number of bytes in a commitment."
+  def bytes_per_commitment, do: 48
+
+  @doc "The number of bytes in a field element."
+  def bytes_per_field_element, do: 32
+
+  @doc "The number of bytes in a proof."
+  def bytes_per_proof, do: 48
+
+  @doc "The number of field elements in a blob."
+  def field_elements_per_blob, do: 4096
+
+  @doc """
+  Loads a trusted setup from a file in the text format, such as
+  `src/trusted_setup.txt`. Returns `{:error, posix}` if the file cannot be
+  opened.
+  """
+  @spec load_trusted_setup_file(String.t()) ::
+          {:ok, settings()} | error() | {:error, :enoent | :eacces | :eisdir | :eio}
+  def load_trusted_setup_file(_path), do: :erlang.nif_error(:nif_not_loaded)
+
+  @doc """
+  Loads a trusted setup from its g1 points, in Lagrange form, and its g2
+  points, each concatenated.
+  """
+  @spec load_trusted_setup(binary(), binary()) :: {:ok, settings()} | error()
+  def load_trusted_setup(_g1, _g2), do: :erlang.nif_error(:nif_not_loaded)
+
+  @doc "Computes the commitment to a blob."
+  @spec blob_to_kzg_commitment(binary(), settings()) :: {:ok, binary()} | error()
+  def blob_to_kzg_commitment(_blob, _settings), do: :erlang.nif_error(:nif_not_loaded)
+
+  @doc """
+  Computes the proof of the evaluation of a blob at `z`, returning the proof
+  and the evaluation `y`.
+  """
+  @spec compute_kzg_proof(binary(), binary(), settings()) ::
+          {:ok, {binary(), binary()}} | error()
+  def compute_kzg_proof(_blob, _z, _settings), do: :erlang.nif_error(:nif_not_loaded)
+
+  @doc "Computes the proof of a blob against its commitment."
+  @spec compute_blob_kzg_proof(binary(), binary(), settings()) :: {:ok, binary()} | error()
+  def compute_blob_kzg_proof(_blob, _commitment, _settings),
+    do: :erlang.nif_error(:nif_not_loaded)
+
+  @doc "Verifies that the polynomial of a commitment evaluates to `y` at `z`."
+  @spec verify_kzg_proof(binary(), binary(), binary(), binary(), settings()) ::
+          {:ok, boolean()} | error()
+  def verify_kzg_proof(_commitment, _z, _y, _proof, _settings),
+    do: :erlang.nif_error(:nif_not_loaded)
+
+  @doc "Verifies the proof of a blob against its commitment."
+  @spec verify_blob_kzg_proof(binary(), binary(), binary(), settings()) ::
+          {:ok, boolean()} | error()
+  def verify_blob_kzg_proof(_blob, _commitment, _proof, _settings),
+    do: :erlang.nif_error(:nif_not_loaded)
+
+  @doc """
+  Verifies the proofs of blobs against their commitments at once, which is
+  faster than verifying them one by one. The lists must have the same length.
+  """
+  @spec verify_blob_kzg_proof_batch([binary()], [binary()], [binary()], settings()) ::
+          {:ok, boolean()} | error()
+  def verify_blob_kzg_proof_batch(_blobs, _commitments, _proofs, _settings),
+    do: :erlang.nif_error(:nif_not_loaded)
+end
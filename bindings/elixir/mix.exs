@@ -0,0 +1,26 @@
+defmodule CKZG.MixProject do
+  use Mix.Project
+
+  def project do
+    [
+      app: :ckzg,
+      version: "0.1.0",
+      elixir: "~> 1.14",
+      compilers: [:elixir_make] ++ Mix.compilers(),
+      make_targets: ["all"],
+      make_clean: ["clean"],
+      deps: deps()
+    ]
+  end
+
+  def application do
+    []
+  end
+
+  defp deps do
+    [
+      {:elixir_make, "~> 0.7", runtime: false},
+      {:yaml_elixir, "~> 2.9", only: :test}
+    ]
+  end
+end
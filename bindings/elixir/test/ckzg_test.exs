@@ -0,0 +1,138 @@
+defmodule CKZGTest do
+  use ExUnit.Case, async: true
+
+  @trusted_setup "../../src/trusted_setup.txt"
+  @tests "../../tests"
+
+  setup_all do
+    {:ok, settings} = CKZG.load_trusted_setup_file(@trusted_setup)
+    %{settings: settings}
+  end
+
+  defp reference_tests(name) do
+    files = Path.wildcard(Path.join([@tests, name, "*", "*", "data.yaml"]))
+    assert files != []
+    Enum.map(files, fn file -> {file, YamlElixir.read_from_file!(file)} end)
+  end
+
+  defp bytes("0x" <> hex), do: Base.decode16!(hex, case: :mixed)
+
+  defp assert_result(result, nil, file), do: assert(match?({:error, _}, result), file)
+  defp assert_result(result, expected, file), do: assert(result == {:ok, expected}, file)
+
+  test "blob_to_kzg_commitment", %{settings: settings} do
+    for {file, %{"input" => input, "output" => output}} <-
+          reference_tests("blob_to_kzg_commitment") do
+      result = CKZG.blob_to_kzg_commitment(bytes(input["blob"]), settings)
+      assert_result(result, output && bytes(output), file)
+    end
+  end
+
+  test "compute_kzg_proof", %{settings: settings} do
+    for {file, %{"input" => input, "output" => output}} <- reference_tests("compute_kzg_proof") do
+      result = CKZG.compute_kzg_proof(bytes(input["blob"]), bytes(input["z"]), settings)
+      expected = if output, do: {bytes(Enum.at(output, 0)), bytes(Enum.at(output, 1))}
+      assert_result(result, expected, file)
+    end
+  end
+
+  test "compute_blob_kzg_proof", %{settings: settings} do
+    for {file, %{"input" => input, "output" => output}} <-
+          reference_tests("compute_blob_kzg_proof") do
+      result =
+        CKZG.compute_blob_kzg_proof(bytes(input["blob"]), bytes(input["commitment"]), settings)
+
+      assert_result(result, output && bytes(output), file)
+    end
+  end
+
+  test "verify_kzg_proof", %{settings: settings} do
+    for {file, %{"input" => input, "output" => output}} <- reference_tests("verify_kzg_proof") do
+      result =
+        CKZG.verify_kzg_proof(
+          bytes(input["commitment"]),
+          bytes(input["z"]),
+          bytes(input["y"]),
+          bytes(input["proof"]),
+          settings
+        )
+
+      assert_result(result, output, file)
+    end
+  end
+
+  test "verify_blob_kzg_proof", %{settings: settings} do
+    for {file, %{"input" => input, "output" => output}} <-
+          reference_tests("verify_blob_kzg_proof") do
+      result =
+        CKZG.verify_blob_kzg_proof(
+          bytes(input["blob"]),
+          bytes(input["commitment"]),
+          bytes(input["proof"]),
+          settings
+        )
+
+      assert_result(result, output, file)
+    end
+  end
+
+  test "verify_blob_kzg_proof_batch", %{settings: settings} do
+    for {file, %{"input" => input, "output" => output}} <-
+          reference_tests("verify_blob_kzg_proof_batch") do
+      result =
+        CKZG.verify_blob_kzg_proof_batch(
+          Enum.map(input["blobs"], &bytes/1),
+          Enum.map(input["commitments"], &bytes/1),
+          Enum.map(input["proofs"], &bytes/1),
+          settings
+        )
+
+      assert_result(result, output, file)
+    end
+  end
+
+  test "batches of different lengths are bad arguments", %{settings: settings} do
+    blob = :binary.copy(<<0>>, CKZG.bytes_per_blob())
+    {:ok, commitment} = CKZG.blob_to_kzg_commitment(blob, settings)
+    {:ok, proof} = CKZG.compute_blob_kzg_proof(blob, commitment, settings)
+
+    assert CKZG.verify_blob_kzg_proof_batch([blob], [commitment], [proof], settings) ==
+             {:ok, true}
+
+    assert CKZG.verify_blob_kzg_proof_batch([blob], [commitment], [], settings) ==
+             {:error, :badargs}
+
+    assert CKZG.verify_blob_kzg_proof_batch([], [], [], settings) == {:ok, true}
+  end
+
+  test "arguments of the wrong type raise", %{settings: settings} do
+    assert_raise ArgumentError, fn -> CKZG.blob_to_kzg_commitment(:blob, settings) end
+    assert_raise ArgumentError, fn -> CKZG.blob_to_kzg_commitment(<<>>, make_ref()) end
+    assert_raise ArgumentError, fn -> CKZG.verify_blob_kzg_proof_batch(:a, [], [], settings) end
+  end
+
+  test "load_trusted_setup matches load_trusted_setup_file", %{settings: settings} do
+    [g1_count, g2_count | points] = @trusted_setup |> File.read!() |> String.split()
+    {g1, g2} = Enum.split(points, String.to_integer(g1_count))
+    assert length(g2) == String.to_integer(g2_count)
+    g1 = g1 |> Enum.map(&Base.decode16!(&1, case: :mixed)) |> IO.iodata_to_binary()
+    g2 = g2 |> Enum.map(&Base.decode16!(&1, case: :mixed)) |> IO.iodata_to_binary()
+    {:ok, loaded} = CKZG.load_trusted_setup(g1, g2)
+
+    blob = :binary.copy(<<0, 1>>, div(CKZG.bytes_per_blob(), 2))
+    assert CKZG.blob_to_kzg_commitment(blob, loaded) ==
+             CKZG.blob_to_kzg_commitment(blob, settings)
+
+    assert CKZG.load_trusted_setup(binary_part(g1, 0, 47), g2) == {:error, :badargs}
+    assert CKZG.load_trusted_setup_file("missing.txt") == {:error, :enoent}
+  end
+
+  test "calls run concurrently", %{settings: settings} do
+    blob = :binary.copy(<<0>>, CKZG.bytes_per_blob())
+    {:ok, expected} = CKZG.blob_to_kzg_commitment(blob, settings)
+
+    1..8
+    |> Task.async_stream(fn _ -> CKZG.blob_to_kzg_commitment(blob, settings) end)
+    |> Enum.each(fn result -> assert result == {:ok, {:ok, expected}} end)
+  end
+end
@@ -0,0 +1 @@
+ExUnit.start()
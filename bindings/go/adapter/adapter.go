@@ -0,0 +1,173 @@
+// Package adapter converts the input types used by the major Go Ethereum
+// clients into the types of this package, without depending on those
+// clients.
+//
+// Execution clients (geth-style) pass fixed-size named arrays such as
+// common.Hash, kzg4844.Blob, kzg4844.Commitment and kzg4844.Proof; these are
+// accepted through type parameters constrained by their underlying type.
+// Consensus clients (prysm-style) pass protobuf byte slices and hex strings;
+// these are accepted as []byte or string, with lengths checked.
+package adapter
+
+import (
+	"fmt"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+)
+
+// BlobArray is satisfied by any blob type backed by a byte array, such as
+// geth's kzg4844.Blob.
+type BlobArray interface {
+	~[ckzg4844.BytesPerBlob]byte
+}
+
+// G1Array is satisfied by any commitment or proof type backed by a 48-byte
+// array, such as geth's kzg4844.Commitment and kzg4844.Proof.
+type G1Array interface {
+	~[48]byte
+}
+
+// Hash is satisfied by any 32-byte array type, such as common.Hash.
+type Hash interface {
+	~[32]byte
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Execution Client (Array) Inputs
+///////////////////////////////////////////////////////////////////////////////
+
+// Bytes32FromHash converts a 32-byte hash, e.g. common.Hash or a versioned
+// hash.
+func Bytes32FromHash[H Hash](h H) ckzg4844.Bytes32 {
+	return ckzg4844.Bytes32(h)
+}
+
+// Bytes32sFromHashes converts a slice of 32-byte hashes.
+func Bytes32sFromHashes[H Hash](hashes []H) []ckzg4844.Bytes32 {
+	out := make([]ckzg4844.Bytes32, len(hashes))
+	for i := range hashes {
+		out[i] = ckzg4844.Bytes32(hashes[i])
+	}
+	return out
+}
+
+// Bytes48sFromArrays converts a slice of commitments or proofs.
+func Bytes48sFromArrays[G G1Array](points []G) []ckzg4844.Bytes48 {
+	out := make([]ckzg4844.Bytes48, len(points))
+	for i := range points {
+		out[i] = ckzg4844.Bytes48(points[i])
+	}
+	return out
+}
+
+// BlobsFromArrays converts a slice of blobs.
+func BlobsFromArrays[B BlobArray](blobs []B) []ckzg4844.Blob {
+	out := make([]ckzg4844.Blob, len(blobs))
+	for i := range blobs {
+		out[i] = ckzg4844.Blob(blobs[i])
+	}
+	return out
+}
+
+// FromBlobTxSidecar converts the fields of a geth-style blob transaction
+// sidecar (Blobs, Commitments, Proofs) into arguments for
+// ckzg4844.VerifyBlobKZGProofBatch. The slices must have equal lengths.
+func FromBlobTxSidecar[B BlobArray, C G1Array, P G1Array](blobs []B, commitments []C, proofs []P) ([]ckzg4844.Blob, []ckzg4844.Bytes48, []ckzg4844.Bytes48, error) {
+	if len(blobs) != len(commitments) || len(blobs) != len(proofs) {
+		return nil, nil, nil, fmt.Errorf("%w: %v blobs, %v commitments, %v proofs",
+			ckzg4844.ErrBadArgs, len(blobs), len(commitments), len(proofs))
+	}
+	return BlobsFromArrays(blobs), Bytes48sFromArrays(commitments), Bytes48sFromArrays(proofs), nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Consensus Client (Byte Slice) Inputs
+///////////////////////////////////////////////////////////////////////////////
+
+func copyExact(dst, src []byte, name string) error {
+	if len(src) != len(dst) {
+		return fmt.Errorf("%w: %v must be %v bytes, got %v", ckzg4844.ErrBadArgs, name, len(dst), len(src))
+	}
+	copy(dst, src)
+	return nil
+}
+
+// Bytes32FromBytes converts a byte slice (e.g. hexutil.Bytes) of exactly 32
+// bytes.
+func Bytes32FromBytes(b []byte) (ckzg4844.Bytes32, error) {
+	var out ckzg4844.Bytes32
+	err := copyExact(out[:], b, "field element")
+	return out, err
+}
+
+// Bytes48FromBytes converts a byte slice (e.g. hexutil.Bytes or a protobuf
+// bytes field) of exactly 48 bytes.
+func Bytes48FromBytes(b []byte) (ckzg4844.Bytes48, error) {
+	var out ckzg4844.Bytes48
+	err := copyExact(out[:], b, "commitment/proof")
+	return out, err
+}
+
+// BlobFromBytes converts a byte slice of exactly BytesPerBlob bytes.
+func BlobFromBytes(b []byte) (*ckzg4844.Blob, error) {
+	blob := new(ckzg4844.Blob)
+	if err := copyExact(blob[:], b, "blob"); err != nil {
+		return nil, err
+	}
+	return blob, nil
+}
+
+// FromBlobSidecar converts the blob, commitment and proof fields of a
+// prysm-style BlobSidecar into arguments for ckzg4844.VerifyBlobKZGProof.
+func FromBlobSidecar(blob, commitment, proof []byte) (*ckzg4844.Blob, ckzg4844.Bytes48, ckzg4844.Bytes48, error) {
+	b, err := BlobFromBytes(blob)
+	if err != nil {
+		return nil, ckzg4844.Bytes48{}, ckzg4844.Bytes48{}, err
+	}
+	c, err := Bytes48FromBytes(commitment)
+	if err != nil {
+		return nil, ckzg4844.Bytes48{}, ckzg4844.Bytes48{}, err
+	}
+	p, err := Bytes48FromBytes(proof)
+	if err != nil {
+		return nil, ckzg4844.Bytes48{}, ckzg4844.Bytes48{}, err
+	}
+	return b, c, p, nil
+}
+
+// FromBlobSidecars converts the fields of many prysm-style BlobSidecars,
+// given as parallel slices, into arguments for
+// ckzg4844.VerifyBlobKZGProofBatch.
+func FromBlobSidecars(blobs, commitments, proofs [][]byte) ([]ckzg4844.Blob, []ckzg4844.Bytes48, []ckzg4844.Bytes48, error) {
+	if len(blobs) != len(commitments) || len(blobs) != len(proofs) {
+		return nil, nil, nil, fmt.Errorf("%w: %v blobs, %v commitments, %v proofs",
+			ckzg4844.ErrBadArgs, len(blobs), len(commitments), len(proofs))
+	}
+	outBlobs := make([]ckzg4844.Blob, len(blobs))
+	outCommitments := make([]ckzg4844.Bytes48, len(blobs))
+	outProofs := make([]ckzg4844.Bytes48, len(blobs))
+	for i := range blobs {
+		if err := copyExact(outBlobs[i][:], blobs[i], fmt.Sprintf("blob %v", i)); err != nil {
+			return nil, nil, nil, err
+		}
+		if err := copyExact(outCommitments[i][:], commitments[i], fmt.Sprintf("commitment %v", i)); err != nil {
+			return nil, nil, nil, err
+		}
+		if err := copyExact(outProofs[i][:], proofs[i], fmt.Sprintf("proof %v", i)); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	return outBlobs, outCommitments, outProofs, nil
+}
+
+// Bytes48FromHex converts a 0x-prefixed hex string, as found in JSON-RPC
+// payloads.
+func Bytes48FromHex(s string) (ckzg4844.Bytes48, error) {
+	return ckzg4844.NewBytes48FromHex(s)
+}
+
+// Bytes32FromHex converts a 0x-prefixed hex string, as found in JSON-RPC
+// payloads.
+func Bytes32FromHex(s string) (ckzg4844.Bytes32, error) {
+	return ckzg4844.NewBytes32FromHex(s)
+}
@@ -0,0 +1,72 @@
+package adapter
+
+import (
+	"strings"
+	"testing"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+	"github.com/stretchr/testify/require"
+)
+
+// Stand-ins for the client types, which have the same underlying types.
+type (
+	hash       [32]byte
+	blob       [ckzg4844.BytesPerBlob]byte
+	commitment [48]byte
+	proof      [48]byte
+)
+
+func TestFromBlobTxSidecar(t *testing.T) {
+	blobs := []blob{{1}, {2}}
+	commitments := []commitment{{3}, {4}}
+	proofs := []proof{{5}, {6}}
+
+	gotBlobs, gotCommitments, gotProofs, err := FromBlobTxSidecar(blobs, commitments, proofs)
+	require.NoError(t, err)
+	require.Len(t, gotBlobs, 2)
+	require.Equal(t, byte(2), gotBlobs[1][0])
+	require.Equal(t, []ckzg4844.Bytes48{{3}, {4}}, gotCommitments)
+	require.Equal(t, []ckzg4844.Bytes48{{5}, {6}}, gotProofs)
+
+	_, _, _, err = FromBlobTxSidecar(blobs, commitments[:1], proofs)
+	require.ErrorIs(t, err, ckzg4844.ErrBadArgs)
+}
+
+func TestHashes(t *testing.T) {
+	require.Equal(t, ckzg4844.Bytes32{7}, Bytes32FromHash(hash{7}))
+	require.Equal(t, []ckzg4844.Bytes32{{8}, {9}}, Bytes32sFromHashes([]hash{{8}, {9}}))
+}
+
+func TestFromBlobSidecars(t *testing.T) {
+	blobs := [][]byte{make([]byte, ckzg4844.BytesPerBlob)}
+	commitments := [][]byte{make([]byte, 48)}
+	proofs := [][]byte{make([]byte, 48)}
+	blobs[0][0] = 1
+
+	gotBlobs, gotCommitments, gotProofs, err := FromBlobSidecars(blobs, commitments, proofs)
+	require.NoError(t, err)
+	require.Equal(t, byte(1), gotBlobs[0][0])
+	require.Len(t, gotCommitments, 1)
+	require.Len(t, gotProofs, 1)
+
+	b, c, p, err := FromBlobSidecar(blobs[0], commitments[0], proofs[0])
+	require.NoError(t, err)
+	require.Equal(t, gotBlobs[0], *b)
+	require.Equal(t, gotCommitments[0], c)
+	require.Equal(t, gotProofs[0], p)
+
+	_, _, _, err = FromBlobSidecars(blobs, [][]byte{make([]byte, 47)}, proofs)
+	require.ErrorIs(t, err, ckzg4844.ErrBadArgs)
+	_, _, _, err = FromBlobSidecar(blobs[0][1:], commitments[0], proofs[0])
+	require.ErrorIs(t, err, ckzg4844.ErrBadArgs)
+	_, err = Bytes32FromBytes(make([]byte, 31))
+	require.ErrorIs(t, err, ckzg4844.ErrBadArgs)
+}
+
+func TestFromHex(t *testing.T) {
+	b, err := Bytes48FromHex("0xc0" + strings.Repeat("00", 47))
+	require.NoError(t, err)
+	require.Equal(t, ckzg4844.Bytes48{0xc0}, b)
+	_, err = Bytes32FromHex("0x01")
+	require.ErrorIs(t, err, ckzg4844.ErrBadArgs)
+}
@@ -0,0 +1,30 @@
+//go:build cgo && !ckzg_nocgo && ckzg_alloc_hooks
+
+package ckzg4844
+
+// #cgo CFLAGS: -DC_KZG_ALLOC_HOOKS
+// extern int c_kzg_alloc_failure_countdown;
+// extern int c_kzg_alloc_outstanding;
+import "C"
+
+// The functions below drive the allocation hooks of the C library, which
+// are only compiled in with the ckzg_alloc_hooks build tag. They let tests
+// exercise the C_KZG_MALLOC paths. Like the hooks, they are not thread-safe.
+
+// failAllocation makes the allocation after the next n fail. A negative n
+// stops failure injection.
+func failAllocation(n int) {
+	C.c_kzg_alloc_failure_countdown = C.int(n)
+}
+
+// allocationFailed reports whether the allocation set up by failAllocation
+// has been made, and so has failed.
+func allocationFailed() bool {
+	return C.c_kzg_alloc_failure_countdown < 0
+}
+
+// outstandingAllocations returns the number of C allocations that have not
+// been freed yet.
+func outstandingAllocations() int {
+	return int(C.c_kzg_alloc_outstanding)
+}
@@ -0,0 +1,82 @@
+//go:build cgo && !ckzg_nocgo && ckzg_alloc_hooks
+
+// The allocation failure tests only build with the ckzg_alloc_hooks tag:
+//
+//	go test -tags ckzg_alloc_hooks -run Allocation
+
+package ckzg4844
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// requireAllocationFailures calls f with the first allocation failing, then
+// the second, and so on until f makes no more allocations than were allowed.
+// Every failing call must return ErrMalloc and free what it allocated, and
+// the last call must succeed. It returns the number of failing calls.
+func requireAllocationFailures(t *testing.T, f func() error) int {
+	defer failAllocation(-1)
+	for n := 0; ; n++ {
+		before := outstandingAllocations()
+		failAllocation(n)
+		err := f()
+		if !allocationFailed() {
+			require.NoError(t, err)
+			return n
+		}
+		require.ErrorIs(t, err, ErrMalloc, "allocation %v", n)
+		require.Equal(t, before, outstandingAllocations(), "leak after failing allocation %v", n)
+	}
+}
+
+func TestAllocationFailureLoadTrustedSetup(t *testing.T) {
+	g1Bytes, g2Bytes := TrustedSetupBytes()
+	FreeTrustedSetup()
+	before := outstandingAllocations()
+
+	n := requireAllocationFailures(t, func() error {
+		err := LoadTrustedSetup(g1Bytes, g2Bytes)
+		if err != nil {
+			require.Equal(t, before, outstandingAllocations())
+		}
+		return err
+	})
+	require.NotZero(t, n)
+
+	FreeTrustedSetup()
+	require.Equal(t, before, outstandingAllocations())
+	require.NoError(t, LoadTrustedSetup(g1Bytes, g2Bytes))
+}
+
+func TestAllocationFailureOperations(t *testing.T) {
+	blobs, commitments, proofs, _ := getBundle(t, 2)
+	z := getRandFieldElement(1)
+
+	before := outstandingAllocations()
+	failures := 0
+	failures += requireAllocationFailures(t, func() error {
+		_, err := BlobToKZGCommitment(&blobs[0])
+		return err
+	})
+	failures += requireAllocationFailures(t, func() error {
+		_, _, err := ComputeKZGProof(&blobs[0], z)
+		return err
+	})
+	failures += requireAllocationFailures(t, func() error {
+		_, err := ComputeBlobKZGProof(&blobs[1], commitments[1])
+		return err
+	})
+	failures += requireAllocationFailures(t, func() error {
+		_, err := VerifyBlobKZGProof(&blobs[1], commitments[1], proofs[1])
+		return err
+	})
+	failures += requireAllocationFailures(t, func() error {
+		ok, err := VerifyBlobKZGProofBatch(blobs[1:], commitments[1:], proofs[1:])
+		require.True(t, err != nil || ok)
+		return err
+	})
+	require.NotZero(t, failures)
+	require.Equal(t, before, outstandingAllocations())
+}
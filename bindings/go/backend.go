@@ -0,0 +1,43 @@
+package ckzg4844
+
+// Backend is the set of EIP-4844 operations, with the signatures of the
+// functions of this package. It lets code be written against, and tested
+// with, other implementations of the same operations.
+type Backend interface {
+	BlobToKZGCommitment(blob *Blob) (KZGCommitment, error)
+	ComputeKZGProof(blob *Blob, zBytes Bytes32) (KZGProof, Bytes32, error)
+	ComputeBlobKZGProof(blob *Blob, commitmentBytes Bytes48) (KZGProof, error)
+	VerifyKZGProof(commitmentBytes Bytes48, zBytes, yBytes Bytes32, proofBytes Bytes48) (bool, error)
+	VerifyBlobKZGProof(blob *Blob, commitmentBytes, proofBytes Bytes48) (bool, error)
+	VerifyBlobKZGProofBatch(blobs []Blob, commitmentsBytes, proofsBytes []Bytes48) (bool, error)
+}
+
+// DefaultBackend is the Backend implemented by the functions of this
+// package, using the loaded trusted setup.
+var DefaultBackend Backend = cBackend{}
+
+type cBackend struct{}
+
+func (cBackend) BlobToKZGCommitment(blob *Blob) (KZGCommitment, error) {
+	return BlobToKZGCommitment(blob)
+}
+
+func (cBackend) ComputeKZGProof(blob *Blob, zBytes Bytes32) (KZGProof, Bytes32, error) {
+	return ComputeKZGProof(blob, zBytes)
+}
+
+func (cBackend) ComputeBlobKZGProof(blob *Blob, commitmentBytes Bytes48) (KZGProof, error) {
+	return ComputeBlobKZGProof(blob, commitmentBytes)
+}
+
+func (cBackend) VerifyKZGProof(commitmentBytes Bytes48, zBytes, yBytes Bytes32, proofBytes Bytes48) (bool, error) {
+	return VerifyKZGProof(commitmentBytes, zBytes, yBytes, proofBytes)
+}
+
+func (cBackend) VerifyBlobKZGProof(blob *Blob, commitmentBytes, proofBytes Bytes48) (bool, error) {
+	return VerifyBlobKZGProof(blob, commitmentBytes, proofBytes)
+}
+
+func (cBackend) VerifyBlobKZGProofBatch(blobs []Blob, commitmentsBytes, proofsBytes []Bytes48) (bool, error) {
+	return VerifyBlobKZGProofBatch(blobs, commitmentsBytes, proofsBytes)
+}
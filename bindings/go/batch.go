@@ -0,0 +1,139 @@
+package ckzg4844
+
+// BatchMode selects how much work batch functions do once an entry fails.
+type BatchMode int
+
+const (
+	// FullReport checks every entry and reports all failures.
+	FullReport BatchMode = iota
+	// EarlyAbort stops at the first failing entry, by index. Later entries
+	// are not checked.
+	EarlyAbort
+)
+
+// fillNotChecked sets every nil error after the first non-nil one to
+// ErrNotChecked.
+func fillNotChecked(errs []error) {
+	for i, err := range errs {
+		if err != nil {
+			for j := i + 1; j < len(errs); j++ {
+				errs[j] = ErrNotChecked
+			}
+			return
+		}
+	}
+}
+
+// bisectBlobKZGProofBatch appends to failed the offset-adjusted indices of
+// the entries that make the batch fail, by verifying halves of a failing
+// batch recursively. An entry fails if its proof does not verify or if its
+// inputs are malformed. With firstOnly set it stops at the first failing
+// entry.
+func bisectBlobKZGProofBatch(blobs []Blob, commitmentsBytes, proofsBytes []Bytes48, offset int, firstOnly bool, failed []int) []int {
+	if len(blobs) == 0 {
+		return failed
+	}
+	if ok, err := VerifyBlobKZGProofBatch(blobs, commitmentsBytes, proofsBytes); err == nil && ok {
+		return failed
+	}
+	if len(blobs) == 1 {
+		logf(LogLevelDebug, "batch entry %v failed", offset)
+		return append(failed, offset)
+	}
+	mid := len(blobs) / 2
+	logf(LogLevelDebug, "batch entries [%v, %v) failed, splitting at %v", offset, offset+len(blobs), offset+mid)
+	failed = bisectBlobKZGProofBatch(blobs[:mid], commitmentsBytes[:mid], proofsBytes[:mid], offset, firstOnly, failed)
+	if firstOnly && len(failed) != 0 {
+		return failed
+	}
+	return bisectBlobKZGProofBatch(blobs[mid:], commitmentsBytes[mid:], proofsBytes[mid:], offset+mid, firstOnly, failed)
+}
+
+/*
+FindInvalidBlobKZGProofs is like VerifyBlobKZGProofBatch, but instead of a
+bool it returns the indices of the entries that fail, in ascending order. An
+entry fails if its proof does not verify or if its blob, commitment or proof
+is malformed. When the batch is valid the result is nil, at the cost of a
+single batch verification; otherwise failing batches are split in half and
+verified again until every failing entry is isolated. In EarlyAbort mode only
+the first failing entry is returned, which needs fewer verifications.
+
+It only returns an error, ErrBadArgs, if the input lengths differ.
+*/
+func FindInvalidBlobKZGProofs(blobs []Blob, commitmentsBytes, proofsBytes []Bytes48, mode BatchMode) ([]int, error) {
+	if !isLoaded() {
+		panic("trusted setup isn't loaded")
+	}
+	if len(blobs) != len(commitmentsBytes) || len(blobs) != len(proofsBytes) {
+		return nil, ErrBadArgs
+	}
+	return bisectBlobKZGProofBatch(blobs, commitmentsBytes, proofsBytes, 0, mode == EarlyAbort, nil), nil
+}
+
+/*
+CheckBlobKZGProofs verifies each blob proof and returns one error per blob:
+nil if the proof verifies, ErrInvalidProof if it does not, or an error
+wrapping ErrBadArgs if the inputs of that entry are malformed. An entry
+without a commitment or proof, because the slices have different lengths,
+gets ErrBadArgs.
+
+The entries are first verified as a batch, so for valid input this costs
+about as much as VerifyBlobKZGProofBatch. Each failing entry costs some
+extra batch verifications and a single verification. In EarlyAbort mode only
+the first failing entry is attributed and the entries after it get
+ErrNotChecked.
+*/
+func CheckBlobKZGProofs(blobs []Blob, commitmentsBytes, proofsBytes []Bytes48, mode BatchMode) []error {
+	if !isLoaded() {
+		panic("trusted setup isn't loaded")
+	}
+	n := len(blobs)
+	if len(commitmentsBytes) < n {
+		n = len(commitmentsBytes)
+	}
+	if len(proofsBytes) < n {
+		n = len(proofsBytes)
+	}
+
+	errs := make([]error, len(blobs))
+	for i := n; i < len(blobs); i++ {
+		errs[i] = ErrBadArgs
+	}
+	for _, i := range bisectBlobKZGProofBatch(blobs[:n], commitmentsBytes[:n], proofsBytes[:n], 0, mode == EarlyAbort, nil) {
+		ok, err := VerifyBlobKZGProof(&blobs[i], commitmentsBytes[i], proofsBytes[i])
+		if err == nil && !ok {
+			err = ErrInvalidProof
+		}
+		errs[i] = err
+	}
+	if mode == EarlyAbort {
+		fillNotChecked(errs)
+	}
+	return errs
+}
+
+// VerifyBlobKZGProofs is like CheckBlobKZGProofs in FullReport mode, but
+// reports for each blob only whether its proof verifies.
+func VerifyBlobKZGProofs(blobs []Blob, commitmentsBytes, proofsBytes []Bytes48) []bool {
+	errs := CheckBlobKZGProofs(blobs, commitmentsBytes, proofsBytes, FullReport)
+	results := make([]bool, len(errs))
+	for i, err := range errs {
+		results[i] = err == nil
+	}
+	return results
+}
+
+// ValidateBlobs is the batch form of ValidateBlob. It returns one error per
+// blob, naming the first non-canonical field element of each invalid blob. In
+// EarlyAbort mode the blobs after the first invalid one get ErrNotChecked.
+func ValidateBlobs(blobs []Blob, mode BatchMode) []error {
+	errs := make([]error, len(blobs))
+	for i := range blobs {
+		errs[i] = ValidateBlob(&blobs[i])
+		if errs[i] != nil && mode == EarlyAbort {
+			fillNotChecked(errs)
+			break
+		}
+	}
+	return errs
+}
@@ -0,0 +1,90 @@
+package ckzg4844
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindInvalidBlobKZGProofs(t *testing.T) {
+	blobs, commitments, proofs, _ := getBundle(t, 7)
+
+	failed, err := FindInvalidBlobKZGProofs(blobs, commitments, proofs, FullReport)
+	require.NoError(t, err)
+	require.Nil(t, failed)
+
+	failed, err = FindInvalidBlobKZGProofs(nil, nil, nil, FullReport)
+	require.NoError(t, err)
+	require.Nil(t, failed)
+
+	_, err = FindInvalidBlobKZGProofs(blobs, commitments[:6], proofs, FullReport)
+	require.ErrorIs(t, err, ErrBadArgs)
+
+	// Swapping two proofs invalidates both entries, and a proof that is not a
+	// valid point is reported as well.
+	proofs[1], proofs[4] = proofs[4], proofs[1]
+	proofs[6] = Bytes48{}
+	failed, err = FindInvalidBlobKZGProofs(blobs, commitments, proofs, FullReport)
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 4, 6}, failed)
+
+	failed, err = FindInvalidBlobKZGProofs(blobs, commitments, proofs, EarlyAbort)
+	require.NoError(t, err)
+	require.Equal(t, []int{1}, failed)
+}
+
+func TestCheckBlobKZGProofs(t *testing.T) {
+	blobs, commitments, proofs, _ := getBundle(t, 4)
+
+	errs := CheckBlobKZGProofs(blobs, commitments, proofs, FullReport)
+	require.Equal(t, []error{nil, nil, nil, nil}, errs)
+	require.Equal(t, []bool{true, true, true, true}, VerifyBlobKZGProofs(blobs, commitments, proofs))
+	require.Empty(t, CheckBlobKZGProofs(nil, nil, nil, FullReport))
+
+	proofs[0] = proofs[1]
+	commitments[2] = Bytes48{}
+	errs = CheckBlobKZGProofs(blobs, commitments, proofs[:3], FullReport)
+	require.ErrorIs(t, errs[0], ErrInvalidProof)
+	require.NoError(t, errs[1])
+	require.ErrorIs(t, errs[2], ErrBadArgs)
+	require.EqualError(t, errs[2], "bad arguments: commitment is not a valid G1 point")
+	require.ErrorIs(t, errs[3], ErrBadArgs)
+	require.Equal(t, []bool{false, true, false, false}, VerifyBlobKZGProofs(blobs, commitments, proofs[:3]))
+}
+
+func TestCheckBlobKZGProofsEarlyAbort(t *testing.T) {
+	blobs, commitments, proofs, _ := getBundle(t, 4)
+	require.Equal(t, []error{nil, nil, nil, nil}, CheckBlobKZGProofs(blobs, commitments, proofs, EarlyAbort))
+
+	proofs[1], proofs[3] = proofs[3], proofs[1]
+	errs := CheckBlobKZGProofs(blobs, commitments, proofs, EarlyAbort)
+	require.NoError(t, errs[0])
+	require.ErrorIs(t, errs[1], ErrInvalidProof)
+	require.ErrorIs(t, errs[2], ErrNotChecked)
+	require.ErrorIs(t, errs[3], ErrNotChecked)
+
+	// A missing proof is the first failure when the others verify.
+	proofs[1], proofs[3] = proofs[3], proofs[1]
+	errs = CheckBlobKZGProofs(blobs, commitments, proofs[:2], EarlyAbort)
+	require.Equal(t, []error{nil, nil, ErrBadArgs, ErrNotChecked}, errs)
+}
+
+func TestValidateBlobs(t *testing.T) {
+	blobs := make([]Blob, 3)
+	for i := range blobs {
+		fillBlobRandom(&blobs[i], int64(i))
+	}
+	require.Equal(t, []error{nil, nil, nil}, ValidateBlobs(blobs, FullReport))
+
+	blobs[0][0] = 0xff
+	blobs[2][BytesPerFieldElement] = 0xff
+	errs := ValidateBlobs(blobs, FullReport)
+	require.EqualError(t, errs[0], "bad arguments: blob field element 0 is not canonical")
+	require.NoError(t, errs[1])
+	require.EqualError(t, errs[2], "bad arguments: blob field element 1 is not canonical")
+
+	errs = ValidateBlobs(blobs, EarlyAbort)
+	require.ErrorIs(t, errs[0], ErrBadArgs)
+	require.ErrorIs(t, errs[1], ErrNotChecked)
+	require.ErrorIs(t, errs[2], ErrNotChecked)
+}
@@ -0,0 +1,118 @@
+// Package baseline records benchmark results of the bench package as a
+// baseline in a JSON file and fails tests when later results are slower
+// than the baseline by more than a threshold, so regressions are caught
+// between releases.
+//
+// Timings depend on the machine, so a baseline is only meaningful on the
+// machine that recorded it.
+package baseline
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"testing"
+
+	"github.com/ethereum/c-kzg-4844/bindings/go/bench"
+)
+
+// DefaultThreshold is the slowdown tolerated by DefaultCheck: 20%, which is
+// above the noise of repeated runs on an idle machine.
+const DefaultThreshold = 0.2
+
+// Regression is a benchmark that is slower than its baseline allows.
+type Regression struct {
+	Name            string
+	BaselineNsPerOp int64
+	NsPerOp         int64
+}
+
+// Slowdown returns how much slower the benchmark got, as a fraction of the
+// baseline.
+func (r Regression) Slowdown() float64 {
+	return float64(r.NsPerOp-r.BaselineNsPerOp) / float64(r.BaselineNsPerOp)
+}
+
+func (r Regression) String() string {
+	return fmt.Sprintf("%v: %v ns/op, %.1f%% slower than the baseline of %v ns/op",
+		r.Name, r.NsPerOp, 100*r.Slowdown(), r.BaselineNsPerOp)
+}
+
+// Read reads a baseline written by Write, or by bench.WriteJSON.
+func Read(path string) ([]bench.Result, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var results []bench.Result
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, fmt.Errorf("baseline %v: %w", path, err)
+	}
+	return results, nil
+}
+
+// Write records results as the baseline at path, replacing any previous one.
+func Write(path string, results []bench.Result) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := bench.WriteJSON(f, results); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Compare returns the results that are slower than the result of the same
+// name in baseline by more than threshold, a fraction of the baseline, in
+// the order of results. Results without a baseline are ignored.
+func Compare(baseline, results []bench.Result, threshold float64) []Regression {
+	baselineNsPerOp := make(map[string]int64, len(baseline))
+	for _, r := range baseline {
+		baselineNsPerOp[r.Name] = r.NsPerOp
+	}
+	var regressions []Regression
+	for _, r := range results {
+		base, ok := baselineNsPerOp[r.Name]
+		if !ok || base <= 0 {
+			continue
+		}
+		if float64(r.NsPerOp) > float64(base)*(1+threshold) {
+			regressions = append(regressions, Regression{Name: r.Name, BaselineNsPerOp: base, NsPerOp: r.NsPerOp})
+		}
+	}
+	return regressions
+}
+
+/*
+Check compares results with the baseline at path and reports every
+regression beyond threshold as an error of t. If there is no baseline yet,
+results are recorded as the baseline instead. To record a new baseline,
+for example after an intended slowdown, remove the file or call Write.
+*/
+func Check(t testing.TB, path string, results []bench.Result, threshold float64) {
+	t.Helper()
+	baseline, err := Read(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		if err := Write(path, results); err != nil {
+			t.Fatalf("failed to record baseline: %v", err)
+		}
+		t.Logf("recorded baseline %v", path)
+		return
+	}
+	if err != nil {
+		t.Fatalf("failed to read baseline: %v", err)
+	}
+	for _, regression := range Compare(baseline, results, threshold) {
+		t.Errorf("regression: %v", regression)
+	}
+}
+
+// DefaultCheck is Check with DefaultThreshold.
+func DefaultCheck(t testing.TB, path string, results []bench.Result) {
+	t.Helper()
+	Check(t, path, results, DefaultThreshold)
+}
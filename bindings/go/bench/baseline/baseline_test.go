@@ -0,0 +1,83 @@
+package baseline
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+	"github.com/ethereum/c-kzg-4844/bindings/go/bench"
+	"github.com/stretchr/testify/require"
+)
+
+var baselineFile = flag.String("baseline.file", "", "baseline to check the benchmark suite against, recorded if missing")
+
+// recorder is a testing.TB that records errors instead of failing.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestCompare(t *testing.T) {
+	baseline := []bench.Result{
+		{Name: "Recover/missing=0.5", NsPerOp: 1000},
+		{Name: "VerifyBlobKZGProof", NsPerOp: 2000},
+		{Name: "BlobToKZGCommitment", NsPerOp: 0},
+	}
+	results := []bench.Result{
+		{Name: "VerifyBlobKZGProof", NsPerOp: 2300},
+		{Name: "Recover/missing=0.5", NsPerOp: 1100},
+		{Name: "BlobToKZGCommitment", NsPerOp: 5000},
+		{Name: "ComputeKZGProof", NsPerOp: 5000},
+	}
+
+	regressions := Compare(baseline, results, 0.1)
+	require.Equal(t, []Regression{{Name: "VerifyBlobKZGProof", BaselineNsPerOp: 2000, NsPerOp: 2300}}, regressions)
+	require.InDelta(t, 0.15, regressions[0].Slowdown(), 1e-9)
+	require.Equal(t, "VerifyBlobKZGProof: 2300 ns/op, 15.0% slower than the baseline of 2000 ns/op", regressions[0].String())
+
+	require.Empty(t, Compare(baseline, results, 0.2))
+	require.Len(t, Compare(baseline, results, 0), 2)
+}
+
+func TestCheck(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	results := []bench.Result{{Name: "VerifyBlobKZGProof", Operation: "VerifyBlobKZGProof", Iterations: 10, NsPerOp: 2000}}
+
+	// The first check records the baseline.
+	r := &recorder{TB: t}
+	Check(r, path, results, DefaultThreshold)
+	require.Empty(t, r.errors)
+	recorded, err := Read(path)
+	require.NoError(t, err)
+	require.Equal(t, results, recorded)
+
+	DefaultCheck(r, path, []bench.Result{{Name: "VerifyBlobKZGProof", NsPerOp: 2200}})
+	require.Empty(t, r.errors)
+	DefaultCheck(r, path, []bench.Result{{Name: "VerifyBlobKZGProof", NsPerOp: 3000}})
+	require.Equal(t, []string{"regression: VerifyBlobKZGProof: 3000 ns/op, 50.0% slower than the baseline of 2000 ns/op"}, r.errors)
+}
+
+func TestReadMalformed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o644))
+	_, err := Read(path)
+	require.Error(t, err)
+}
+
+// TestSuite runs the default benchmark suite against the baseline given with
+// -baseline.file. It takes a few minutes, so it is skipped without one.
+func TestSuite(t *testing.T) {
+	if *baselineFile == "" {
+		t.Skip("no -baseline.file")
+	}
+	require.NoError(t, ckzg4844.LoadTrustedSetupFile("../../../../src/trusted_setup.txt"))
+	defer ckzg4844.FreeTrustedSetup()
+	DefaultCheck(t, *baselineFile, bench.Run(bench.DefaultConfig()))
+}
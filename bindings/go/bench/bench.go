@@ -0,0 +1,219 @@
+// Package bench runs a parameterized benchmark suite of the ckzg4844 and rs
+// packages and writes the results as JSON or CSV, so performance can be
+// tracked across releases and machines.
+//
+// The suite sweeps the number of blobs of batch verification, the fraction
+// of missing evaluations for recovery, and the number of goroutines verifying
+// concurrently. The C library has no precomputation or thread settings, so
+// there are no parameters for those.
+package bench
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"strconv"
+	"sync"
+	"testing"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+	"github.com/ethereum/c-kzg-4844/bindings/go/ckzgtest"
+	"github.com/ethereum/c-kzg-4844/bindings/go/rs"
+)
+
+// Config selects the parameters swept by the suite.
+type Config struct {
+	// BlobCounts are the batch sizes for VerifyBlobKZGProofBatch.
+	BlobCounts []int
+	// MissingFractions are the fractions of the codeword left out when
+	// recovering, in [0, 0.5].
+	MissingFractions []float64
+	// Goroutines are the numbers of goroutines calling VerifyBlobKZGProof
+	// concurrently.
+	Goroutines []int
+}
+
+// DefaultConfig returns the parameters used by the go test benchmarks.
+func DefaultConfig() Config {
+	return Config{
+		BlobCounts:       []int{1, 2, 4, 8, 16, 32, 64},
+		MissingFractions: []float64{0, 0.25, 0.5},
+		Goroutines:       []int{1, 2, 4, 8},
+	}
+}
+
+// Result is the measurement of one benchmark. Parameters that do not apply
+// to the operation are zero.
+type Result struct {
+	Name            string  `json:"name"`
+	Operation       string  `json:"operation"`
+	Count           int     `json:"count,omitempty"`
+	MissingFraction float64 `json:"missing_fraction,omitempty"`
+	Goroutines      int     `json:"goroutines,omitempty"`
+	Iterations      int     `json:"iterations"`
+	NsPerOp         int64   `json:"ns_per_op"`
+}
+
+// Case is a single benchmark of the suite.
+type Case struct {
+	Result
+	// F is the benchmark function, for use with testing.Benchmark or
+	// testing.B.Run.
+	F func(b *testing.B)
+}
+
+// Cases returns the benchmarks selected by cfg. The trusted setup must be
+// loaded.
+func Cases(cfg Config) []Case {
+	maxCount := 1
+	for _, count := range cfg.BlobCounts {
+		if count > maxCount {
+			maxCount = count
+		}
+	}
+	blobs, commitments, proofs := ckzgtest.RandomBundle(0, maxCount)
+	z := ckzgtest.RandomFieldElement(0)
+
+	cases := []Case{
+		{Result: Result{Operation: "BlobToKZGCommitment"}, F: func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				_, _ = ckzg4844.BlobToKZGCommitment(&blobs[0])
+			}
+		}},
+		{Result: Result{Operation: "ComputeKZGProof"}, F: func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				_, _, _ = ckzg4844.ComputeKZGProof(&blobs[0], z)
+			}
+		}},
+		{Result: Result{Operation: "ComputeBlobKZGProof"}, F: func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				_, _ = ckzg4844.ComputeBlobKZGProof(&blobs[0], commitments[0])
+			}
+		}},
+		{Result: Result{Operation: "VerifyBlobKZGProof"}, F: func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				_, _ = ckzg4844.VerifyBlobKZGProof(&blobs[0], commitments[0], proofs[0])
+			}
+		}},
+	}
+
+	for _, count := range cfg.BlobCounts {
+		count := count
+		cases = append(cases, Case{Result: Result{Operation: "VerifyBlobKZGProofBatch", Count: count}, F: func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				_, _ = ckzg4844.VerifyBlobKZGProofBatch(blobs[:count], commitments[:count], proofs[:count])
+			}
+		}})
+	}
+
+	for _, goroutines := range cfg.Goroutines {
+		goroutines := goroutines
+		cases = append(cases, Case{Result: Result{Operation: "VerifyBlobKZGProofConcurrent", Goroutines: goroutines}, F: func(b *testing.B) {
+			// b.N verifications are shared by the goroutines, so ns/op is
+			// the inverse of the throughput.
+			var wg sync.WaitGroup
+			for g := 0; g < goroutines; g++ {
+				wg.Add(1)
+				go func(g int) {
+					defer wg.Done()
+					for n := g; n < b.N; n += goroutines {
+						_, _ = ckzg4844.VerifyBlobKZGProof(&blobs[0], commitments[0], proofs[0])
+					}
+				}(g)
+			}
+			wg.Wait()
+		}})
+	}
+
+	if len(cfg.MissingFractions) > 0 {
+		codeword, err := rs.Encode(&blobs[0])
+		if err != nil {
+			panic(fmt.Sprintf("failed to encode blob: %v", err))
+		}
+		for _, fraction := range cfg.MissingFractions {
+			fraction := fraction
+			perm := rand.New(rand.NewSource(0)).Perm(rs.CodewordLength)
+			indices := perm[:rs.CodewordLength-int(fraction*rs.CodewordLength)]
+			evaluations := make([]ckzg4844.Bytes32, len(indices))
+			for i, index := range indices {
+				evaluations[i] = codeword[index]
+			}
+			cases = append(cases, Case{Result: Result{Operation: "Recover", MissingFraction: fraction}, F: func(b *testing.B) {
+				for n := 0; n < b.N; n++ {
+					_, _ = rs.Recover(indices, evaluations)
+				}
+			}})
+		}
+	}
+
+	for i := range cases {
+		cases[i].Name = caseName(cases[i].Result)
+	}
+	return cases
+}
+
+// caseName returns the operation followed by its parameters, in the format
+// of sub-benchmark names.
+func caseName(r Result) string {
+	name := r.Operation
+	if r.Count != 0 {
+		name += fmt.Sprintf("/count=%v", r.Count)
+	}
+	if r.Operation == "Recover" {
+		name += fmt.Sprintf("/missing=%v", r.MissingFraction)
+	}
+	if r.Goroutines != 0 {
+		name += fmt.Sprintf("/goroutines=%v", r.Goroutines)
+	}
+	return name
+}
+
+// Run runs every benchmark selected by cfg with testing.Benchmark and
+// returns the results in order. The trusted setup must be loaded.
+func Run(cfg Config) []Result {
+	cases := Cases(cfg)
+	results := make([]Result, len(cases))
+	for i, c := range cases {
+		r := testing.Benchmark(c.F)
+		results[i] = c.Result
+		results[i].Iterations = r.N
+		results[i].NsPerOp = r.NsPerOp()
+	}
+	return results
+}
+
+// WriteJSON writes the results as an indented JSON array.
+func WriteJSON(w io.Writer, results []Result) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(results)
+}
+
+// csvHeader is the first row written by WriteCSV.
+var csvHeader = []string{"name", "operation", "count", "missing_fraction", "goroutines", "iterations", "ns_per_op"}
+
+// WriteCSV writes the results as CSV, with a header row.
+func WriteCSV(w io.Writer, results []Result) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, r := range results {
+		record := []string{
+			r.Name,
+			r.Operation,
+			strconv.Itoa(r.Count),
+			strconv.FormatFloat(r.MissingFraction, 'g', -1, 64),
+			strconv.Itoa(r.Goroutines),
+			strconv.Itoa(r.Iterations),
+			strconv.FormatInt(r.NsPerOp, 10),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,68 @@
+package bench
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	if err := ckzg4844.LoadTrustedSetupFile("../../../src/trusted_setup.txt"); err != nil {
+		panic(fmt.Sprintf("failed to load trusted setup: %v", err))
+	}
+	defer ckzg4844.FreeTrustedSetup()
+	code := m.Run()
+	os.Exit(code)
+}
+
+func TestCases(t *testing.T) {
+	cases := Cases(Config{BlobCounts: []int{1, 4}, MissingFractions: []float64{0.5}, Goroutines: []int{2}})
+	var names []string
+	for _, c := range cases {
+		names = append(names, c.Name)
+	}
+	require.Equal(t, []string{
+		"BlobToKZGCommitment",
+		"ComputeKZGProof",
+		"ComputeBlobKZGProof",
+		"VerifyBlobKZGProof",
+		"VerifyBlobKZGProofBatch/count=1",
+		"VerifyBlobKZGProofBatch/count=4",
+		"VerifyBlobKZGProofConcurrent/goroutines=2",
+		"Recover/missing=0.5",
+	}, names)
+}
+
+func TestWrite(t *testing.T) {
+	results := []Result{
+		{Name: "VerifyBlobKZGProofBatch/count=4", Operation: "VerifyBlobKZGProofBatch", Count: 4, Iterations: 10, NsPerOp: 12345},
+		{Name: "Recover/missing=0.25", Operation: "Recover", MissingFraction: 0.25, Iterations: 3, NsPerOp: 999},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteJSON(&buf, results))
+	var decoded []Result
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	require.Equal(t, results, decoded)
+
+	buf.Reset()
+	require.NoError(t, WriteCSV(&buf, results))
+	require.Equal(t, strings.Join([]string{
+		"name,operation,count,missing_fraction,goroutines,iterations,ns_per_op",
+		"VerifyBlobKZGProofBatch/count=4,VerifyBlobKZGProofBatch,4,0,0,10,12345",
+		"Recover/missing=0.25,Recover,0,0.25,0,3,999",
+		"",
+	}, "\n"), buf.String())
+}
+
+func Benchmark(b *testing.B) {
+	for _, c := range Cases(DefaultConfig()) {
+		b.Run(c.Name, c.F)
+	}
+}
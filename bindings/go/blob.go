@@ -0,0 +1,186 @@
+package ckzg4844
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// Field Element Accessors
+///////////////////////////////////////////////////////////////////////////////
+
+// Count returns the number of field elements in a blob.
+func (b *Blob) Count() int {
+	return FieldElementsPerBlob
+}
+
+// FieldElement returns the i-th field element of the blob. It panics if i is
+// out of range, like indexing a slice.
+func (b *Blob) FieldElement(i int) Bytes32 {
+	if i < 0 || i >= FieldElementsPerBlob {
+		panic(fmt.Sprintf("field element index %v out of range [0, %v)", i, FieldElementsPerBlob))
+	}
+	return *(*Bytes32)(b[i*BytesPerFieldElement : (i+1)*BytesPerFieldElement])
+}
+
+// SetFieldElement replaces the i-th field element of the blob. It returns
+// ErrBadArgs and leaves the blob unchanged if i is out of range or if the
+// field element is not canonical.
+func (b *Blob) SetFieldElement(i int, fieldElement Bytes32) error {
+	if i < 0 || i >= FieldElementsPerBlob {
+		return fmt.Errorf("%w: field element index %v out of range", ErrBadArgs, i)
+	}
+	if err := ValidateFieldElement(fieldElement); err != nil {
+		return err
+	}
+	copy(b[i*BytesPerFieldElement:], fieldElement[:])
+	return nil
+}
+
+// NonCanonicalFieldElements returns the indices of all field elements of the
+// blob that are not canonical, in ascending order, or nil if the blob is
+// valid. ValidateBlob only reports the first one.
+func (b *Blob) NonCanonicalFieldElements() []int {
+	var indices []int
+	for i := 0; i < FieldElementsPerBlob; i++ {
+		if ValidateFieldElement(b.FieldElement(i)) != nil {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Blob Builder
+///////////////////////////////////////////////////////////////////////////////
+
+// bytesPerFieldElementPayload is the number of bytes AppendBytes stores in
+// each field element. The first byte is left zero so the element is always
+// canonical.
+const bytesPerFieldElementPayload = BytesPerFieldElement - 1
+
+// BlobBuilder constructs a blob incrementally. Unused field elements are
+// zero. The first error from an append is remembered: later appends become
+// no-ops and Build returns that error, so callers may check once at the end.
+type BlobBuilder struct {
+	blob Blob
+	// numFieldElements is the number of field elements in use, including a
+	// partially filled one.
+	numFieldElements int
+	// partial is the number of payload bytes already written to the last
+	// field element by AppendBytes, or zero if it is full.
+	partial int
+	err     error
+}
+
+// NewBlobBuilder returns an empty builder.
+func NewBlobBuilder() *BlobBuilder {
+	return &BlobBuilder{}
+}
+
+// Len returns the number of field elements in use.
+func (bb *BlobBuilder) Len() int {
+	return bb.numFieldElements
+}
+
+// AppendFieldElement appends a single canonical field element. A field
+// element partially filled by AppendBytes is closed first.
+func (bb *BlobBuilder) AppendFieldElement(fieldElement Bytes32) error {
+	if bb.err != nil {
+		return bb.err
+	}
+	if bb.numFieldElements >= FieldElementsPerBlob {
+		bb.err = ErrBlobFull
+		return bb.err
+	}
+	if err := bb.blob.SetFieldElement(bb.numFieldElements, fieldElement); err != nil {
+		bb.err = err
+		return bb.err
+	}
+	bb.numFieldElements++
+	bb.partial = 0
+	return nil
+}
+
+// AppendBytes appends data packed 31 bytes per field element, continuing in
+// the last field element if a previous AppendBytes left it partially filled.
+// If the data does not fit, nothing is appended and ErrBlobFull is returned.
+func (bb *BlobBuilder) AppendBytes(data []byte) error {
+	if bb.err != nil {
+		return bb.err
+	}
+	capacity := (FieldElementsPerBlob - bb.numFieldElements) * bytesPerFieldElementPayload
+	if bb.partial > 0 {
+		capacity += bytesPerFieldElementPayload - bb.partial
+	}
+	if len(data) > capacity {
+		bb.err = fmt.Errorf("%w: %v bytes do not fit in the remaining %v", ErrBlobFull, len(data), capacity)
+		return bb.err
+	}
+	for len(data) > 0 {
+		if bb.partial == 0 {
+			bb.numFieldElements++
+		}
+		offset := (bb.numFieldElements-1)*BytesPerFieldElement + 1 + bb.partial
+		n := copy(bb.blob[offset:offset+bytesPerFieldElementPayload-bb.partial], data)
+		data = data[n:]
+		bb.partial = (bb.partial + n) % bytesPerFieldElementPayload
+	}
+	return nil
+}
+
+// Build returns the blob built so far, or the first error encountered while
+// appending. The builder can continue to be used afterwards.
+func (bb *BlobBuilder) Build() (Blob, error) {
+	if bb.err != nil {
+		return Blob{}, bb.err
+	}
+	return bb.blob, nil
+}
+
+// Reset empties the builder and clears any remembered error.
+func (bb *BlobBuilder) Reset() {
+	*bb = BlobBuilder{}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Compression
+///////////////////////////////////////////////////////////////////////////////
+
+/*
+CompressBlob drops the run of all-zero field elements at the end of the blob.
+The result is a 2-byte big-endian count n of the remaining field elements,
+followed by their n*BytesPerFieldElement bytes. An empty blob compresses to
+two bytes. Mostly-empty blobs, which are common on chain, shrink accordingly.
+*/
+func CompressBlob(blob *Blob) []byte {
+	n := FieldElementsPerBlob
+	for n > 0 && blob.FieldElement(n-1) == (Bytes32{}) {
+		n--
+	}
+	out := make([]byte, 2+n*BytesPerFieldElement)
+	binary.BigEndian.PutUint16(out, uint16(n))
+	copy(out[2:], blob[:n*BytesPerFieldElement])
+	return out
+}
+
+// DecompressBlob reverses CompressBlob. It only accepts the exact output of
+// CompressBlob, so every blob has a single compressed form.
+func DecompressBlob(data []byte) (*Blob, error) {
+	if len(data) < 2 {
+		return nil, fmt.Errorf("%w: missing header", ErrInvalidCompression)
+	}
+	n := int(binary.BigEndian.Uint16(data))
+	if n > FieldElementsPerBlob {
+		return nil, fmt.Errorf("%w: %v field elements", ErrInvalidCompression, n)
+	}
+	if len(data) != 2+n*BytesPerFieldElement {
+		return nil, fmt.Errorf("%w: expected %v bytes, got %v", ErrInvalidCompression, 2+n*BytesPerFieldElement, len(data))
+	}
+	blob := new(Blob)
+	copy(blob[:], data[2:])
+	if n > 0 && blob.FieldElement(n-1) == (Bytes32{}) {
+		return nil, fmt.Errorf("%w: trailing zero field element", ErrInvalidCompression)
+	}
+	return blob, nil
+}
@@ -0,0 +1,20 @@
+//go:build go1.23
+
+package ckzg4844
+
+import (
+	"iter"
+)
+
+// FieldElements returns an iterator over the index and value of every field
+// element in the blob. It does not allocate, and it reads the blob lazily, so
+// changes made to the blob during iteration are observed.
+func (b *Blob) FieldElements() iter.Seq2[int, Bytes32] {
+	return func(yield func(int, Bytes32) bool) {
+		for i := 0; i < FieldElementsPerBlob; i++ {
+			if !yield(i, b.FieldElement(i)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,38 @@
+//go:build go1.23
+
+package ckzg4844
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldElementsIterator(t *testing.T) {
+	blob := new(Blob)
+	fillBlobRandom(blob, 0)
+
+	count := 0
+	for i, fieldElement := range blob.FieldElements() {
+		require.Equal(t, count, i)
+		require.Equal(t, blob.FieldElement(i), fieldElement)
+		count++
+	}
+	require.Equal(t, FieldElementsPerBlob, count)
+
+	// Stopping early must not visit further elements.
+	visited := 0
+	for i := range blob.FieldElements() {
+		visited++
+		if i == 9 {
+			break
+		}
+	}
+	require.Equal(t, 10, visited)
+
+	allocs := testing.AllocsPerRun(10, func() {
+		for range blob.FieldElements() {
+		}
+	})
+	require.Zero(t, allocs)
+}
@@ -0,0 +1,144 @@
+package ckzg4844
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldElementAccessors(t *testing.T) {
+	blob := new(Blob)
+	require.Equal(t, FieldElementsPerBlob, blob.Count())
+
+	fieldElement := getRandFieldElement(0)
+	require.NoError(t, blob.SetFieldElement(1, fieldElement))
+	require.Equal(t, fieldElement, blob.FieldElement(1))
+	require.Equal(t, Bytes32{}, blob.FieldElement(0))
+	require.Equal(t, Bytes32{}, blob.FieldElement(2))
+	require.Equal(t, fieldElement[:], blob[BytesPerFieldElement:2*BytesPerFieldElement])
+
+	require.NoError(t, blob.SetFieldElement(FieldElementsPerBlob-1, fieldElement))
+	require.Equal(t, fieldElement, blob.FieldElement(FieldElementsPerBlob-1))
+
+	modulus, err := NewBytes32FromHex(blsModulusHex)
+	require.NoError(t, err)
+	require.ErrorIs(t, blob.SetFieldElement(1, modulus), ErrBadArgs)
+	require.Equal(t, fieldElement, blob.FieldElement(1))
+	require.ErrorIs(t, blob.SetFieldElement(-1, fieldElement), ErrBadArgs)
+	require.ErrorIs(t, blob.SetFieldElement(FieldElementsPerBlob, fieldElement), ErrBadArgs)
+
+	require.Panics(t, func() { blob.FieldElement(FieldElementsPerBlob) })
+	require.Panics(t, func() { blob.FieldElement(-1) })
+}
+
+func TestNonCanonicalFieldElements(t *testing.T) {
+	blob := new(Blob)
+	fillBlobRandom(blob, 0)
+	require.Nil(t, blob.NonCanonicalFieldElements())
+
+	modulus, err := NewBytes32FromHex(blsModulusHex)
+	require.NoError(t, err)
+	for _, i := range []int{17, 3, FieldElementsPerBlob - 1} {
+		copy(blob[i*BytesPerFieldElement:], modulus[:])
+	}
+	require.Equal(t, []int{3, 17, FieldElementsPerBlob - 1}, blob.NonCanonicalFieldElements())
+	require.EqualError(t, ValidateBlob(blob), "bad arguments: blob field element 3 is not canonical")
+}
+
+func TestBlobBuilder(t *testing.T) {
+	bb := NewBlobBuilder()
+	fieldElement := getRandFieldElement(0)
+	require.NoError(t, bb.AppendFieldElement(fieldElement))
+	require.NoError(t, bb.AppendBytes(bytes.Repeat([]byte{1}, 40)))
+	require.Equal(t, 3, bb.Len())
+	// Continues in the partially filled third field element.
+	require.NoError(t, bb.AppendBytes(bytes.Repeat([]byte{2}, 22)))
+	require.Equal(t, 3, bb.Len())
+	require.NoError(t, bb.AppendBytes([]byte{3}))
+	require.Equal(t, 4, bb.Len())
+	// Closes the partial fourth field element.
+	require.NoError(t, bb.AppendFieldElement(fieldElement))
+	require.Equal(t, 5, bb.Len())
+
+	blob, err := bb.Build()
+	require.NoError(t, err)
+	require.NoError(t, ValidateBlob(&blob))
+	require.Equal(t, fieldElement, blob.FieldElement(0))
+	second := blob.FieldElement(1)
+	require.Equal(t, append([]byte{0}, bytes.Repeat([]byte{1}, 31)...), second[:])
+	third := blob.FieldElement(2)
+	require.Equal(t, append(append([]byte{0}, bytes.Repeat([]byte{1}, 9)...), bytes.Repeat([]byte{2}, 22)...), third[:])
+	fourth := blob.FieldElement(3)
+	require.Equal(t, Bytes32{1: 3}, fourth)
+	require.Equal(t, fieldElement, blob.FieldElement(4))
+	for i := 5; i < FieldElementsPerBlob; i++ {
+		require.Equal(t, Bytes32{}, blob.FieldElement(i))
+	}
+}
+
+func TestBlobBuilderCapacity(t *testing.T) {
+	bb := NewBlobBuilder()
+	require.NoError(t, bb.AppendBytes(make([]byte, FieldElementsPerBlob*31-1)))
+	require.NoError(t, bb.AppendBytes([]byte{1}))
+	require.Equal(t, FieldElementsPerBlob, bb.Len())
+	require.ErrorIs(t, bb.AppendBytes([]byte{1}), ErrBlobFull)
+	// The error is sticky.
+	require.ErrorIs(t, bb.AppendFieldElement(Bytes32{}), ErrBlobFull)
+	_, err := bb.Build()
+	require.ErrorIs(t, err, ErrBlobFull)
+
+	bb.Reset()
+	for i := 0; i < FieldElementsPerBlob; i++ {
+		require.NoError(t, bb.AppendFieldElement(Bytes32{}))
+	}
+	require.ErrorIs(t, bb.AppendFieldElement(Bytes32{}), ErrBlobFull)
+
+	bb.Reset()
+	require.ErrorIs(t, bb.AppendBytes(make([]byte, FieldElementsPerBlob*31+1)), ErrBlobFull)
+	require.Equal(t, 0, bb.Len())
+
+	bb.Reset()
+	modulus, err := NewBytes32FromHex(blsModulusHex)
+	require.NoError(t, err)
+	require.ErrorIs(t, bb.AppendFieldElement(modulus), ErrBadArgs)
+	_, err = bb.Build()
+	require.ErrorIs(t, err, ErrBadArgs)
+}
+
+func TestCompressBlob(t *testing.T) {
+	blob := new(Blob)
+	compressed := CompressBlob(blob)
+	require.Equal(t, []byte{0, 0}, compressed)
+	decompressed, err := DecompressBlob(compressed)
+	require.NoError(t, err)
+	require.Equal(t, blob, decompressed)
+
+	// Zero field elements before the last non-zero one are kept.
+	blob[BytesPerFieldElement+31] = 1
+	blob[3*BytesPerFieldElement] = 2
+	compressed = CompressBlob(blob)
+	require.Len(t, compressed, 2+4*BytesPerFieldElement)
+	decompressed, err = DecompressBlob(compressed)
+	require.NoError(t, err)
+	require.Equal(t, blob, decompressed)
+
+	fillBlobRandom(blob, 0)
+	compressed = CompressBlob(blob)
+	require.Len(t, compressed, 2+BytesPerBlob)
+	decompressed, err = DecompressBlob(compressed)
+	require.NoError(t, err)
+	require.Equal(t, blob, decompressed)
+}
+
+func TestDecompressBlobInvalid(t *testing.T) {
+	_, err := DecompressBlob(nil)
+	require.ErrorIs(t, err, ErrInvalidCompression)
+	_, err = DecompressBlob([]byte{0, 1})
+	require.ErrorIs(t, err, ErrInvalidCompression)
+	_, err = DecompressBlob([]byte{0xff, 0xff})
+	require.ErrorIs(t, err, ErrInvalidCompression)
+	// A trailing zero field element is not canonical.
+	_, err = DecompressBlob(append([]byte{0, 1}, make([]byte, BytesPerFieldElement)...))
+	require.ErrorIs(t, err, ErrInvalidCompression)
+}
@@ -0,0 +1,63 @@
+//go:build cgo && !ckzg_nocgo
+
+package ckzg4844
+
+// #include "blst.h"
+import "C"
+
+import "fmt"
+
+var (
+	// blstG1Generator and blstG2Generator are the compressed generators of
+	// G1 and G2.
+	blstG1Generator = [bytesPerG1]byte{
+		0x97, 0xf1, 0xd3, 0xa7, 0x31, 0x97, 0xd7, 0x94, 0x26, 0x95, 0x63, 0x8c,
+		0x4f, 0xa9, 0xac, 0x0f, 0xc3, 0x68, 0x8c, 0x4f, 0x97, 0x74, 0xb9, 0x05,
+		0xa1, 0x4e, 0x3a, 0x3f, 0x17, 0x1b, 0xac, 0x58, 0x6c, 0x55, 0xe8, 0x3f,
+		0xf9, 0x7a, 0x1a, 0xef, 0xfb, 0x3a, 0xf0, 0x0a, 0xdb, 0x22, 0xc6, 0xbb,
+	}
+	blstG2Generator = [bytesPerG2]byte{
+		0x93, 0xe0, 0x2b, 0x60, 0x52, 0x71, 0x9f, 0x60, 0x7d, 0xac, 0xd3, 0xa0,
+		0x88, 0x27, 0x4f, 0x65, 0x59, 0x6b, 0xd0, 0xd0, 0x99, 0x20, 0xb6, 0x1a,
+		0xb5, 0xda, 0x61, 0xbb, 0xdc, 0x7f, 0x50, 0x49, 0x33, 0x4c, 0xf1, 0x12,
+		0x13, 0x94, 0x5d, 0x57, 0xe5, 0xac, 0x7d, 0x05, 0x5d, 0x04, 0x2b, 0x7e,
+		0x02, 0x4a, 0xa2, 0xb2, 0xf0, 0x8f, 0x0a, 0x91, 0x26, 0x08, 0x05, 0x27,
+		0x2d, 0xc5, 0x10, 0x51, 0xc6, 0xe4, 0x7a, 0xd4, 0xfa, 0x40, 0x3b, 0x02,
+		0xb4, 0x51, 0x0b, 0x64, 0x7a, 0xe3, 0xd1, 0x77, 0x0b, 0xac, 0x03, 0x26,
+		0xa8, 0x05, 0xbb, 0xef, 0xd4, 0x80, 0x56, 0xc8, 0xc1, 0x21, 0xbd, 0xb8,
+	}
+)
+
+// checkBlst checks blst against known answers: the compressed generators,
+// a field element round trip and a product. Some of the structures the C
+// library passes to blst have a layout that depends on how blst was built,
+// which these catch.
+func checkBlst() error {
+	var g1 [bytesPerG1]byte
+	C.blst_p1_compress((*C.byte)(&g1[0]), C.blst_p1_generator())
+	if g1 != blstG1Generator {
+		return fmt.Errorf("the G1 generator is %x", g1)
+	}
+	var g2 [bytesPerG2]byte
+	C.blst_p2_compress((*C.byte)(&g2[0]), C.blst_p2_generator())
+	if g2 != blstG2Generator {
+		return fmt.Errorf("the G2 generator is %x", g2)
+	}
+
+	var a, b C.blst_fr
+	limbs := [4]C.uint64_t{1, 2, 3, 4}
+	C.blst_fr_from_uint64(&a, &limbs[0])
+	var out [4]C.uint64_t
+	C.blst_uint64_from_fr(&out[0], &a)
+	if out != limbs {
+		return fmt.Errorf("field elements do not round trip: %v is %v", limbs, out)
+	}
+	three := [4]C.uint64_t{3}
+	C.blst_fr_from_uint64(&b, &three[0])
+	C.blst_fr_mul(&a, &a, &b)
+	C.blst_uint64_from_fr(&out[0], &a)
+	if out != [4]C.uint64_t{3, 6, 9, 12} {
+		return fmt.Errorf("field multiplication is wrong: 3*%v is %v", limbs, out)
+	}
+	return nil
+}
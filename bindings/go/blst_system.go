@@ -0,0 +1,19 @@
+//go:build cgo && !ckzg_nocgo && ckzg_system_blst
+
+package ckzg4844
+
+// #cgo LDFLAGS: -lblst
+import "C"
+
+import "fmt"
+
+// With the ckzg_system_blst tag, blst is linked from the system rather than
+// built from the Go module, and its headers come from the system include
+// path. Set CGO_CFLAGS and CGO_LDFLAGS if they are elsewhere. A library
+// that does not match its headers would compute wrong results silently, so
+// it is checked before use.
+func init() {
+	if err := checkBlst(); err != nil {
+		panic(fmt.Sprintf("system blst is unusable: %v", err))
+	}
+}
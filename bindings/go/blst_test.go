@@ -0,0 +1,13 @@
+//go:build cgo && !ckzg_nocgo
+
+package ckzg4844
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckBlst(t *testing.T) {
+	require.NoError(t, checkBlst())
+}
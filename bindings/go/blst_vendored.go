@@ -0,0 +1,11 @@
+//go:build cgo && !ckzg_nocgo && !ckzg_system_blst
+
+package ckzg4844
+
+// #cgo CFLAGS: -I${SRCDIR}/blst_headers
+import "C"
+
+import (
+	// So its functions are available during compilation.
+	_ "github.com/supranational/blst/bindings/go"
+)
@@ -0,0 +1,95 @@
+package ckzg4844
+
+import (
+	"crypto/sha256"
+	"fmt"
+)
+
+// VersionedHashVersionKZG is the version byte of versioned hashes derived from
+// KZG commitments (VERSIONED_HASH_VERSION_KZG in EIP-4844).
+const VersionedHashVersionKZG = 0x01
+
+// KZGToVersionedHash returns the versioned hash of a commitment, as defined
+// by kzg_to_versioned_hash in EIP-4844.
+func KZGToVersionedHash(commitment KZGCommitment) Bytes32 {
+	hash := Bytes32(sha256.Sum256(commitment[:]))
+	hash[0] = VersionedHashVersionKZG
+	return hash
+}
+
+// findInvalidBlobProof returns the index of the first blob proof that fails,
+// with the reason, or -1 if they all verify.
+func findInvalidBlobProof(blobs []Blob, commitmentsBytes, proofsBytes []Bytes48) (int, error) {
+	failed := bisectBlobKZGProofBatch(blobs, commitmentsBytes, proofsBytes, 0, true, nil)
+	if len(failed) == 0 {
+		return -1, nil
+	}
+	i := failed[0]
+	ok, err := VerifyBlobKZGProof(&blobs[i], commitmentsBytes[i], proofsBytes[i])
+	if err != nil {
+		return i, fmt.Errorf("blob %v: %w", i, err)
+	}
+	if !ok {
+		return i, fmt.Errorf("blob %v: %w", i, ErrInvalidProof)
+	}
+	return -1, nil
+}
+
+/*
+VerifyBlobsBundle performs the full check an execution client applies to the
+blobs bundle of a payload: the inputs must have equal lengths, every versioned
+hash must match its commitment, and every blob proof must verify. Blob proofs
+are checked in a single batch.
+
+It returns -1 and a nil error when the bundle is valid. Otherwise it returns
+the index of the offending entry (or -1 when the lengths differ) and an error
+that wraps ErrBadArgs, ErrVersionedHashMismatch or ErrInvalidProof.
+*/
+func VerifyBlobsBundle(blobs []Blob, commitmentsBytes, proofsBytes []Bytes48, versionedHashes []Bytes32) (int, error) {
+	if len(blobs) != len(commitmentsBytes) || len(blobs) != len(proofsBytes) || len(blobs) != len(versionedHashes) {
+		return -1, fmt.Errorf("%w: %v blobs, %v commitments, %v proofs, %v versioned hashes",
+			ErrBadArgs, len(blobs), len(commitmentsBytes), len(proofsBytes), len(versionedHashes))
+	}
+	for i := range commitmentsBytes {
+		if KZGToVersionedHash(KZGCommitment(commitmentsBytes[i])) != versionedHashes[i] {
+			return i, fmt.Errorf("blob %v: %w", i, ErrVersionedHashMismatch)
+		}
+	}
+
+	ok, err := VerifyBlobKZGProofBatch(blobs, commitmentsBytes, proofsBytes)
+	if err == nil && ok {
+		return -1, nil
+	}
+	// The batch failed; find out which entry is responsible.
+	if index, err := findInvalidBlobProof(blobs, commitmentsBytes, proofsBytes); index >= 0 {
+		return index, err
+	}
+	if err != nil {
+		return -1, err
+	}
+	return -1, ErrInvalidProof
+}
+
+/*
+ValidateBlobTxWrapper implements the EIP-4844 network wrapper validation that a
+mempool applies to a pooled blob transaction. The transaction must carry at
+least one blob, each versioned hash from the transaction payload must use
+VersionedHashVersionKZG and match the commitment at the same index, and the
+blob proofs must verify.
+
+Errors wrap ErrNoBlobs, ErrBadArgs, ErrInvalidHashVersion,
+ErrVersionedHashMismatch or ErrInvalidProof and name the offending blob
+index where there is one.
+*/
+func ValidateBlobTxWrapper(versionedHashes []Bytes32, blobs []Blob, commitmentsBytes, proofsBytes []Bytes48) error {
+	if len(versionedHashes) == 0 {
+		return ErrNoBlobs
+	}
+	for i, hash := range versionedHashes {
+		if hash[0] != VersionedHashVersionKZG {
+			return fmt.Errorf("blob %v: %w: %#x", i, ErrInvalidHashVersion, hash[0])
+		}
+	}
+	_, err := VerifyBlobsBundle(blobs, commitmentsBytes, proofsBytes, versionedHashes)
+	return err
+}
@@ -0,0 +1,87 @@
+package ckzg4844
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func getBundle(t *testing.T, n int) ([]Blob, []Bytes48, []Bytes48, []Bytes32) {
+	blobs := make([]Blob, n)
+	commitments := make([]Bytes48, n)
+	proofs := make([]Bytes48, n)
+	versionedHashes := make([]Bytes32, n)
+	for i := 0; i < n; i++ {
+		fillBlobRandom(&blobs[i], int64(i))
+		commitment, err := BlobToKZGCommitment(&blobs[i])
+		require.NoError(t, err)
+		proof, err := ComputeBlobKZGProof(&blobs[i], Bytes48(commitment))
+		require.NoError(t, err)
+		commitments[i] = Bytes48(commitment)
+		proofs[i] = Bytes48(proof)
+		versionedHashes[i] = KZGToVersionedHash(commitment)
+	}
+	return blobs, commitments, proofs, versionedHashes
+}
+
+func TestKZGToVersionedHash(t *testing.T) {
+	var commitment KZGCommitment
+	commitment[0] = 0xc0
+	hash := KZGToVersionedHash(commitment)
+	expected := sha256.Sum256(commitment[:])
+	require.Equal(t, byte(VersionedHashVersionKZG), hash[0])
+	require.Equal(t, expected[1:], hash[1:])
+}
+
+func TestVerifyBlobsBundle(t *testing.T) {
+	blobs, commitments, proofs, versionedHashes := getBundle(t, 3)
+
+	index, err := VerifyBlobsBundle(blobs, commitments, proofs, versionedHashes)
+	require.NoError(t, err)
+	require.Equal(t, -1, index)
+
+	// An empty bundle is valid.
+	index, err = VerifyBlobsBundle(nil, nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, -1, index)
+
+	index, err = VerifyBlobsBundle(blobs, commitments, proofs, versionedHashes[:2])
+	require.ErrorIs(t, err, ErrBadArgs)
+	require.Equal(t, -1, index)
+
+	badHashes := append([]Bytes32(nil), versionedHashes...)
+	badHashes[1][0] = 0x02
+	index, err = VerifyBlobsBundle(blobs, commitments, proofs, badHashes)
+	require.ErrorIs(t, err, ErrVersionedHashMismatch)
+	require.Equal(t, 1, index)
+
+	badProofs := append([]Bytes48(nil), proofs...)
+	badProofs[2] = proofs[0]
+	index, err = VerifyBlobsBundle(blobs, commitments, badProofs, versionedHashes)
+	require.ErrorIs(t, err, ErrInvalidProof)
+	require.Equal(t, 2, index)
+
+	// A proof that is not a valid point is reported by index, too.
+	badProofs[2] = Bytes48{}
+	index, err = VerifyBlobsBundle(blobs, commitments, badProofs, versionedHashes)
+	require.ErrorIs(t, err, ErrBadArgs)
+	require.Equal(t, 2, index)
+}
+
+func TestValidateBlobTxWrapper(t *testing.T) {
+	blobs, commitments, proofs, versionedHashes := getBundle(t, 2)
+	require.NoError(t, ValidateBlobTxWrapper(versionedHashes, blobs, commitments, proofs))
+
+	require.ErrorIs(t, ValidateBlobTxWrapper(nil, nil, nil, nil), ErrNoBlobs)
+	require.ErrorIs(t, ValidateBlobTxWrapper(versionedHashes, blobs[:1], commitments, proofs), ErrBadArgs)
+
+	badHashes := append([]Bytes32(nil), versionedHashes...)
+	badHashes[1][0] = 0x02
+	require.ErrorIs(t, ValidateBlobTxWrapper(badHashes, blobs, commitments, proofs), ErrInvalidHashVersion)
+
+	badHashes[1] = versionedHashes[0]
+	require.ErrorIs(t, ValidateBlobTxWrapper(badHashes, blobs, commitments, proofs), ErrVersionedHashMismatch)
+
+	require.ErrorIs(t, ValidateBlobTxWrapper(versionedHashes, blobs, commitments, []Bytes48{proofs[1], proofs[0]}), ErrInvalidProof)
+}
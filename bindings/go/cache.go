@@ -0,0 +1,79 @@
+package ckzg4844
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+)
+
+// The cache holds fetched trusted setups and the Lagrange form of setups
+// loaded in monomial form, named after the digest of their input. Every entry
+// is stored as the SHA-256 digest of its contents followed by the contents, so
+// a truncated or corrupted entry is ignored rather than loaded. That digest
+// says nothing about whether the entry belongs to its name, though: anyone who
+// can write to CacheDir can store an intact entry under any name. Callers must
+// therefore check what readCache returns against the input the name was
+// derived from, as FetchTrustedSetup does with the expected digest and
+// LoadTrustedSetupMonomial does with VerifyTrustedSetup, and fall back to the
+// uncached path when the check fails. Caching is best effort: failing to read
+// or write the cache is never an error.
+
+// CacheDir returns the directory used for cached trusted setups. It is ckzg
+// inside the user cache directory, i.e. $XDG_CACHE_HOME/ckzg on Linux.
+func CacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "ckzg"), nil
+}
+
+// ClearCache removes CacheDir and everything in it.
+func ClearCache() error {
+	dir, err := CacheDir()
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
+
+// readCache returns the contents of the named cache entry, if it exists and
+// is intact. The contents are not known to match the name; see above.
+func readCache(name string) ([]byte, bool) {
+	dir, err := CacheDir()
+	if err != nil {
+		return nil, false
+	}
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil || len(data) < sha256.Size {
+		return nil, false
+	}
+	digest, contents := data[:sha256.Size], data[sha256.Size:]
+	if sum := sha256.Sum256(contents); !bytes.Equal(digest, sum[:]) {
+		return nil, false
+	}
+	return contents, true
+}
+
+// writeCache atomically stores contents as the named cache entry.
+func writeCache(name string, contents []byte) {
+	dir, err := CacheDir()
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return
+	}
+	f, err := os.CreateTemp(dir, ".tmp-*")
+	if err != nil {
+		return
+	}
+	defer os.Remove(f.Name())
+	digest := sha256.Sum256(contents)
+	_, err = f.Write(append(digest[:], contents...))
+	if closeErr := f.Close(); err != nil || closeErr != nil {
+		return
+	}
+	_ = os.Rename(f.Name(), filepath.Join(dir, name))
+}
@@ -0,0 +1,43 @@
+package ckzg4844
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// setCacheDir points CacheDir at a temporary directory for the test.
+func setCacheDir(t *testing.T) string {
+	dir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", dir)
+	t.Setenv("HOME", dir)
+	cacheDir, err := CacheDir()
+	require.NoError(t, err)
+	return cacheDir
+}
+
+func TestCache(t *testing.T) {
+	cacheDir := setCacheDir(t)
+	_, ok := readCache("entry")
+	require.False(t, ok)
+
+	writeCache("entry", []byte("contents"))
+	contents, ok := readCache("entry")
+	require.True(t, ok)
+	require.Equal(t, []byte("contents"), contents)
+
+	// Corrupted entries are ignored.
+	path := filepath.Join(cacheDir, "entry")
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	data[len(data)-1] ^= 1
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+	_, ok = readCache("entry")
+	require.False(t, ok)
+
+	require.NoError(t, ClearCache())
+	_, err = os.Stat(cacheDir)
+	require.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,116 @@
+package ckzg4844
+
+import (
+	"encoding/binary"
+)
+
+// The CBOR methods below match the Marshaler/Unmarshaler interfaces of
+// fxamacker/cbor. Every type is encoded as a definite-length CBOR byte string
+// (major type 2) holding its raw bytes.
+
+const cborMajorTypeBytes = 2 << 5
+
+///////////////////////////////////////////////////////////////////////////////
+// Helper Functions
+///////////////////////////////////////////////////////////////////////////////
+
+// marshalCBOR encodes b as a CBOR byte string.
+func marshalCBOR(b []byte) []byte {
+	var header []byte
+	switch n := len(b); {
+	case n < 24:
+		header = []byte{cborMajorTypeBytes | byte(n)}
+	case n <= 0xff:
+		header = []byte{cborMajorTypeBytes | 24, byte(n)}
+	case n <= 0xffff:
+		header = []byte{cborMajorTypeBytes | 25, 0, 0}
+		binary.BigEndian.PutUint16(header[1:], uint16(n))
+	default:
+		header = []byte{cborMajorTypeBytes | 26, 0, 0, 0, 0}
+		binary.BigEndian.PutUint32(header[1:], uint32(n))
+	}
+	return append(header, b...)
+}
+
+// unmarshalCBOR decodes a CBOR byte string into dst, which it must exactly
+// fill. Indefinite-length strings and trailing data are rejected.
+func unmarshalCBOR(dst, data []byte) error {
+	if len(data) == 0 || data[0]&0xe0 != cborMajorTypeBytes {
+		return ErrBadArgs
+	}
+	info, data := data[0]&0x1f, data[1:]
+	var n uint64
+	switch {
+	case info < 24:
+		n = uint64(info)
+	case info == 24 && len(data) >= 1:
+		n, data = uint64(data[0]), data[1:]
+	case info == 25 && len(data) >= 2:
+		n, data = uint64(binary.BigEndian.Uint16(data)), data[2:]
+	case info == 26 && len(data) >= 4:
+		n, data = uint64(binary.BigEndian.Uint32(data)), data[4:]
+	case info == 27 && len(data) >= 8:
+		n, data = binary.BigEndian.Uint64(data), data[8:]
+	default:
+		return ErrBadArgs
+	}
+	if n != uint64(len(dst)) || len(data) != len(dst) {
+		return ErrBadArgs
+	}
+	copy(dst, data)
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Marshal Functions
+///////////////////////////////////////////////////////////////////////////////
+
+// MarshalCBOR encodes the bytes as a CBOR byte string.
+func (b Bytes32) MarshalCBOR() ([]byte, error) {
+	return marshalCBOR(b[:]), nil
+}
+
+// MarshalCBOR encodes the bytes as a CBOR byte string.
+func (b Bytes48) MarshalCBOR() ([]byte, error) {
+	return marshalCBOR(b[:]), nil
+}
+
+// MarshalCBOR encodes the blob as a CBOR byte string. Like MarshalText, it has
+// a pointer receiver to avoid copying the blob.
+func (b *Blob) MarshalCBOR() ([]byte, error) {
+	return marshalCBOR(b[:]), nil
+}
+
+// MarshalCBOR encodes the commitment as a CBOR byte string.
+func (c KZGCommitment) MarshalCBOR() ([]byte, error) {
+	return marshalCBOR(c[:]), nil
+}
+
+// MarshalCBOR encodes the proof as a CBOR byte string.
+func (p KZGProof) MarshalCBOR() ([]byte, error) {
+	return marshalCBOR(p[:]), nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Unmarshal Functions
+///////////////////////////////////////////////////////////////////////////////
+
+func (b *Bytes32) UnmarshalCBOR(data []byte) error {
+	return unmarshalCBOR(b[:], data)
+}
+
+func (b *Bytes48) UnmarshalCBOR(data []byte) error {
+	return unmarshalCBOR(b[:], data)
+}
+
+func (b *Blob) UnmarshalCBOR(data []byte) error {
+	return unmarshalCBOR(b[:], data)
+}
+
+func (c *KZGCommitment) UnmarshalCBOR(data []byte) error {
+	return unmarshalCBOR(c[:], data)
+}
+
+func (p *KZGProof) UnmarshalCBOR(data []byte) error {
+	return unmarshalCBOR(p[:], data)
+}
@@ -0,0 +1,63 @@
+package ckzg4844
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCBORRoundTrip(t *testing.T) {
+	blob := new(Blob)
+	fillBlobRandom(blob, 0)
+	commitment, err := BlobToKZGCommitment(blob)
+	require.NoError(t, err)
+	proof, err := ComputeBlobKZGProof(blob, Bytes48(commitment))
+	require.NoError(t, err)
+	z := getRandFieldElement(0)
+
+	// Byte string headers: 1+1 bytes for 32/48 bytes, 1+4 bytes for a blob.
+	data, err := z.MarshalCBOR()
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x58, 0x20}, data[:2])
+	var decodedZ Bytes32
+	require.NoError(t, decodedZ.UnmarshalCBOR(data))
+	require.Equal(t, z, decodedZ)
+
+	data, err = commitment.MarshalCBOR()
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x58, 0x30}, data[:2])
+	var decodedCommitment KZGCommitment
+	require.NoError(t, decodedCommitment.UnmarshalCBOR(data))
+	require.Equal(t, commitment, decodedCommitment)
+
+	data, err = proof.MarshalCBOR()
+	require.NoError(t, err)
+	var decodedProof KZGProof
+	require.NoError(t, decodedProof.UnmarshalCBOR(data))
+	require.Equal(t, proof, decodedProof)
+
+	data, err = blob.MarshalCBOR()
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x5a, 0x00, 0x02, 0x00, 0x00}, data[:5])
+	decodedBlob := new(Blob)
+	require.NoError(t, decodedBlob.UnmarshalCBOR(data))
+	require.Equal(t, blob, decodedBlob)
+}
+
+func TestCBORUnmarshalInvalid(t *testing.T) {
+	commitment, err := KZGCommitment{}.MarshalCBOR()
+	require.NoError(t, err)
+
+	var b Bytes48
+	require.ErrorIs(t, b.UnmarshalCBOR(nil), ErrBadArgs)
+	// Wrong major type (text string).
+	require.ErrorIs(t, b.UnmarshalCBOR(append([]byte{0x78, 0x30}, commitment[2:]...)), ErrBadArgs)
+	// Indefinite length.
+	require.ErrorIs(t, b.UnmarshalCBOR([]byte{0x5f, 0xff}), ErrBadArgs)
+	// Truncated and trailing data.
+	require.ErrorIs(t, b.UnmarshalCBOR(commitment[:len(commitment)-1]), ErrBadArgs)
+	require.ErrorIs(t, b.UnmarshalCBOR(append(commitment, 0)), ErrBadArgs)
+	// Wrong length for the type.
+	var z Bytes32
+	require.ErrorIs(t, z.UnmarshalCBOR(commitment), ErrBadArgs)
+}
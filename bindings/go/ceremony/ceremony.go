@@ -0,0 +1,268 @@
+// Package ceremony verifies powers-of-tau contributions in the format of the
+// Ethereum KZG ceremony.
+//
+// A set of powers holds [tau^i]G1 and [tau^i]G2 for a secret tau, in monomial
+// form and compressed as 0x-prefixed hex strings. A contribution multiplies
+// tau by a new secret x, publishes the new powers and the pot pubkey [x]G2,
+// and optionally signs the contributor's identity with x as a proof of
+// knowledge.
+package ceremony
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// SignatureDST is the domain separation tag for hashing an identity to G1
+// when signing it with the contribution secret.
+const SignatureDST = "BLS_SIG_BLS12381G1_XMD:SHA-256_SSWU_RO_POP_"
+
+var (
+	ErrInvalidPoint     = errors.New("invalid point encoding")
+	ErrLengthMismatch   = errors.New("unexpected number of powers")
+	ErrInvalidGenerator = errors.New("first power is not the generator")
+	ErrInvalidPowers    = errors.New("powers are not consecutive powers of one secret")
+	ErrZeroPubkey       = errors.New("pot pubkey is the identity")
+	ErrInvalidUpdate    = errors.New("powers do not extend the previous powers by the pot pubkey")
+	ErrInvalidSignature = errors.New("invalid proof of knowledge")
+)
+
+// PowersOfTau holds compressed, hex-encoded [tau^i]G1 and [tau^i]G2.
+type PowersOfTau struct {
+	G1Powers []string `json:"G1Powers"`
+	G2Powers []string `json:"G2Powers"`
+}
+
+// Contribution is a single contribution to a sub-ceremony.
+type Contribution struct {
+	NumG1Powers  int         `json:"numG1Powers"`
+	NumG2Powers  int         `json:"numG2Powers"`
+	PowersOfTau  PowersOfTau `json:"powersOfTau"`
+	PotPubkey    string      `json:"potPubkey"`
+	BLSSignature string      `json:"blsSignature,omitempty"`
+}
+
+type powers struct {
+	g1 []bls12381.G1Affine
+	g2 []bls12381.G2Affine
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Helper Functions
+///////////////////////////////////////////////////////////////////////////////
+
+func decodeHex(s string, size int) ([]byte, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil || len(b) != size {
+		return nil, ErrInvalidPoint
+	}
+	return b, nil
+}
+
+// decodeG1 decodes a compressed G1 point, checking that it is in the subgroup.
+func decodeG1(s string) (bls12381.G1Affine, error) {
+	var p bls12381.G1Affine
+	b, err := decodeHex(s, bls12381.SizeOfG1AffineCompressed)
+	if err != nil {
+		return p, err
+	}
+	if _, err := p.SetBytes(b); err != nil {
+		return p, ErrInvalidPoint
+	}
+	return p, nil
+}
+
+// decodeG2 decodes a compressed G2 point, checking that it is in the subgroup.
+func decodeG2(s string) (bls12381.G2Affine, error) {
+	var p bls12381.G2Affine
+	b, err := decodeHex(s, bls12381.SizeOfG2AffineCompressed)
+	if err != nil {
+		return p, err
+	}
+	if _, err := p.SetBytes(b); err != nil {
+		return p, ErrInvalidPoint
+	}
+	return p, nil
+}
+
+func decodePowers(p PowersOfTau) (*powers, error) {
+	if len(p.G1Powers) < 2 || len(p.G2Powers) < 2 {
+		return nil, ErrLengthMismatch
+	}
+	out := &powers{
+		g1: make([]bls12381.G1Affine, len(p.G1Powers)),
+		g2: make([]bls12381.G2Affine, len(p.G2Powers)),
+	}
+	var err error
+	for i, s := range p.G1Powers {
+		if out.g1[i], err = decodeG1(s); err != nil {
+			return nil, err
+		}
+	}
+	for i, s := range p.G2Powers {
+		if out.g2[i], err = decodeG2(s); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func randomScalars(n int) ([]fr.Element, error) {
+	scalars := make([]fr.Element, n)
+	for i := range scalars {
+		if _, err := scalars[i].SetRandom(); err != nil {
+			return nil, err
+		}
+	}
+	return scalars, nil
+}
+
+// pairingsEqual reports whether e(a1, a2) == e(b1, b2).
+func pairingsEqual(a1 *bls12381.G1Affine, a2 *bls12381.G2Affine, b1 *bls12381.G1Affine, b2 *bls12381.G2Affine) (bool, error) {
+	var negB1 bls12381.G1Affine
+	negB1.Neg(b1)
+	return bls12381.PairingCheck([]bls12381.G1Affine{*a1, negB1}, []bls12381.G2Affine{*a2, *b2})
+}
+
+// checkPowers checks that p starts at the generators and that each power is
+// tau times the previous one, with tau = g1[1]. The ratio checks are batched
+// with random linear combinations.
+func checkPowers(p *powers) error {
+	_, _, g1Gen, g2Gen := bls12381.Generators()
+	if !p.g1[0].Equal(&g1Gen) || !p.g2[0].Equal(&g2Gen) {
+		return ErrInvalidGenerator
+	}
+	if p.g1[1].IsInfinity() {
+		return ErrInvalidPowers
+	}
+
+	// e(sum r_i g1[i+1], G2) == e(sum r_i g1[i], [tau]G2)
+	r, err := randomScalars(len(p.g1) - 1)
+	if err != nil {
+		return err
+	}
+	var lower, upper bls12381.G1Affine
+	if _, err := lower.MultiExp(p.g1[:len(p.g1)-1], r, ecc.MultiExpConfig{}); err != nil {
+		return err
+	}
+	if _, err := upper.MultiExp(p.g1[1:], r, ecc.MultiExpConfig{}); err != nil {
+		return err
+	}
+	ok, err := pairingsEqual(&upper, &p.g2[0], &lower, &p.g2[1])
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidPowers
+	}
+
+	// e([tau]G1, sum t_j g2[j]) == e(G1, sum t_j g2[j+1])
+	t, err := randomScalars(len(p.g2) - 1)
+	if err != nil {
+		return err
+	}
+	var lowerG2, upperG2 bls12381.G2Affine
+	if _, err := lowerG2.MultiExp(p.g2[:len(p.g2)-1], t, ecc.MultiExpConfig{}); err != nil {
+		return err
+	}
+	if _, err := upperG2.MultiExp(p.g2[1:], t, ecc.MultiExpConfig{}); err != nil {
+		return err
+	}
+	ok, err = pairingsEqual(&p.g1[1], &lowerG2, &g1Gen, &upperG2)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidPowers
+	}
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Verification Functions
+///////////////////////////////////////////////////////////////////////////////
+
+// VerifyPowersOfTau checks that p is well-formed: every point is in its
+// subgroup, the first powers are the generators and all powers are
+// consecutive powers of one secret.
+func VerifyPowersOfTau(p PowersOfTau) error {
+	decoded, err := decodePowers(p)
+	if err != nil {
+		return err
+	}
+	return checkPowers(decoded)
+}
+
+// VerifyContribution checks that c is a valid update of previous: the new
+// powers are well-formed, have the same lengths as the previous ones and
+// their secret is the previous secret times the secret of the pot pubkey.
+// It does not check the signature; see VerifyProofOfKnowledge.
+func VerifyContribution(previous PowersOfTau, c *Contribution) error {
+	if c.NumG1Powers != len(c.PowersOfTau.G1Powers) || c.NumG2Powers != len(c.PowersOfTau.G2Powers) ||
+		c.NumG1Powers != len(previous.G1Powers) || c.NumG2Powers != len(previous.G2Powers) {
+		return ErrLengthMismatch
+	}
+	prev, err := decodePowers(previous)
+	if err != nil {
+		return err
+	}
+	next, err := decodePowers(c.PowersOfTau)
+	if err != nil {
+		return err
+	}
+	pubkey, err := decodeG2(c.PotPubkey)
+	if err != nil {
+		return err
+	}
+	if pubkey.IsInfinity() {
+		return ErrZeroPubkey
+	}
+	if err := checkPowers(next); err != nil {
+		return err
+	}
+
+	// e([tau x]G1, G2) == e([tau]G1, [x]G2)
+	_, _, _, g2Gen := bls12381.Generators()
+	ok, err := pairingsEqual(&next.g1[1], &g2Gen, &prev.g1[1], &pubkey)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidUpdate
+	}
+	return nil
+}
+
+// VerifyProofOfKnowledge checks that signature is a BLS signature, in G1, of
+// identity under potPubkey, which proves knowledge of the contribution
+// secret.
+func VerifyProofOfKnowledge(potPubkey, signature, identity string) error {
+	pubkey, err := decodeG2(potPubkey)
+	if err != nil {
+		return err
+	}
+	sig, err := decodeG1(signature)
+	if err != nil {
+		return err
+	}
+	message, err := bls12381.HashToG1([]byte(identity), []byte(SignatureDST))
+	if err != nil {
+		return err
+	}
+
+	// e(signature, G2) == e(H(identity), [x]G2)
+	_, _, _, g2Gen := bls12381.Generators()
+	ok, err := pairingsEqual(&sig, &g2Gen, &message, &pubkey)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidSignature
+	}
+	return nil
+}
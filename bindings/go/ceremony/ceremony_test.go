@@ -0,0 +1,108 @@
+package ceremony
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	numG1Powers = 16
+	numG2Powers = 3
+)
+
+// getPowers returns the powers of tau, encoded for the ceremony.
+func getPowers(tau int64) PowersOfTau {
+	_, _, g1Gen, g2Gen := bls12381.Generators()
+	var p PowersOfTau
+	power, modulus := big.NewInt(1), fr.Modulus()
+	for i := 0; i < numG1Powers; i++ {
+		var g1 bls12381.G1Affine
+		g1.ScalarMultiplication(&g1Gen, power)
+		g1Bytes := g1.Bytes()
+		p.G1Powers = append(p.G1Powers, "0x"+hex.EncodeToString(g1Bytes[:]))
+		if i < numG2Powers {
+			var g2 bls12381.G2Affine
+			g2.ScalarMultiplication(&g2Gen, power)
+			g2Bytes := g2.Bytes()
+			p.G2Powers = append(p.G2Powers, "0x"+hex.EncodeToString(g2Bytes[:]))
+		}
+		power.Mul(power, big.NewInt(tau))
+		power.Mod(power, modulus)
+	}
+	return p
+}
+
+func getPubkey(x int64) string {
+	_, _, _, g2Gen := bls12381.Generators()
+	var pubkey bls12381.G2Affine
+	pubkey.ScalarMultiplication(&g2Gen, big.NewInt(x))
+	b := pubkey.Bytes()
+	return "0x" + hex.EncodeToString(b[:])
+}
+
+func getContribution(previousTau, x int64) *Contribution {
+	return &Contribution{
+		NumG1Powers: numG1Powers,
+		NumG2Powers: numG2Powers,
+		PowersOfTau: getPowers(previousTau * x),
+		PotPubkey:   getPubkey(x),
+	}
+}
+
+func TestVerifyPowersOfTau(t *testing.T) {
+	require.NoError(t, VerifyPowersOfTau(getPowers(1)))
+	require.NoError(t, VerifyPowersOfTau(getPowers(12345)))
+
+	p := getPowers(12345)
+	p.G1Powers[5], p.G1Powers[6] = p.G1Powers[6], p.G1Powers[5]
+	require.ErrorIs(t, VerifyPowersOfTau(p), ErrInvalidPowers)
+
+	p = getPowers(12345)
+	p.G2Powers[2] = getPowers(999).G2Powers[2]
+	require.ErrorIs(t, VerifyPowersOfTau(p), ErrInvalidPowers)
+
+	p = getPowers(12345)
+	p.G1Powers = p.G1Powers[1:]
+	require.ErrorIs(t, VerifyPowersOfTau(p), ErrInvalidGenerator)
+
+	p = getPowers(12345)
+	p.G1Powers[3] = "0x00"
+	require.ErrorIs(t, VerifyPowersOfTau(p), ErrInvalidPoint)
+}
+
+func TestVerifyContribution(t *testing.T) {
+	previous := getPowers(7)
+	require.NoError(t, VerifyContribution(previous, getContribution(7, 11)))
+
+	// Powers for a secret other than the previous one times x.
+	c := getContribution(7, 11)
+	c.PowersOfTau = getPowers(13 * 11)
+	require.ErrorIs(t, VerifyContribution(previous, c), ErrInvalidUpdate)
+
+	c = getContribution(7, 11)
+	c.PotPubkey = getPubkey(0)
+	require.ErrorIs(t, VerifyContribution(previous, c), ErrZeroPubkey)
+
+	c = getContribution(7, 11)
+	c.NumG1Powers--
+	require.ErrorIs(t, VerifyContribution(previous, c), ErrLengthMismatch)
+}
+
+func TestVerifyProofOfKnowledge(t *testing.T) {
+	identity := "git|1234|contributor"
+	message, err := bls12381.HashToG1([]byte(identity), []byte(SignatureDST))
+	require.NoError(t, err)
+	var sig bls12381.G1Affine
+	sig.ScalarMultiplication(&message, big.NewInt(11))
+	sigBytes := sig.Bytes()
+	signature := "0x" + hex.EncodeToString(sigBytes[:])
+
+	require.NoError(t, VerifyProofOfKnowledge(getPubkey(11), signature, identity))
+	require.ErrorIs(t, VerifyProofOfKnowledge(getPubkey(12), signature, identity), ErrInvalidSignature)
+	require.ErrorIs(t, VerifyProofOfKnowledge(getPubkey(11), signature, "other"), ErrInvalidSignature)
+}
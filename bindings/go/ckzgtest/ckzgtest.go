@@ -0,0 +1,125 @@
+// Package ckzgtest provides helpers for testing code that uses the ckzg4844
+// package: deterministic random inputs, ways to corrupt them, and generators
+// and properties for testing/quick.
+//
+// Every function that takes a seed returns the same value for the same seed,
+// on every platform and in every release, so tests built on them are
+// reproducible. The functions do not use the global math/rand source.
+package ckzgtest
+
+import (
+	"fmt"
+	"math/rand"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+)
+
+// blsModulus is the BLS scalar field modulus, big-endian. It is the smallest
+// non-canonical field element.
+var blsModulus = ckzg4844.Bytes32{
+	0x73, 0xed, 0xa7, 0x53, 0x29, 0x9d, 0x7d, 0x48,
+	0x33, 0x39, 0xd8, 0x08, 0x09, 0xa1, 0xd8, 0x05,
+	0x53, 0xbd, 0xa4, 0x02, 0xff, 0xfe, 0x5b, 0xfe,
+	0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x00, 0x01,
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Random Inputs
+///////////////////////////////////////////////////////////////////////////////
+
+// randomFieldElement reads a canonical field element from r. The first byte
+// is left zero, which guarantees it is less than the modulus.
+func randomFieldElement(r *rand.Rand) ckzg4844.Bytes32 {
+	var fieldElement ckzg4844.Bytes32
+	r.Read(fieldElement[1:])
+	return fieldElement
+}
+
+// RandomFieldElement returns a canonical field element derived from seed.
+func RandomFieldElement(seed int64) ckzg4844.Bytes32 {
+	return randomFieldElement(rand.New(rand.NewSource(seed)))
+}
+
+// RandomBlob returns a valid blob derived from seed.
+func RandomBlob(seed int64) *ckzg4844.Blob {
+	r := rand.New(rand.NewSource(seed))
+	blob := new(ckzg4844.Blob)
+	for i := 0; i < ckzg4844.FieldElementsPerBlob; i++ {
+		fieldElement := randomFieldElement(r)
+		copy(blob[i*ckzg4844.BytesPerFieldElement:], fieldElement[:])
+	}
+	return blob
+}
+
+// RandomBlobs returns n valid blobs, derived from seed, seed+1, and so on.
+func RandomBlobs(seed int64, n int) []ckzg4844.Blob {
+	blobs := make([]ckzg4844.Blob, n)
+	for i := range blobs {
+		blobs[i] = *RandomBlob(seed + int64(i))
+	}
+	return blobs
+}
+
+// RandomBundle returns n blobs, as from RandomBlobs, with their commitments
+// and blob proofs. The trusted setup must be loaded. It panics if computing a
+// commitment or proof fails, which does not happen for valid blobs.
+func RandomBundle(seed int64, n int) (blobs []ckzg4844.Blob, commitments, proofs []ckzg4844.Bytes48) {
+	blobs = RandomBlobs(seed, n)
+	commitments = make([]ckzg4844.Bytes48, n)
+	proofs = make([]ckzg4844.Bytes48, n)
+	for i := range blobs {
+		commitment, err := ckzg4844.BlobToKZGCommitment(&blobs[i])
+		if err != nil {
+			panic(fmt.Sprintf("failed to compute commitment: %v", err))
+		}
+		proof, err := ckzg4844.ComputeBlobKZGProof(&blobs[i], ckzg4844.Bytes48(commitment))
+		if err != nil {
+			panic(fmt.Sprintf("failed to compute proof: %v", err))
+		}
+		commitments[i] = ckzg4844.Bytes48(commitment)
+		proofs[i] = ckzg4844.Bytes48(proof)
+	}
+	return blobs, commitments, proofs
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Corruption
+///////////////////////////////////////////////////////////////////////////////
+
+// NonCanonicalFieldElement returns the BLS modulus, the smallest field
+// element that is not canonical.
+func NonCanonicalFieldElement() ckzg4844.Bytes32 {
+	return blsModulus
+}
+
+// CorruptFieldElement returns a copy of blob in which the i-th field element
+// is replaced by the BLS modulus, so that blob validation fails there.
+func CorruptFieldElement(blob *ckzg4844.Blob, i int) *ckzg4844.Blob {
+	corrupted := *blob
+	copy(corrupted[i*ckzg4844.BytesPerFieldElement:], blsModulus[:])
+	return &corrupted
+}
+
+// InvalidPoint returns bytes that are not the encoding of any G1 point: the
+// compression flag is set, but the x-coordinate is not on the curve.
+func InvalidPoint() ckzg4844.Bytes48 {
+	point := ckzg4844.Bytes48{0x80}
+	point[47] = 0x01
+	return point
+}
+
+// Tweak returns fieldElement with the lowest bit of its last byte flipped.
+// The result stays canonical if the first byte is zero, as it is for the
+// field elements returned by this package.
+func Tweak(fieldElement ckzg4844.Bytes32) ckzg4844.Bytes32 {
+	fieldElement[31] ^= 0x01
+	return fieldElement
+}
+
+// SwapProofs returns a copy of proofs with the entries at i and j swapped,
+// which invalidates both entries unless the proofs are equal.
+func SwapProofs(proofs []ckzg4844.Bytes48, i, j int) []ckzg4844.Bytes48 {
+	swapped := append([]ckzg4844.Bytes48(nil), proofs...)
+	swapped[i], swapped[j] = swapped[j], swapped[i]
+	return swapped
+}
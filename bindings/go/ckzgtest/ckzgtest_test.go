@@ -0,0 +1,85 @@
+package ckzgtest
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"testing/quick"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	if err := ckzg4844.LoadTrustedSetupFile("../../../src/trusted_setup.txt"); err != nil {
+		panic(fmt.Sprintf("failed to load trusted setup: %v", err))
+	}
+	defer ckzg4844.FreeTrustedSetup()
+	code := m.Run()
+	os.Exit(code)
+}
+
+func TestRandomIsDeterministic(t *testing.T) {
+	require.Equal(t, RandomFieldElement(1), RandomFieldElement(1))
+	require.NotEqual(t, RandomFieldElement(1), RandomFieldElement(2))
+	require.Equal(t, RandomBlob(1), RandomBlob(1))
+	require.NotEqual(t, RandomBlob(1), RandomBlob(2))
+	require.Equal(t, []ckzg4844.Blob{*RandomBlob(5), *RandomBlob(6)}, RandomBlobs(5, 2))
+
+	// Pin the output, so changes to the derivation are noticed.
+	require.Equal(t, "0x000194fdc2fa2ffcc041d3ff12045b73c86e4ff95ff662a5eee82abdf44a2d0b", RandomFieldElement(0).String())
+}
+
+func TestRandomBundle(t *testing.T) {
+	blobs, commitments, proofs := RandomBundle(0, 3)
+	require.NoError(t, ckzg4844.ValidateBlob(&blobs[0]))
+	ok, err := ckzg4844.VerifyBlobKZGProofBatch(blobs, commitments, proofs)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = ckzg4844.VerifyBlobKZGProofBatch(blobs, commitments, SwapProofs(proofs, 0, 2))
+	require.NoError(t, err)
+	require.False(t, ok)
+	failed, err := ckzg4844.FindInvalidBlobKZGProofs(blobs, commitments, SwapProofs(proofs, 0, 2), ckzg4844.FullReport)
+	require.NoError(t, err)
+	require.Equal(t, []int{0, 2}, failed)
+}
+
+func TestCorruption(t *testing.T) {
+	blob := RandomBlob(0)
+	corrupted := CorruptFieldElement(blob, 9)
+	require.NoError(t, ckzg4844.ValidateBlob(blob))
+	require.Equal(t, []int{9}, corrupted.NonCanonicalFieldElements())
+
+	require.ErrorIs(t, ckzg4844.ValidateFieldElement(NonCanonicalFieldElement()), ckzg4844.ErrBadArgs)
+	require.ErrorIs(t, ckzg4844.ValidateG1(InvalidPoint()), ckzg4844.ErrBadArgs)
+
+	fieldElement := RandomFieldElement(0)
+	require.NotEqual(t, fieldElement, Tweak(fieldElement))
+	require.NoError(t, ckzg4844.ValidateFieldElement(Tweak(fieldElement)))
+}
+
+func TestProperties(t *testing.T) {
+	require.NoError(t, quick.Check(PropFieldElementValidation, nil))
+	require.NoError(t, quick.Check(PropG1Validation, nil))
+
+	config := &quick.Config{MaxCount: 8}
+	require.NoError(t, quick.Check(PropBlobProofVerifies, config))
+	require.NoError(t, quick.Check(PropKZGProofVerifies, config))
+}
+
+func TestStress(t *testing.T) {
+	cfg := StressConfig{Goroutines: 8, Iterations: 4}
+	if testing.Short() {
+		cfg.Iterations = 1
+	}
+	Stress(t, cfg)
+}
+
+func TestStressReload(t *testing.T) {
+	cfg := StressConfig{Goroutines: 8, Iterations: 4, Reload: true}
+	if testing.Short() {
+		cfg.Iterations = 1
+	}
+	Stress(t, cfg)
+}
@@ -0,0 +1,173 @@
+package ckzgtest
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+)
+
+// The types below implement testing/quick.Generator, so they can be used as
+// arguments of properties checked with quick.Check. Their generators favour
+// boundary values, which random bytes would practically never hit. Converting
+// them to the ckzg4844 types is free.
+
+// boundaryFieldElements are the canonical field elements at the edges of the
+// field: zero, one and the modulus minus one.
+var boundaryFieldElements = []ckzg4844.Bytes32{
+	{},
+	{31: 0x01},
+	Tweak(blsModulus),
+}
+
+// nonCanonicalFieldElements are the smallest and largest values that are not
+// canonical field elements, and one in between.
+var nonCanonicalFieldElements = []ckzg4844.Bytes32{
+	blsModulus,
+	func() ckzg4844.Bytes32 { b := blsModulus; b[31]++; return b }(),
+	{0x80},
+	bytes32Max(),
+}
+
+func bytes32Max() ckzg4844.Bytes32 {
+	var b ckzg4844.Bytes32
+	for i := range b {
+		b[i] = 0xff
+	}
+	return b
+}
+
+// FieldElement is a canonical field element. A quarter of the generated
+// values are zero, one or the modulus minus one.
+type FieldElement ckzg4844.Bytes32
+
+func generateFieldElement(r *rand.Rand) ckzg4844.Bytes32 {
+	if r.Intn(4) == 0 {
+		return boundaryFieldElements[r.Intn(len(boundaryFieldElements))]
+	}
+	return randomFieldElement(r)
+}
+
+func (FieldElement) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(FieldElement(generateFieldElement(r)))
+}
+
+// AnyFieldElement is a field element that may not be canonical. Half of the
+// generated values are not canonical, including the modulus itself.
+type AnyFieldElement ckzg4844.Bytes32
+
+func (AnyFieldElement) Generate(r *rand.Rand, size int) reflect.Value {
+	if r.Intn(2) == 0 {
+		return reflect.ValueOf(AnyFieldElement(nonCanonicalFieldElements[r.Intn(len(nonCanonicalFieldElements))]))
+	}
+	return reflect.ValueOf(AnyFieldElement(generateFieldElement(r)))
+}
+
+// CanonicalBlob is a valid blob. Its field elements are generated as for
+// FieldElement, so some are zero, one or the modulus minus one. Some of the
+// generated blobs are all zeros, whose commitment is the point at infinity.
+type CanonicalBlob ckzg4844.Blob
+
+func (CanonicalBlob) Generate(r *rand.Rand, size int) reflect.Value {
+	var blob CanonicalBlob
+	if r.Intn(8) != 0 {
+		for i := 0; i < ckzg4844.FieldElementsPerBlob; i++ {
+			fieldElement := generateFieldElement(r)
+			copy(blob[i*ckzg4844.BytesPerFieldElement:], fieldElement[:])
+		}
+	}
+	return reflect.ValueOf(blob)
+}
+
+// AnyG1Point is a G1 point encoding that may be invalid: the point at
+// infinity, the generator, an encoding that is not on the curve, or random
+// bytes.
+type AnyG1Point ckzg4844.Bytes48
+
+// g1Generator is the compressed generator of G1.
+var g1Generator = ckzg4844.Bytes48{
+	0x97, 0xf1, 0xd3, 0xa7, 0x31, 0x97, 0xd7, 0x94,
+	0x26, 0x95, 0x63, 0x8c, 0x4f, 0xa9, 0xac, 0x0f,
+	0xc3, 0x68, 0x8c, 0x4f, 0x97, 0x74, 0xb9, 0x05,
+	0xa1, 0x4e, 0x3a, 0x3f, 0x17, 0x1b, 0xac, 0x58,
+	0x6c, 0x55, 0xe8, 0x3f, 0xf9, 0x7a, 0x1a, 0xef,
+	0xfb, 0x3a, 0xf0, 0x0a, 0xdb, 0x22, 0xc6, 0xbb,
+}
+
+func (AnyG1Point) Generate(r *rand.Rand, size int) reflect.Value {
+	var point ckzg4844.Bytes48
+	switch r.Intn(4) {
+	case 0:
+		point = ckzg4844.Bytes48{0xc0}
+	case 1:
+		point = g1Generator
+	case 2:
+		point = InvalidPoint()
+	default:
+		r.Read(point[:])
+	}
+	return reflect.ValueOf(AnyG1Point(point))
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Properties
+///////////////////////////////////////////////////////////////////////////////
+
+// The properties below hold for any arguments; check them with quick.Check.
+// Those that commit to blobs need the trusted setup to be loaded and take
+// tens of milliseconds per blob, so a small quick.Config.MaxCount is
+// advisable.
+
+// PropFieldElementValidation checks that ValidateFieldElement accepts exactly
+// the values less than the modulus.
+func PropFieldElementValidation(fieldElement AnyFieldElement) bool {
+	canonical := bytes.Compare(fieldElement[:], blsModulus[:]) < 0
+	return (ckzg4844.ValidateFieldElement(ckzg4844.Bytes32(fieldElement)) == nil) == canonical
+}
+
+// PropG1Validation checks that ValidateG1 and NewKZGCommitmentFromHex agree.
+func PropG1Validation(point AnyG1Point) bool {
+	_, err := ckzg4844.NewKZGCommitmentFromHex(ckzg4844.Bytes48(point).String())
+	return (ckzg4844.ValidateG1(ckzg4844.Bytes48(point)) == nil) == (err == nil)
+}
+
+// PropBlobProofVerifies checks that the blob proof computed for a blob and
+// its commitment verifies.
+func PropBlobProofVerifies(blob CanonicalBlob) bool {
+	b := (*ckzg4844.Blob)(&blob)
+	commitment, err := ckzg4844.BlobToKZGCommitment(b)
+	if err != nil {
+		return false
+	}
+	proof, err := ckzg4844.ComputeBlobKZGProof(b, ckzg4844.Bytes48(commitment))
+	if err != nil {
+		return false
+	}
+	ok, err := ckzg4844.VerifyBlobKZGProof(b, ckzg4844.Bytes48(commitment), ckzg4844.Bytes48(proof))
+	return err == nil && ok
+}
+
+// PropKZGProofVerifies checks that the proof computed for an evaluation of a
+// blob verifies, and that it does not verify for any other value.
+func PropKZGProofVerifies(blob CanonicalBlob, z FieldElement) bool {
+	b := (*ckzg4844.Blob)(&blob)
+	commitment, err := ckzg4844.BlobToKZGCommitment(b)
+	if err != nil {
+		return false
+	}
+	proof, y, err := ckzg4844.ComputeKZGProof(b, ckzg4844.Bytes32(z))
+	if err != nil {
+		return false
+	}
+	ok, err := ckzg4844.VerifyKZGProof(ckzg4844.Bytes48(commitment), ckzg4844.Bytes32(z), y, ckzg4844.Bytes48(proof))
+	if err != nil || !ok {
+		return false
+	}
+	wrongY := Tweak(y)
+	if ckzg4844.ValidateFieldElement(wrongY) != nil {
+		return true
+	}
+	ok, err = ckzg4844.VerifyKZGProof(ckzg4844.Bytes48(commitment), ckzg4844.Bytes32(z), wrongY, ckzg4844.Bytes48(proof))
+	return err == nil && !ok
+}
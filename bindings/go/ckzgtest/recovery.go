@@ -0,0 +1,41 @@
+package ckzgtest
+
+import ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+
+// The helpers below make subtly wrong inputs for recovering a codeword from
+// some of its evaluations, as done by the rs package. An evaluation is given
+// by its position in the codeword, indices[i], and its value,
+// evaluations[i]. Each helper returns modified copies and leaves its
+// arguments unchanged.
+//
+// Recovery can only detect such inputs when it is given more evaluations
+// than the minimum: any FieldElementsPerBlob evaluations lie on some
+// codeword, so with exactly that many a wrong input recovers a wrong
+// codeword instead of failing.
+
+// MixEvaluations replaces the evaluation at positions[k] of the sample with
+// the value of other, a different codeword, at the same index, for each k.
+func MixEvaluations(indices []int, evaluations, other []ckzg4844.Bytes32, positions ...int) []ckzg4844.Bytes32 {
+	mixed := append([]ckzg4844.Bytes32(nil), evaluations...)
+	for _, k := range positions {
+		mixed[k] = other[indices[k]]
+	}
+	return mixed
+}
+
+// SwapIndices swaps the indices at i and j, so that two evaluations are
+// attributed to each other's positions.
+func SwapIndices(indices []int, i, j int) []int {
+	swapped := append([]int(nil), indices...)
+	swapped[i], swapped[j] = swapped[j], swapped[i]
+	return swapped
+}
+
+// FlipBit flips one bit of the evaluation at i. Bit 0 is the lowest bit of
+// the last byte, which keeps a canonical value canonical, unless it is the
+// modulus minus one.
+func FlipBit(evaluations []ckzg4844.Bytes32, i, bit int) []ckzg4844.Bytes32 {
+	flipped := append([]ckzg4844.Bytes32(nil), evaluations...)
+	flipped[i][31-bit/8] ^= 1 << (bit % 8)
+	return flipped
+}
@@ -0,0 +1,158 @@
+package ckzgtest
+
+import (
+	"math/rand"
+	"runtime"
+	"sync"
+	"testing"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+)
+
+// StressConfig configures Stress. Zero fields take their default value.
+type StressConfig struct {
+	// Goroutines is the number of goroutines calling into the library at
+	// once. It defaults to 4 * GOMAXPROCS.
+	Goroutines int
+	// Iterations is the number of operations done by each goroutine. It
+	// defaults to 16.
+	Iterations int
+	// Blobs is the number of distinct blobs the operations pick from, and
+	// the maximum batch size. It defaults to 4.
+	Blobs int
+	// Seed selects the blobs and the sequence of operations.
+	Seed int64
+	// Reload makes one more goroutine free and reload the trusted setup
+	// Iterations times while the others run. Operations that find no setup
+	// loaded are then skipped, provided the panic guard is disabled.
+	Reload bool
+}
+
+func (cfg StressConfig) withDefaults() StressConfig {
+	if cfg.Goroutines == 0 {
+		cfg.Goroutines = 4 * runtime.GOMAXPROCS(0)
+	}
+	if cfg.Iterations == 0 {
+		cfg.Iterations = 16
+	}
+	if cfg.Blobs == 0 {
+		cfg.Blobs = 4
+	}
+	return cfg
+}
+
+/*
+Stress calls every operation of the library from many goroutines at once, and
+reports an error on t for every result that differs from the result computed
+up front on a single goroutine. Run it with the race detector enabled to check
+that concurrent use does not race.
+
+The trusted setup must be loaded, and is loaded again when Stress returns.
+Unless cfg.Reload is set, it must not be loaded or freed by anything else
+while Stress runs.
+*/
+func Stress(t testing.TB, cfg StressConfig) {
+	t.Helper()
+	cfg = cfg.withDefaults()
+	blobs, commitments, proofs := RandomBundle(cfg.Seed, cfg.Blobs)
+	z := RandomFieldElement(cfg.Seed)
+	evaluationProof, y, err := ckzg4844.ComputeKZGProof(&blobs[0], z)
+	if err != nil {
+		t.Fatalf("ComputeKZGProof failed: %v", err)
+	}
+
+	operations := []func(r *rand.Rand){
+		func(r *rand.Rand) {
+			i := r.Intn(cfg.Blobs)
+			commitment, err := ckzg4844.BlobToKZGCommitment(&blobs[i])
+			if err != nil || ckzg4844.Bytes48(commitment) != commitments[i] {
+				t.Errorf("BlobToKZGCommitment(blob %v) = %v, %v", i, commitment, err)
+			}
+		},
+		func(r *rand.Rand) {
+			i := r.Intn(cfg.Blobs)
+			proof, err := ckzg4844.ComputeBlobKZGProof(&blobs[i], commitments[i])
+			if err != nil || ckzg4844.Bytes48(proof) != proofs[i] {
+				t.Errorf("ComputeBlobKZGProof(blob %v) = %v, %v", i, proof, err)
+			}
+		},
+		func(r *rand.Rand) {
+			proof, gotY, err := ckzg4844.ComputeKZGProof(&blobs[0], z)
+			if err != nil || proof != evaluationProof || gotY != y {
+				t.Errorf("ComputeKZGProof = %v, %v, %v", proof, gotY, err)
+			}
+		},
+		func(r *rand.Rand) {
+			ok, err := ckzg4844.VerifyKZGProof(commitments[0], z, y, ckzg4844.Bytes48(evaluationProof))
+			if err != nil || !ok {
+				t.Errorf("VerifyKZGProof = %v, %v", ok, err)
+			}
+		},
+		func(r *rand.Rand) {
+			i := r.Intn(cfg.Blobs)
+			ok, err := ckzg4844.VerifyBlobKZGProof(&blobs[i], commitments[i], proofs[i])
+			if err != nil || !ok {
+				t.Errorf("VerifyBlobKZGProof(blob %v) = %v, %v", i, ok, err)
+			}
+		},
+		func(r *rand.Rand) {
+			n := 1 + r.Intn(cfg.Blobs)
+			ok, err := ckzg4844.VerifyBlobKZGProofBatch(blobs[:n], commitments[:n], proofs[:n])
+			if err != nil || !ok {
+				t.Errorf("VerifyBlobKZGProofBatch(%v blobs) = %v, %v", n, ok, err)
+			}
+		},
+		func(r *rand.Rand) {
+			if cfg.Blobs < 2 {
+				return
+			}
+			ok, err := ckzg4844.VerifyBlobKZGProofBatch(blobs[:2], commitments[:2], SwapProofs(proofs[:2], 0, 1))
+			if err != nil || ok {
+				t.Errorf("VerifyBlobKZGProofBatch(swapped proofs) = %v, %v", ok, err)
+			}
+		},
+	}
+
+	var wg sync.WaitGroup
+	if cfg.Reload {
+		g1Bytes, g2Bytes := ckzg4844.TrustedSetupBytes()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < cfg.Iterations; i++ {
+				ckzg4844.FreeTrustedSetup()
+				if err := ckzg4844.LoadTrustedSetup(g1Bytes, g2Bytes); err != nil {
+					t.Errorf("LoadTrustedSetup failed: %v", err)
+					return
+				}
+			}
+		}()
+	}
+	for g := 0; g < cfg.Goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(cfg.Seed + int64(g)))
+			for i := 0; i < cfg.Iterations; i++ {
+				operation := operations[r.Intn(len(operations))]
+				if cfg.Reload {
+					skipIfNotLoaded(operation, r)
+				} else {
+					operation(r)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+}
+
+// skipIfNotLoaded calls operation, recovering from the panic of a call made
+// while the trusted setup is freed.
+func skipIfNotLoaded(operation func(r *rand.Rand), r *rand.Rand) {
+	defer func() {
+		if v := recover(); v != nil && v != "trusted setup isn't loaded" {
+			panic(v)
+		}
+	}()
+	operation(r)
+}
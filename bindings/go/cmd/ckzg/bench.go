@@ -0,0 +1,181 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+	"github.com/ethereum/c-kzg-4844/bindings/go/bench"
+)
+
+func init() {
+	commands["bench"] = command{
+		usage:   "[-counts LIST] [-missing LIST] [-goroutines LIST] [-run REGEXP] [-format json|csv]",
+		summary: "Benchmark the KZG operations and recovery, and write a performance report.",
+		run:     runBench,
+	}
+}
+
+// machine describes where a report was produced. The C library has no
+// precomputation or multi-scalar multiplication settings, so there are no
+// parameters to report besides these.
+type machine struct {
+	OS        string `json:"os"`
+	Arch      string `json:"arch"`
+	NumCPU    int    `json:"num_cpu"`
+	GoVersion string `json:"go_version"`
+}
+
+// benchResult is a bench.Result with its throughput.
+type benchResult struct {
+	bench.Result
+	OpsPerSecond float64 `json:"ops_per_second"`
+}
+
+// report is the JSON output of ckzg bench.
+type report struct {
+	Machine machine       `json:"machine"`
+	Results []benchResult `json:"results"`
+}
+
+func runBench(fs *flag.FlagSet, args []string, stdout io.Writer) error {
+	defaults := bench.DefaultConfig()
+	setup := setupFlag(fs)
+	counts := fs.String("counts", formatList(defaults.BlobCounts), "comma-separated batch sizes of VerifyBlobKZGProofBatch")
+	missing := fs.String("missing", formatList(defaults.MissingFractions), "comma-separated fractions of missing evaluations for Recover")
+	goroutines := fs.String("goroutines", formatList(defaults.Goroutines), "comma-separated numbers of goroutines verifying concurrently")
+	pattern := fs.String("run", "", "only run the benchmarks whose name matches this regular expression")
+	format := fs.String("format", "json", "output format: json or csv")
+	out := fs.String("o", "", "output file (default stdout)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs); err != nil {
+		return err
+	}
+	if *format != "json" && *format != "csv" {
+		return fmt.Errorf("unknown format %q, expected json or csv", *format)
+	}
+	var cfg bench.Config
+	var err error
+	if cfg.BlobCounts, err = parseList(*counts, strconv.Atoi); err != nil {
+		return fmt.Errorf("-counts: %w", err)
+	}
+	if cfg.MissingFractions, err = parseList(*missing, func(s string) (float64, error) {
+		return strconv.ParseFloat(s, 64)
+	}); err != nil {
+		return fmt.Errorf("-missing: %w", err)
+	}
+	if cfg.Goroutines, err = parseList(*goroutines, strconv.Atoi); err != nil {
+		return fmt.Errorf("-goroutines: %w", err)
+	}
+	if err := validateConfig(cfg); err != nil {
+		return err
+	}
+	re, err := regexp.Compile(*pattern)
+	if err != nil {
+		return fmt.Errorf("-run: %w", err)
+	}
+	if err := loadSetup(*setup); err != nil {
+		return err
+	}
+	defer ckzg4844.FreeTrustedSetup()
+
+	var results []bench.Result
+	for _, c := range bench.Cases(cfg) {
+		if !re.MatchString(c.Name) {
+			continue
+		}
+		r := testing.Benchmark(c.F)
+		result := c.Result
+		result.Iterations = r.N
+		result.NsPerOp = r.NsPerOp()
+		results = append(results, result)
+	}
+
+	return writeOutput(*out, stdout, func(w io.Writer) error {
+		if *format == "csv" {
+			return bench.WriteCSV(w, results)
+		}
+		return writeReport(w, results)
+	})
+}
+
+// writeReport writes results as a report, in indented JSON.
+func writeReport(w io.Writer, results []bench.Result) error {
+	r := report{
+		Machine: machine{
+			OS:        runtime.GOOS,
+			Arch:      runtime.GOARCH,
+			NumCPU:    runtime.NumCPU(),
+			GoVersion: runtime.Version(),
+		},
+		Results: make([]benchResult, len(results)),
+	}
+	for i, result := range results {
+		r.Results[i].Result = result
+		if result.NsPerOp > 0 {
+			r.Results[i].OpsPerSecond = 1e9 / float64(result.NsPerOp)
+		}
+	}
+	encoded, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(encoded, '\n'))
+	return err
+}
+
+// validateConfig checks the parameters that bench.Cases does not.
+func validateConfig(cfg bench.Config) error {
+	for _, count := range cfg.BlobCounts {
+		if count < 1 {
+			return errors.New("-counts: batch sizes must be positive")
+		}
+	}
+	for _, fraction := range cfg.MissingFractions {
+		if fraction < 0 || fraction > 0.5 {
+			return errors.New("-missing: fractions must be in [0, 0.5]")
+		}
+	}
+	for _, goroutines := range cfg.Goroutines {
+		if goroutines < 1 {
+			return errors.New("-goroutines: numbers of goroutines must be positive")
+		}
+	}
+	return nil
+}
+
+// parseList parses a comma-separated list. The empty string is the empty
+// list.
+func parseList[T any](s string, parse func(string) (T, error)) ([]T, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var list []T
+	for _, field := range strings.Split(s, ",") {
+		v, err := parse(strings.TrimSpace(field))
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, v)
+	}
+	return list, nil
+}
+
+// formatList formats a list as parsed by parseList.
+func formatList[T any](list []T) string {
+	fields := make([]string, len(list))
+	for i, v := range list {
+		fields[i] = fmt.Sprint(v)
+	}
+	return strings.Join(fields, ",")
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBench(t *testing.T) {
+	stdout := requireRun(t, exitOK, "bench", "-counts", "2", "-missing", "", "-goroutines", "", "-run", "^VerifyBlobKZGProofBatch/")
+	var r report
+	require.NoError(t, json.Unmarshal([]byte(stdout), &r))
+	require.Positive(t, r.Machine.NumCPU)
+	require.Len(t, r.Results, 1)
+	require.Equal(t, "VerifyBlobKZGProofBatch/count=2", r.Results[0].Name)
+	require.Positive(t, r.Results[0].NsPerOp)
+	require.InDelta(t, 1e9/float64(r.Results[0].NsPerOp), r.Results[0].OpsPerSecond, 1e-6)
+
+	// CSV, to a file.
+	path := filepath.Join(t.TempDir(), "bench.csv")
+	requireRun(t, exitOK, "bench", "-counts", "", "-missing", "", "-goroutines", "", "-run", "^$", "-format", "csv", "-o", path)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(string(data), "name,operation,"))
+}
+
+func TestBenchErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{"bench", "-counts", "0"},
+		{"bench", "-counts", "x"},
+		{"bench", "-missing", "0.75"},
+		{"bench", "-goroutines", "-1"},
+		{"bench", "-run", "("},
+		{"bench", "-format", "xml"},
+	} {
+		requireRun(t, exitError, args...)
+	}
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+	"github.com/ethereum/c-kzg-4844/bindings/go/codec"
+)
+
+func init() {
+	commands["encode"] = command{
+		usage:   "-o DIR FILE",
+		summary: "Pack a file into blobs, with their commitments and proofs.",
+		run:     runEncode,
+	}
+	commands["decode"] = command{
+		usage:   "[-bundle FILE] [-o FILE] [BLOB...]",
+		summary: "Unpack the data of blobs packed by encode.",
+		run:     runDecode,
+	}
+}
+
+// bundleFile is the name of the bundle written by encode.
+const bundleFile = "bundle.json"
+
+// blobBundle describes the blobs written by encode. Blobs are file names,
+// relative to the directory of the bundle.
+type blobBundle struct {
+	Blobs           []string                 `json:"blobs"`
+	Commitments     []ckzg4844.KZGCommitment `json:"commitments"`
+	Proofs          []ckzg4844.KZGProof      `json:"proofs"`
+	VersionedHashes []ckzg4844.Bytes32       `json:"versioned_hashes"`
+}
+
+/*
+runEncode packs a file into blobs with the codec package, and writes them to
+a directory as blob-<i>.bin files, with a bundle.json holding their
+commitments, proofs and versioned hashes. It prints the versioned hashes.
+*/
+func runEncode(fs *flag.FlagSet, args []string, stdout io.Writer) error {
+	setup := setupFlag(fs)
+	out := fs.String("o", "", "output directory")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *out == "" {
+		return errors.New("missing -o")
+	}
+	if fs.NArg() != 1 {
+		return errors.New("expected a single file to encode")
+	}
+	data, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if err := loadSetup(*setup); err != nil {
+		return err
+	}
+	defer ckzg4844.FreeTrustedSetup()
+
+	blobs := codec.EncodeToBlobs(data)
+	var bundle blobBundle
+	for i := range blobs {
+		commitment, err := ckzg4844.BlobToKZGCommitment(&blobs[i])
+		if err != nil {
+			return err
+		}
+		proof, err := ckzg4844.ComputeBlobKZGProof(&blobs[i], ckzg4844.Bytes48(commitment))
+		if err != nil {
+			return err
+		}
+		bundle.Blobs = append(bundle.Blobs, fmt.Sprintf("blob-%v.bin", i))
+		bundle.Commitments = append(bundle.Commitments, commitment)
+		bundle.Proofs = append(bundle.Proofs, proof)
+		bundle.VersionedHashes = append(bundle.VersionedHashes, ckzg4844.KZGToVersionedHash(commitment))
+	}
+
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		return err
+	}
+	for i, name := range bundle.Blobs {
+		if err := os.WriteFile(filepath.Join(*out, name), blobs[i][:], 0o644); err != nil {
+			return err
+		}
+	}
+	encoded, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(*out, bundleFile), append(encoded, '\n'), 0o644); err != nil {
+		return err
+	}
+	for _, hash := range bundle.VersionedHashes {
+		if err := writeValues(stdout, false, hash[:]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+runDecode unpacks the data of blobs. With -bundle, the blobs default to
+those of the bundle, and they are verified against its commitments, proofs
+and versioned hashes first.
+*/
+func runDecode(fs *flag.FlagSet, args []string, stdout io.Writer) error {
+	setup := setupFlag(fs)
+	bundlePath := fs.String("bundle", "", "bundle written by encode, to verify the blobs against")
+	out := fs.String("o", "", "output file (default stdout)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	paths := fs.Args()
+	var bundle blobBundle
+	if *bundlePath != "" {
+		data, err := os.ReadFile(*bundlePath)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, &bundle); err != nil {
+			return fmt.Errorf("%v: %w", *bundlePath, err)
+		}
+		if len(paths) == 0 {
+			for _, name := range bundle.Blobs {
+				paths = append(paths, filepath.Join(filepath.Dir(*bundlePath), name))
+			}
+		}
+	}
+	if len(paths) == 0 {
+		return errors.New("no blobs to decode")
+	}
+	blobs := make([]ckzg4844.Blob, len(paths))
+	for i, path := range paths {
+		blob, err := readBlob(path)
+		if err != nil {
+			return err
+		}
+		blobs[i] = *blob
+	}
+
+	if *bundlePath != "" {
+		if err := loadSetup(*setup); err != nil {
+			return err
+		}
+		defer ckzg4844.FreeTrustedSetup()
+		commitments := make([]ckzg4844.Bytes48, len(bundle.Commitments))
+		for i, commitment := range bundle.Commitments {
+			commitments[i] = ckzg4844.Bytes48(commitment)
+		}
+		proofs := make([]ckzg4844.Bytes48, len(bundle.Proofs))
+		for i, proof := range bundle.Proofs {
+			proofs[i] = ckzg4844.Bytes48(proof)
+		}
+		if _, err := ckzg4844.VerifyBlobsBundle(blobs, commitments, proofs, bundle.VersionedHashes); err != nil {
+			if errors.Is(err, ckzg4844.ErrInvalidProof) || errors.Is(err, ckzg4844.ErrVersionedHashMismatch) {
+				return fmt.Errorf("%w: %v", errInvalid, err)
+			}
+			return err
+		}
+	}
+
+	data, err := codec.DecodeFromBlobs(blobs)
+	if err != nil {
+		return err
+	}
+	return writeOutput(*out, stdout, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
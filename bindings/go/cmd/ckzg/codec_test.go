@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/c-kzg-4844/bindings/go/codec"
+	"github.com/stretchr/testify/require"
+)
+
+// requireRunFiles is requireRun for commands taking files as arguments,
+// which must come after the flags.
+func requireRunFiles(t *testing.T, status int, args []string, files ...string) string {
+	t.Helper()
+	args = append(append(append([]string(nil), args...), "-setup", trustedSetupFile), files...)
+	gotStatus, stdout, stderr := runCLI(t, args...)
+	require.Equal(t, status, gotStatus, "stderr: %v", stderr)
+	return stdout
+}
+
+func TestEncodeDecode(t *testing.T) {
+	dir := t.TempDir()
+	data := make([]byte, codec.BytesPerBlobPayload+1000)
+	rand.New(rand.NewSource(0)).Read(data)
+	input := writeFile(t, dir, "input", data)
+	blobsDir := filepath.Join(dir, "blobs")
+
+	stdout := requireRunFiles(t, exitOK, []string{"encode", "-o", blobsDir}, input)
+	hashes := strings.Fields(stdout)
+	require.Len(t, hashes, 2)
+	bundlePath := filepath.Join(blobsDir, bundleFile)
+	var bundle blobBundle
+	bundleData, err := os.ReadFile(bundlePath)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(bundleData, &bundle))
+	require.Equal(t, []string{"blob-0.bin", "blob-1.bin"}, bundle.Blobs)
+	require.Equal(t, hashes[0], bundle.VersionedHashes[0].String())
+
+	// The blobs of the bundle, verified.
+	output := filepath.Join(dir, "output")
+	requireRunFiles(t, exitOK, []string{"decode", "-bundle", bundlePath, "-o", output})
+	decoded, err := os.ReadFile(output)
+	require.NoError(t, err)
+	require.Equal(t, data, decoded)
+
+	// The blob files, unverified.
+	stdout = requireRunFiles(t, exitOK, []string{"decode"}, filepath.Join(blobsDir, "blob-0.bin"), filepath.Join(blobsDir, "blob-1.bin"))
+	require.Equal(t, string(data), stdout)
+
+	// Blobs out of order, or that do not match the bundle.
+	requireRunFiles(t, exitError, []string{"decode"}, filepath.Join(blobsDir, "blob-1.bin"), filepath.Join(blobsDir, "blob-0.bin"))
+	bundle.Proofs[0], bundle.Proofs[1] = bundle.Proofs[1], bundle.Proofs[0]
+	bundleData, err = json.Marshal(bundle)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(bundlePath, bundleData, 0o644))
+	require.Equal(t, "invalid\n", requireRunFiles(t, exitInvalid, []string{"decode", "-bundle", bundlePath}))
+
+	requireRunFiles(t, exitError, []string{"decode"})
+	requireRunFiles(t, exitError, []string{"encode", "-o", blobsDir})
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+	"github.com/ethereum/c-kzg-4844/bindings/go/ckzgtest"
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	commands["gen-corpus"] = command{
+		usage:   "-tests DIR -o DIR [-format go|raw] [-mutations N] [-seed N]",
+		summary: "Generate fuzzing seed corpora from the reference tests.",
+		run:     runGenCorpus,
+	}
+}
+
+// fieldKind is the type of an input of an operation, which selects how it
+// is mutated.
+type fieldKind int
+
+const (
+	kindBlob fieldKind = iota
+	kindPoint
+	kindFieldElement
+)
+
+type corpusField struct {
+	name string
+	kind fieldKind
+	// list is set for the inputs that are lists of values.
+	list bool
+}
+
+// corpusOperation is an operation of the reference tests. target is the
+// name of its Go fuzz target, if it has one.
+type corpusOperation struct {
+	name   string
+	target string
+	fields []corpusField
+}
+
+var (
+	blobField       = corpusField{name: "blob", kind: kindBlob}
+	commitmentField = corpusField{name: "commitment", kind: kindPoint}
+	proofField      = corpusField{name: "proof", kind: kindPoint}
+)
+
+// corpusOperations lists the operations with their inputs, in the order the
+// fuzz targets take them.
+var corpusOperations = []corpusOperation{
+	{"blob_to_kzg_commitment", "FuzzBlobToKZGCommitment", []corpusField{blobField}},
+	{"compute_kzg_proof", "FuzzComputeKZGProof", []corpusField{blobField, {name: "z", kind: kindFieldElement}}},
+	{"compute_blob_kzg_proof", "", []corpusField{blobField, commitmentField}},
+	{"verify_kzg_proof", "FuzzVerifyKZGProof", []corpusField{commitmentField, {name: "z", kind: kindFieldElement}, {name: "y", kind: kindFieldElement}, proofField}},
+	{"verify_blob_kzg_proof", "FuzzVerifyBlobKZGProof", []corpusField{blobField, commitmentField, proofField}},
+	{"verify_blob_kzg_proof_batch", "FuzzVerifyBlobKZGProofBatch", []corpusField{
+		{name: "blobs", kind: kindBlob, list: true},
+		{name: "commitments", kind: kindPoint, list: true},
+		{name: "proofs", kind: kindPoint, list: true},
+	}},
+}
+
+/*
+runGenCorpus writes an input for every reference test, followed by mutations
+of it: non-canonical field elements, points that are invalid or at infinity,
+flipped bits, truncated and extended values, and shorter lists. In the go format the
+inputs are files of the Go fuzzing engine, in <dir>/<target>, to be merged
+into testdata/fuzz. In the raw format they are the concatenated bytes of the
+inputs, lists concatenated element by element, in <dir>/<operation> as read
+by the libFuzzer targets of the fuzz directory and most other fuzzers.
+*/
+func runGenCorpus(fs *flag.FlagSet, args []string, stdout io.Writer) error {
+	tests := fs.String("tests", "", "directory of the reference tests")
+	out := fs.String("o", "", "output directory")
+	format := fs.String("format", "go", "corpus format: go or raw")
+	mutations := fs.Int("mutations", 4, "number of mutated inputs per reference test")
+	seed := fs.Int64("seed", 0, "seed of the mutations")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs, "tests", "o"); err != nil {
+		return err
+	}
+	if *format != "go" && *format != "raw" {
+		return fmt.Errorf("unknown format %q, expected go or raw", *format)
+	}
+	if *mutations < 0 {
+		return errors.New("-mutations must not be negative")
+	}
+
+	r := rand.New(rand.NewSource(*seed))
+	total := 0
+	for _, op := range corpusOperations {
+		if *format == "go" && op.target == "" {
+			continue
+		}
+		paths, err := filepath.Glob(filepath.Join(*tests, op.name, "*", "*", "data.yaml"))
+		if err != nil {
+			return err
+		}
+		dir := filepath.Join(*out, op.name)
+		if *format == "go" {
+			dir = filepath.Join(*out, op.target)
+		}
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+		for _, path := range paths {
+			inputs, err := readCorpusInputs(path, op)
+			if err != nil {
+				return err
+			}
+			if err := writeCorpusEntry(dir, *format, inputs); err != nil {
+				return err
+			}
+			for i := 0; i < *mutations; i++ {
+				if err := writeCorpusEntry(dir, *format, mutateInputs(r, op, inputs)); err != nil {
+					return err
+				}
+			}
+			total += 1 + *mutations
+		}
+	}
+	if total == 0 {
+		return fmt.Errorf("no reference tests in %v", *tests)
+	}
+	fmt.Fprintf(stdout, "wrote %v inputs to %v\n", total, *out)
+	return nil
+}
+
+// readCorpusInputs reads the inputs of a reference test, one list of values
+// per field. Values that are not valid hex are used as is, which makes for
+// malformed inputs as well.
+func readCorpusInputs(path string, op corpusOperation) ([][][]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var test struct {
+		Input map[string]interface{} `yaml:"input"`
+	}
+	if err := yaml.Unmarshal(data, &test); err != nil {
+		return nil, fmt.Errorf("%v: %w", path, err)
+	}
+	inputs := make([][][]byte, len(op.fields))
+	for i, field := range op.fields {
+		var values []interface{}
+		if field.list {
+			values, _ = test.Input[field.name].([]interface{})
+		} else {
+			values = []interface{}{test.Input[field.name]}
+		}
+		for _, value := range values {
+			s, _ := value.(string)
+			b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+			if err != nil {
+				b = []byte(s)
+			}
+			inputs[i] = append(inputs[i], b)
+		}
+	}
+	return inputs, nil
+}
+
+// mutateInputs returns a copy of inputs with a value replaced by a mutation
+// suited to its kind, or, for lists, with the last value dropped.
+func mutateInputs(r *rand.Rand, op corpusOperation, inputs [][][]byte) [][][]byte {
+	mutated := make([][][]byte, len(inputs))
+	for i := range inputs {
+		mutated[i] = append([][]byte(nil), inputs[i]...)
+	}
+	i := r.Intn(len(op.fields))
+	if len(mutated[i]) == 0 {
+		return mutated
+	}
+	if op.fields[i].list && r.Intn(4) == 0 {
+		mutated[i] = mutated[i][:len(mutated[i])-1]
+		return mutated
+	}
+	j := r.Intn(len(mutated[i]))
+	value := append([]byte(nil), mutated[i][j]...)
+	switch choice := r.Intn(4); {
+	case choice == 0 && len(value) > 0:
+		value = value[:len(value)-1]
+	case choice == 1 && len(value) > 0:
+		value[r.Intn(len(value))] ^= 1 << r.Intn(8)
+	case op.fields[i].kind == kindBlob && len(value) == ckzg4844.BytesPerBlob:
+		var blob ckzg4844.Blob
+		copy(blob[:], value)
+		value = ckzgtest.CorruptFieldElement(&blob, r.Intn(ckzg4844.FieldElementsPerBlob))[:]
+	case op.fields[i].kind == kindPoint:
+		point := ckzgtest.InvalidPoint()
+		if r.Intn(2) == 0 {
+			point = ckzg4844.Bytes48{0xc0}
+		}
+		value = point[:]
+	case op.fields[i].kind == kindFieldElement:
+		fieldElement := ckzgtest.NonCanonicalFieldElement()
+		value = fieldElement[:]
+	default:
+		value = append(value, 0)
+	}
+	mutated[i][j] = value
+	return mutated
+}
+
+// writeCorpusEntry writes inputs to dir, in a file named after its contents.
+func writeCorpusEntry(dir, format string, inputs [][][]byte) error {
+	var data []byte
+	if format == "go" {
+		data = []byte("go test fuzz v1\n")
+		for _, values := range inputs {
+			data = append(data, "[]byte("+strconv.Quote(string(concatValues(values)))+")\n"...)
+		}
+	} else {
+		for _, values := range inputs {
+			data = append(data, concatValues(values)...)
+		}
+	}
+	digest := sha256.Sum256(data)
+	return os.WriteFile(filepath.Join(dir, hex.EncodeToString(digest[:8])), data, 0o644)
+}
+
+func concatValues(values [][]byte) []byte {
+	var out []byte
+	for _, value := range values {
+		out = append(out, value...)
+	}
+	return out
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testsDir = "../../../../tests"
+
+// requireGenCorpus runs gen-corpus, which takes no trusted setup, and
+// requires it to exit with status.
+func requireGenCorpus(t *testing.T, status int, args ...string) string {
+	t.Helper()
+	gotStatus, stdout, stderr := runCLI(t, append([]string{"gen-corpus"}, args...)...)
+	require.Equal(t, status, gotStatus, "stderr: %v", stderr)
+	return stdout
+}
+
+func TestGenCorpus(t *testing.T) {
+	dir := t.TempDir()
+	stdout := requireGenCorpus(t, exitOK, "-tests", testsDir, "-o", dir, "-mutations", "1")
+	require.True(t, strings.HasPrefix(stdout, "wrote "))
+
+	// Every entry is a Go fuzzing input with one argument per field.
+	for _, op := range corpusOperations {
+		if op.target == "" {
+			require.NoDirExists(t, filepath.Join(dir, op.name))
+			continue
+		}
+		entries, err := os.ReadDir(filepath.Join(dir, op.target))
+		require.NoError(t, err)
+		require.NotEmpty(t, entries)
+		for _, entry := range entries {
+			data, err := os.ReadFile(filepath.Join(dir, op.target, entry.Name()))
+			require.NoError(t, err)
+			lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+			require.Equal(t, "go test fuzz v1", lines[0])
+			require.Len(t, lines[1:], len(op.fields))
+			for _, line := range lines[1:] {
+				require.True(t, strings.HasPrefix(line, "[]byte(") && strings.HasSuffix(line, ")"), line)
+				_, err := strconv.Unquote(strings.TrimSuffix(strings.TrimPrefix(line, "[]byte("), ")"))
+				require.NoError(t, err)
+			}
+		}
+	}
+
+	// Raw entries of valid inputs are the concatenated values.
+	dir = t.TempDir()
+	requireGenCorpus(t, exitOK, "-tests", testsDir, "-o", dir, "-format", "raw", "-mutations", "0")
+	entries, err := os.ReadDir(filepath.Join(dir, "verify_kzg_proof"))
+	require.NoError(t, err)
+	sizes := map[int]bool{}
+	for _, entry := range entries {
+		info, err := entry.Info()
+		require.NoError(t, err)
+		sizes[int(info.Size())] = true
+	}
+	require.True(t, sizes[48+32+32+48])
+	require.DirExists(t, filepath.Join(dir, "compute_blob_kzg_proof"))
+
+	requireGenCorpus(t, exitError, "-tests", t.TempDir(), "-o", dir)
+	requireGenCorpus(t, exitError, "-tests", testsDir, "-o", dir, "-format", "afl")
+}
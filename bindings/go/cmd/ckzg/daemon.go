@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"sync"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+	"github.com/ethereum/c-kzg-4844/bindings/go/verifyqueue"
+)
+
+func init() {
+	commands["verify-daemon"] = command{
+		usage:   "[-i FILE] [-workers N] [-batch N] [-queue N]",
+		summary: "Verify blob proofs from a stream of JSON jobs, critical ones first.",
+		run:     runVerifyDaemon,
+	}
+}
+
+// maxJobSize bounds the length of a job line, which is dominated by the hex
+// blob.
+const maxJobSize = 2*ckzg4844.BytesPerBlob + 4096
+
+// daemonJob is a line of the input of verify-daemon. Priority is "critical"
+// or "bulk", the default.
+type daemonJob struct {
+	ID         json.RawMessage  `json:"id"`
+	Priority   string           `json:"priority"`
+	Blob       *ckzg4844.Blob   `json:"blob"`
+	Commitment ckzg4844.Bytes48 `json:"commitment"`
+	Proof      ckzg4844.Bytes48 `json:"proof"`
+}
+
+// daemonResult is a line of the output of verify-daemon.
+type daemonResult struct {
+	ID    json.RawMessage `json:"id"`
+	Valid bool            `json:"valid"`
+	Error string          `json:"error,omitempty"`
+}
+
+var priorities = map[string]verifyqueue.Priority{
+	"":         verifyqueue.Bulk,
+	"bulk":     verifyqueue.Bulk,
+	"critical": verifyqueue.Critical,
+}
+
+/*
+runVerifyDaemon reads jobs, one JSON object per line, until the end of the
+input, and writes the result of each as a JSON line in the order they
+complete, with the id of its job. Malformed jobs, and jobs arriving while
+the queue of their priority is full, get an error result.
+*/
+func runVerifyDaemon(fs *flag.FlagSet, args []string, stdout io.Writer) error {
+	setup := setupFlag(fs)
+	in := fs.String("i", "", "input file (default stdin)")
+	workers := fs.Int("workers", runtime.NumCPU(), "number of verifications run at once")
+	batch := fs.Int("batch", 16, "largest number of queued jobs verified as a batch")
+	queued := fs.Int("queue", 1024, "largest number of jobs waiting in each queue")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs); err != nil {
+		return err
+	}
+	if *workers < 1 || *batch < 1 || *queued < 1 {
+		return fmt.Errorf("-workers, -batch and -queue must be positive")
+	}
+	input := io.Reader(os.Stdin)
+	if *in != "" {
+		f, err := os.Open(*in)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		input = f
+	}
+	if err := loadSetup(*setup); err != nil {
+		return err
+	}
+	defer ckzg4844.FreeTrustedSetup()
+
+	queue := verifyqueue.New(ckzg4844.DefaultBackend, verifyqueue.Config{Workers: *workers, MaxBatch: *batch, MaxQueued: *queued})
+	var mu sync.Mutex
+	encoder := json.NewEncoder(stdout)
+	var writeErr error
+	write := func(result daemonResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		if writeErr == nil {
+			writeErr = encoder.Encode(result)
+		}
+	}
+
+	var wg sync.WaitGroup
+	scanner := bufio.NewScanner(input)
+	scanner.Buffer(make([]byte, 64*1024), maxJobSize)
+	for scanner.Scan() {
+		var job daemonJob
+		if err := json.Unmarshal(scanner.Bytes(), &job); err != nil {
+			write(daemonResult{ID: job.ID, Error: "malformed job: " + err.Error()})
+			continue
+		}
+		priority, ok := priorities[job.Priority]
+		if !ok || job.Blob == nil {
+			write(daemonResult{ID: job.ID, Error: "malformed job: missing blob or unknown priority"})
+			continue
+		}
+		result, err := queue.Submit(context.Background(), priority, verifyqueue.Job{Blob: job.Blob, Commitment: job.Commitment, Proof: job.Proof})
+		if err != nil {
+			write(daemonResult{ID: job.ID, Error: err.Error()})
+			continue
+		}
+		wg.Add(1)
+		go func(id json.RawMessage) {
+			defer wg.Done()
+			r := <-result
+			out := daemonResult{ID: id, Valid: r.Valid}
+			if r.Err != nil {
+				out.Error = r.Err.Error()
+			}
+			write(out)
+		}(job.ID)
+	}
+	queue.Close()
+	wg.Wait()
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return writeErr
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+	"github.com/ethereum/c-kzg-4844/bindings/go/ckzgtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyDaemon(t *testing.T) {
+	require.NoError(t, loadSetup(trustedSetupFile))
+	blobs, commitments, proofs := ckzgtest.RandomBundle(1, 2)
+	ckzg4844.FreeTrustedSetup()
+	job := func(id int, priority string, i, j int) string {
+		blob, err := blobs[i].MarshalText()
+		require.NoError(t, err)
+		return fmt.Sprintf(`{"id":%v,"priority":%q,"blob":%q,"commitment":%q,"proof":%q}`,
+			id, priority, blob, commitments[i], proofs[j])
+	}
+	input := strings.Join([]string{
+		job(1, "critical", 0, 0),
+		job(2, "", 1, 1),
+		job(3, "bulk", 1, 0),
+		job(4, "urgent", 0, 0),
+		`{"id":5,"blob":"0x00"}`,
+		`{`,
+	}, "\n")
+	path := writeFile(t, t.TempDir(), "jobs", []byte(input))
+
+	stdout := requireRun(t, exitOK, "verify-daemon", "-i", path, "-workers", "2", "-batch", "2")
+	results := map[string]daemonResult{}
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	require.Len(t, lines, 6)
+	for _, line := range lines {
+		var result daemonResult
+		require.NoError(t, json.Unmarshal([]byte(line), &result))
+		results[string(result.ID)] = result
+	}
+	require.Equal(t, daemonResult{ID: []byte("1"), Valid: true}, results["1"])
+	require.Equal(t, daemonResult{ID: []byte("2"), Valid: true}, results["2"])
+	require.Equal(t, daemonResult{ID: []byte("3")}, results["3"])
+	require.Contains(t, results["4"].Error, "unknown priority")
+	require.Contains(t, results["5"].Error, "malformed job")
+	require.Contains(t, results["null"].Error, "malformed job")
+
+	requireRun(t, exitError, "verify-daemon", "-workers", "0")
+}
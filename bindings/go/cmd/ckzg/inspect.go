@@ -0,0 +1,232 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+	"github.com/ethereum/c-kzg-4844/bindings/go/codec"
+)
+
+func init() {
+	commands["inspect"] = command{
+		usage:   "VALUE...",
+		summary: "Describe blobs, points, field elements and bundles, and check them.",
+		run:     runInspect,
+	}
+}
+
+// maxListedIndices is the number of non-canonical field elements of a blob
+// that inspect lists.
+const maxListedIndices = 8
+
+// infoLine is a line of the output of inspect.
+type infoLine struct {
+	name  string
+	value interface{}
+}
+
+/*
+runInspect describes each value, given in 0x-prefixed hex or as a file
+holding it in binary or in hex. Values are told apart by their size: field
+elements are 32 bytes, points (commitments and proofs) 48 bytes and blobs
+one blob. Files holding JSON are read as the bundles written by encode. If a
+trusted setup is given, the commitments of blobs are computed and the proofs
+of bundles verified.
+*/
+func runInspect(fs *flag.FlagSet, args []string, stdout io.Writer) error {
+	setup := setupFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("nothing to inspect")
+	}
+	withSetup := *setup != ""
+	if withSetup {
+		if err := loadSetup(*setup); err != nil {
+			return err
+		}
+		defer ckzg4844.FreeTrustedSetup()
+	}
+
+	for i, arg := range fs.Args() {
+		lines, err := inspectArg(arg, withSetup)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			fmt.Fprintln(stdout)
+		}
+		fmt.Fprintf(stdout, "%v\n", argName(arg))
+		for _, line := range lines {
+			if _, err := fmt.Fprintf(stdout, "  %-30v %v\n", line.name+":", line.value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// argName abbreviates the values given in hex, which can be as long as a
+// blob, to head the lines describing them.
+func argName(arg string) string {
+	if strings.HasPrefix(arg, "0x") && len(arg) > 2+2*ckzg4844.BytesPerCommitment {
+		return arg[:2+2*ckzg4844.BytesPerFieldElement] + "..."
+	}
+	return arg
+}
+
+// inspectArg reads and describes a value.
+func inspectArg(arg string, withSetup bool) ([]infoLine, error) {
+	var data []byte
+	if strings.HasPrefix(arg, "0x") {
+		data = []byte(arg)
+	} else {
+		var err error
+		if data, err = os.ReadFile(arg); err != nil {
+			return nil, err
+		}
+		if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+			return inspectBundle(arg, trimmed, withSetup)
+		}
+	}
+	switch len(data) {
+	case ckzg4844.BytesPerFieldElement, ckzg4844.BytesPerCommitment, ckzg4844.BytesPerBlob:
+	default:
+		decoded, err := hex.DecodeString(strings.TrimPrefix(string(bytes.TrimSpace(data)), "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("%v: neither a binary value nor hex", arg)
+		}
+		data = decoded
+	}
+
+	switch len(data) {
+	case ckzg4844.BytesPerFieldElement:
+		var fieldElement ckzg4844.Bytes32
+		copy(fieldElement[:], data)
+		return inspectFieldElement(fieldElement), nil
+	case ckzg4844.BytesPerCommitment:
+		var point ckzg4844.Bytes48
+		copy(point[:], data)
+		return inspectPoint(point), nil
+	case ckzg4844.BytesPerBlob:
+		return inspectBlob((*ckzg4844.Blob)(data), withSetup)
+	}
+	return nil, fmt.Errorf("%v: %v bytes is not the size of a field element, point or blob", arg, len(data))
+}
+
+func inspectFieldElement(fieldElement ckzg4844.Bytes32) []infoLine {
+	return []infoLine{
+		{"type", "field element"},
+		{"value", fieldElement},
+		{"canonical", ckzg4844.ValidateFieldElement(fieldElement) == nil},
+	}
+}
+
+func inspectPoint(point ckzg4844.Bytes48) []infoLine {
+	valid := ckzg4844.ValidateG1(point) == nil
+	lines := []infoLine{
+		{"type", "G1 point (commitment or proof)"},
+		{"value", point},
+		{"valid", valid},
+	}
+	if valid {
+		lines = append(lines,
+			infoLine{"infinity", ckzg4844.KZGCommitment(point).IsInfinity()},
+			infoLine{"versioned hash", ckzg4844.KZGToVersionedHash(ckzg4844.KZGCommitment(point))},
+		)
+	}
+	return lines
+}
+
+func inspectBlob(blob *ckzg4844.Blob, withSetup bool) ([]infoLine, error) {
+	var nonCanonical []int
+	zeros := 0
+	for i := 0; i < ckzg4844.FieldElementsPerBlob; i++ {
+		fieldElement := blob.FieldElement(i)
+		if ckzg4844.ValidateFieldElement(fieldElement) != nil {
+			nonCanonical = append(nonCanonical, i)
+		}
+		if fieldElement == (ckzg4844.Bytes32{}) {
+			zeros++
+		}
+	}
+	listed := fmt.Sprint(len(nonCanonical))
+	if len(nonCanonical) > 0 {
+		shown := nonCanonical
+		if len(shown) > maxListedIndices {
+			shown = shown[:maxListedIndices]
+		}
+		listed += fmt.Sprintf(", at %v", strings.Trim(fmt.Sprint(shown), "[]"))
+		if len(nonCanonical) > len(shown) {
+			listed += ", ..."
+		}
+	}
+	payload := "none"
+	if data, err := codec.DecodeFromBlobs([]ckzg4844.Blob{*blob}); err == nil {
+		payload = fmt.Sprintf("%v bytes", len(data))
+	}
+	lines := []infoLine{
+		{"type", "blob"},
+		{"canonical", len(nonCanonical) == 0},
+		{"non-canonical field elements", listed},
+		{"zero field elements", fmt.Sprintf("%v of %v", zeros, ckzg4844.FieldElementsPerBlob)},
+		{"codec payload", payload},
+	}
+	if withSetup && len(nonCanonical) == 0 {
+		commitment, err := ckzg4844.BlobToKZGCommitment(blob)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines,
+			infoLine{"commitment", commitment},
+			infoLine{"versioned hash", ckzg4844.KZGToVersionedHash(commitment)},
+		)
+	}
+	return lines, nil
+}
+
+// inspectBundle checks every entry of a bundle, and verifies its proof if
+// there is a trusted setup and the blob file can be read.
+func inspectBundle(path string, data []byte, withSetup bool) ([]infoLine, error) {
+	var bundle blobBundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("%v: %w", path, err)
+	}
+	lines := []infoLine{
+		{"type", "bundle"},
+		{"blobs", len(bundle.Blobs)},
+	}
+	if len(bundle.Commitments) != len(bundle.Blobs) || len(bundle.Proofs) != len(bundle.Blobs) || len(bundle.VersionedHashes) != len(bundle.Blobs) {
+		lines = append(lines, infoLine{"consistent", fmt.Sprintf("false, %v commitments, %v proofs and %v versioned hashes",
+			len(bundle.Commitments), len(bundle.Proofs), len(bundle.VersionedHashes))})
+		return lines, nil
+	}
+	for i, name := range bundle.Blobs {
+		commitment := bundle.Commitments[i]
+		proof := bundle.Proofs[i]
+		checks := []string{
+			fmt.Sprintf("commitment valid=%v", ckzg4844.ValidateG1(ckzg4844.Bytes48(commitment)) == nil),
+			fmt.Sprintf("proof valid=%v", ckzg4844.ValidateG1(ckzg4844.Bytes48(proof)) == nil),
+			fmt.Sprintf("versioned hash matches=%v", ckzg4844.KZGToVersionedHash(commitment) == bundle.VersionedHashes[i]),
+		}
+		if withSetup {
+			verifies := "unreadable blob"
+			if blob, err := readBlob(filepath.Join(filepath.Dir(path), name)); err == nil {
+				ok, err := ckzg4844.VerifyBlobKZGProof(blob, ckzg4844.Bytes48(commitment), ckzg4844.Bytes48(proof))
+				verifies = fmt.Sprint(err == nil && ok)
+			}
+			checks = append(checks, "proof verifies="+verifies)
+		}
+		lines = append(lines, infoLine{fmt.Sprintf("blob %v", i), name + ": " + strings.Join(checks, ", ")})
+	}
+	return lines, nil
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+	"github.com/ethereum/c-kzg-4844/bindings/go/ckzgtest"
+	"github.com/stretchr/testify/require"
+)
+
+// requireInspect runs inspect on a value and returns its description, with
+// the indentation removed so infoField can read it.
+func requireInspect(t *testing.T, value string) string {
+	t.Helper()
+	stdout := requireRunFiles(t, exitOK, []string{"inspect"}, value)
+	return strings.ReplaceAll(stdout, "\n  ", "\n")
+}
+
+func TestInspect(t *testing.T) {
+	dir := t.TempDir()
+	blobsDir := filepath.Join(dir, "blobs")
+	hash := strings.TrimSpace(requireRunFiles(t, exitOK, []string{"encode", "-o", blobsDir}, writeFile(t, dir, "input", []byte("hello"))))
+
+	info := requireInspect(t, filepath.Join(blobsDir, bundleFile))
+	require.Equal(t, "bundle", infoField(t, info, "type"))
+	require.Equal(t, "blob-0.bin: commitment valid=true, proof valid=true, versioned hash matches=true, proof verifies=true",
+		infoField(t, info, "blob 0"))
+
+	info = requireInspect(t, filepath.Join(blobsDir, "blob-0.bin"))
+	require.Equal(t, "true", infoField(t, info, "canonical"))
+	require.Equal(t, "5 bytes", infoField(t, info, "codec payload"))
+	require.Equal(t, hash, infoField(t, info, "versioned hash"))
+
+	blob := ckzgtest.CorruptFieldElement(ckzgtest.RandomBlob(0), 3)
+	text, err := blob.MarshalText()
+	require.NoError(t, err)
+	info = requireInspect(t, writeFile(t, dir, "invalid", text))
+	require.Equal(t, "false", infoField(t, info, "canonical"))
+	require.Equal(t, "1, at 3", infoField(t, info, "non-canonical field elements"))
+	require.Equal(t, "none", infoField(t, info, "codec payload"))
+
+	info = requireInspect(t, ckzgtest.NonCanonicalFieldElement().String())
+	require.Equal(t, "field element", infoField(t, info, "type"))
+	require.Equal(t, "false", infoField(t, info, "canonical"))
+
+	info = requireInspect(t, ckzg4844.Bytes48{0xc0}.String())
+	require.Equal(t, "true", infoField(t, info, "valid"))
+	require.Equal(t, "true", infoField(t, info, "infinity"))
+	info = requireInspect(t, ckzgtest.InvalidPoint().String())
+	require.Equal(t, "false", infoField(t, info, "valid"))
+
+	requireRunFiles(t, exitError, []string{"inspect"})
+	requireRunFiles(t, exitError, []string{"inspect"}, "0x00")
+	requireRunFiles(t, exitError, []string{"inspect"}, filepath.Join(dir, "missing"))
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+)
+
+func init() {
+	commands["commit"] = command{
+		usage:   "-blob FILE",
+		summary: "Compute the commitment to a blob.",
+		run:     runCommit,
+	}
+	commands["prove"] = command{
+		usage:   "-blob FILE -z VALUE",
+		summary: "Compute the proof of the evaluation of a blob at z, and the evaluation y.",
+		run:     runProve,
+	}
+	commands["prove-blob"] = command{
+		usage:   "-blob FILE -commitment VALUE",
+		summary: "Compute the blob proof of a blob and its commitment.",
+		run:     runProveBlob,
+	}
+	commands["verify"] = command{
+		usage:   "-commitment VALUE -z VALUE -y VALUE -proof VALUE",
+		summary: "Verify the proof that the committed polynomial evaluates to y at z.",
+		run:     runVerify,
+	}
+	commands["verify-blob"] = command{
+		usage:   "-blob FILE -commitment VALUE -proof VALUE",
+		summary: "Verify the blob proof of a blob and its commitment.",
+		run:     runVerifyBlob,
+	}
+}
+
+func runCommit(fs *flag.FlagSet, args []string, stdout io.Writer) error {
+	setup := setupFlag(fs)
+	blobPath := fs.String("blob", "", "blob file")
+	binary := fs.Bool("binary", false, "write the commitment in binary")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs, "blob"); err != nil {
+		return err
+	}
+	blob, err := readBlob(*blobPath)
+	if err != nil {
+		return err
+	}
+	if err := loadSetup(*setup); err != nil {
+		return err
+	}
+	defer ckzg4844.FreeTrustedSetup()
+
+	commitment, err := ckzg4844.BlobToKZGCommitment(blob)
+	if err != nil {
+		return err
+	}
+	return writeValues(stdout, *binary, commitment[:])
+}
+
+func runProve(fs *flag.FlagSet, args []string, stdout io.Writer) error {
+	setup := setupFlag(fs)
+	blobPath := fs.String("blob", "", "blob file")
+	zArg := fs.String("z", "", "evaluation point, as hex or a file")
+	binary := fs.Bool("binary", false, "write the proof and y in binary")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs, "blob", "z"); err != nil {
+		return err
+	}
+	blob, err := readBlob(*blobPath)
+	if err != nil {
+		return err
+	}
+	var z ckzg4844.Bytes32
+	if err := readValue("z", *zArg, ckzg4844.BytesPerFieldElement, &z); err != nil {
+		return err
+	}
+	if err := loadSetup(*setup); err != nil {
+		return err
+	}
+	defer ckzg4844.FreeTrustedSetup()
+
+	proof, y, err := ckzg4844.ComputeKZGProof(blob, z)
+	if err != nil {
+		return err
+	}
+	return writeValues(stdout, *binary, proof[:], y[:])
+}
+
+func runProveBlob(fs *flag.FlagSet, args []string, stdout io.Writer) error {
+	setup := setupFlag(fs)
+	blobPath := fs.String("blob", "", "blob file")
+	commitmentArg := fs.String("commitment", "", "commitment to the blob, as hex or a file")
+	binary := fs.Bool("binary", false, "write the proof in binary")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs, "blob", "commitment"); err != nil {
+		return err
+	}
+	blob, err := readBlob(*blobPath)
+	if err != nil {
+		return err
+	}
+	var commitment ckzg4844.Bytes48
+	if err := readValue("commitment", *commitmentArg, ckzg4844.BytesPerCommitment, &commitment); err != nil {
+		return err
+	}
+	if err := loadSetup(*setup); err != nil {
+		return err
+	}
+	defer ckzg4844.FreeTrustedSetup()
+
+	proof, err := ckzg4844.ComputeBlobKZGProof(blob, commitment)
+	if err != nil {
+		return err
+	}
+	return writeValues(stdout, *binary, proof[:])
+}
+
+func runVerify(fs *flag.FlagSet, args []string, stdout io.Writer) error {
+	setup := setupFlag(fs)
+	commitmentArg := fs.String("commitment", "", "commitment, as hex or a file")
+	zArg := fs.String("z", "", "evaluation point, as hex or a file")
+	yArg := fs.String("y", "", "claimed evaluation, as hex or a file")
+	proofArg := fs.String("proof", "", "proof, as hex or a file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs, "commitment", "z", "y", "proof"); err != nil {
+		return err
+	}
+	var commitment, proof ckzg4844.Bytes48
+	var z, y ckzg4844.Bytes32
+	if err := readValue("commitment", *commitmentArg, ckzg4844.BytesPerCommitment, &commitment); err != nil {
+		return err
+	}
+	if err := readValue("z", *zArg, ckzg4844.BytesPerFieldElement, &z); err != nil {
+		return err
+	}
+	if err := readValue("y", *yArg, ckzg4844.BytesPerFieldElement, &y); err != nil {
+		return err
+	}
+	if err := readValue("proof", *proofArg, ckzg4844.BytesPerProof, &proof); err != nil {
+		return err
+	}
+	if err := loadSetup(*setup); err != nil {
+		return err
+	}
+	defer ckzg4844.FreeTrustedSetup()
+
+	ok, err := ckzg4844.VerifyKZGProof(commitment, z, y, proof)
+	return verifyResult(stdout, ok, err)
+}
+
+func runVerifyBlob(fs *flag.FlagSet, args []string, stdout io.Writer) error {
+	setup := setupFlag(fs)
+	blobPath := fs.String("blob", "", "blob file")
+	commitmentArg := fs.String("commitment", "", "commitment to the blob, as hex or a file")
+	proofArg := fs.String("proof", "", "blob proof, as hex or a file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs, "blob", "commitment", "proof"); err != nil {
+		return err
+	}
+	blob, err := readBlob(*blobPath)
+	if err != nil {
+		return err
+	}
+	var commitment, proof ckzg4844.Bytes48
+	if err := readValue("commitment", *commitmentArg, ckzg4844.BytesPerCommitment, &commitment); err != nil {
+		return err
+	}
+	if err := readValue("proof", *proofArg, ckzg4844.BytesPerProof, &proof); err != nil {
+		return err
+	}
+	if err := loadSetup(*setup); err != nil {
+		return err
+	}
+	defer ckzg4844.FreeTrustedSetup()
+
+	ok, err := ckzg4844.VerifyBlobKZGProof(blob, commitment, proof)
+	return verifyResult(stdout, ok, err)
+}
+
+// verifyResult prints valid if the proof verified, and returns errInvalid or
+// the error otherwise.
+func verifyResult(stdout io.Writer, ok bool, err error) error {
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errInvalid
+	}
+	_, err = fmt.Fprintln(stdout, "valid")
+	return err
+}
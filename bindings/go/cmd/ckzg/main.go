@@ -0,0 +1,218 @@
+// Command ckzg runs the KZG operations of EIP-4844 on blobs, commitments and
+// proofs held in files, for use by operators and in scripts.
+//
+// Usage:
+//
+//	ckzg <command> [flags]
+//
+// Run ckzg help for the list of commands, and ckzg <command> -h for the
+// flags of one. Blobs, commitments, proofs and field elements are read from
+// files holding them in binary or in hex, with or without a 0x prefix.
+// Values other than blobs can also be given inline as 0x-prefixed hex.
+// Results are written as 0x-prefixed hex, one per line, or in binary with
+// -binary. Trusted setups are read in the txt, json or bin format, by file
+// extension; see ckzg setup.
+//
+// The exit status is 0 on success, 1 if a proof does not verify, and 2 for
+// any other error.
+package main
+
+import (
+	"bytes"
+	"encoding"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+)
+
+const (
+	exitOK      = 0
+	exitInvalid = 1
+	exitError   = 2
+)
+
+// errInvalid is returned by commands whose proof does not verify.
+var errInvalid = errors.New("invalid proof")
+
+// command is a subcommand of ckzg.
+type command struct {
+	// usage is the synopsis of the arguments, after the command name.
+	usage string
+	// summary is a one-line description, shown by ckzg help.
+	summary string
+	// run parses args with fs, to which it adds its own flags, and runs the
+	// command.
+	run func(fs *flag.FlagSet, args []string, stdout io.Writer) error
+}
+
+// commands maps command names to commands. The commands register themselves
+// from the files implementing them.
+var commands = map[string]command{}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs the command in args and returns the exit status.
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "-help" {
+		printUsage(stderr)
+		if len(args) == 0 {
+			return exitError
+		}
+		return exitOK
+	}
+	name := args[0]
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(stderr, "ckzg: unknown command %q\n", name)
+		printUsage(stderr)
+		return exitError
+	}
+	fs := flag.NewFlagSet("ckzg "+name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "usage: ckzg %v %v\n\n%v\n\n", name, cmd.usage, cmd.summary)
+		fs.PrintDefaults()
+	}
+	err := cmd.run(fs, args[1:], stdout)
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, flag.ErrHelp):
+		return exitOK
+	case errors.Is(err, errInvalid):
+		fmt.Fprintln(stdout, "invalid")
+		return exitInvalid
+	}
+	fmt.Fprintf(stderr, "ckzg %v: %v\n", name, err)
+	return exitError
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprint(w, "usage: ckzg <command> [flags]\n\ncommands:\n")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-12v %v\n", name, commands[name].summary)
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Helper Functions
+///////////////////////////////////////////////////////////////////////////////
+
+// setupFlag adds the -setup flag, the trusted setup file to load, to fs.
+func setupFlag(fs *flag.FlagSet) *string {
+	return fs.String("setup", os.Getenv("CKZG_TRUSTED_SETUP"),
+		"trusted setup file, in the txt, json or bin format by extension (default $CKZG_TRUSTED_SETUP)")
+}
+
+// loadSetup loads the trusted setup file, in the format given by its
+// extension. The caller must call ckzg4844.FreeTrustedSetup when done.
+func loadSetup(path string) error {
+	if path == "" {
+		return errors.New("no trusted setup: set -setup or CKZG_TRUSTED_SETUP")
+	}
+	var err error
+	if format := setupFormat(path); format == "txt" {
+		// LoadTrustedSetupFile panics if it cannot open the file.
+		if _, err = os.Stat(path); err != nil {
+			return err
+		}
+		err = ckzg4844.LoadTrustedSetupFile(path)
+	} else {
+		var data []byte
+		if data, err = os.ReadFile(path); err != nil {
+			return err
+		}
+		err = loadSetupData(data, format)
+	}
+	if err != nil {
+		return fmt.Errorf("loading trusted setup %v: %w", path, err)
+	}
+	return nil
+}
+
+// requireFlags returns an error naming the first of the flags that is empty.
+func requireFlags(fs *flag.FlagSet, names ...string) error {
+	for _, name := range names {
+		if fs.Lookup(name).Value.String() == "" {
+			return fmt.Errorf("missing -%v", name)
+		}
+	}
+	if fs.NArg() != 0 {
+		return fmt.Errorf("unexpected arguments: %v", strings.Join(fs.Args(), " "))
+	}
+	return nil
+}
+
+// value is implemented by the pointer types of the values read by commands.
+type value interface {
+	encoding.TextUnmarshaler
+	encoding.BinaryUnmarshaler
+}
+
+/*
+readValue reads a value of size bytes into v from arg, which is either the
+value in 0x-prefixed hex, or a file holding it in binary or in hex. Files of
+exactly size bytes are binary; others are hex, with surrounding whitespace
+ignored.
+*/
+func readValue(name, arg string, size int, v value) error {
+	if strings.HasPrefix(arg, "0x") {
+		if err := v.UnmarshalText([]byte(arg)); err != nil {
+			return fmt.Errorf("-%v is not %v bytes of hex", name, size)
+		}
+		return nil
+	}
+	data, err := os.ReadFile(arg)
+	if err != nil {
+		return err
+	}
+	if len(data) == size {
+		return v.UnmarshalBinary(data)
+	}
+	if err := v.UnmarshalText(bytes.TrimSpace(data)); err != nil {
+		return fmt.Errorf("%v is not %v bytes of binary or hex", arg, size)
+	}
+	return nil
+}
+
+// readBlob reads a blob from a file, as for readValue.
+func readBlob(path string) (*ckzg4844.Blob, error) {
+	if strings.HasPrefix(path, "0x") {
+		return nil, errors.New("-blob must be a file")
+	}
+	blob := new(ckzg4844.Blob)
+	if err := readValue("blob", path, ckzg4844.BytesPerBlob, blob); err != nil {
+		return nil, err
+	}
+	return blob, nil
+}
+
+// writeValues writes the values as 0x-prefixed hex, one per line, or, in
+// binary, one after the other.
+func writeValues(w io.Writer, binary bool, values ...[]byte) error {
+	for _, v := range values {
+		var err error
+		if binary {
+			_, err = w.Write(v)
+		} else {
+			_, err = fmt.Fprintf(w, "0x%x\n", v)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
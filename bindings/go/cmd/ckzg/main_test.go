@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/c-kzg-4844/bindings/go/ckzgtest"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const trustedSetupFile = "../../../../src/trusted_setup.txt"
+
+// runCLI runs ckzg with args and returns its exit status and output.
+func runCLI(t *testing.T, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	status := run(args, &stdout, &stderr)
+	return status, stdout.String(), stderr.String()
+}
+
+// requireRun runs ckzg with args and the trusted setup, requires it to exit
+// with status, and returns its standard output.
+func requireRun(t *testing.T, status int, args ...string) string {
+	t.Helper()
+	return requireRunWithSetup(t, status, trustedSetupFile, args...)
+}
+
+// requireRunWithSetup is requireRun with another trusted setup file.
+func requireRunWithSetup(t *testing.T, status int, setup string, args ...string) string {
+	t.Helper()
+	args = append(append([]string(nil), args...), "-setup", setup)
+	gotStatus, stdout, stderr := runCLI(t, args...)
+	require.Equal(t, status, gotStatus, "stderr: %v", stderr)
+	return stdout
+}
+
+// writeFile writes data to a new file in dir and returns its path.
+func writeFile(t *testing.T, dir, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+	return path
+}
+
+func TestCommit(t *testing.T) {
+	data, err := os.ReadFile("../../../../tests/blob_to_kzg_commitment/kzg-mainnet/blob_to_kzg_commitment_case_valid_blob_19b3f3f8c98ea31e/data.yaml")
+	require.NoError(t, err)
+	var test struct {
+		Input struct {
+			Blob string `yaml:"blob"`
+		}
+		Output string `yaml:"output"`
+	}
+	require.NoError(t, yaml.Unmarshal(data, &test))
+	dir := t.TempDir()
+
+	hexBlob := writeFile(t, dir, "blob.hex", []byte(test.Input.Blob+"\n"))
+	require.Equal(t, test.Output+"\n", requireRun(t, exitOK, "commit", "-blob", hexBlob))
+
+	blob := ckzgtest.RandomBlob(0)
+	binaryBlob := writeFile(t, dir, "blob.bin", blob[:])
+	hexCommitment := requireRun(t, exitOK, "commit", "-blob", binaryBlob)
+	binaryCommitment := requireRun(t, exitOK, "commit", "-binary", "-blob", binaryBlob)
+	require.Len(t, binaryCommitment, 48)
+	require.Equal(t, fmt.Sprintf("0x%x\n", binaryCommitment), hexCommitment)
+}
+
+func TestProveAndVerify(t *testing.T) {
+	dir := t.TempDir()
+	blob := ckzgtest.RandomBlob(1)
+	blobPath := writeFile(t, dir, "blob", blob[:])
+	commitment := strings.TrimSpace(requireRun(t, exitOK, "commit", "-blob", blobPath))
+
+	// Blob proofs, with the commitment given inline and in a file.
+	proof := strings.TrimSpace(requireRun(t, exitOK, "prove-blob", "-blob", blobPath, "-commitment", commitment))
+	commitmentPath := writeFile(t, dir, "commitment", []byte(commitment))
+	require.Equal(t, "valid\n", requireRun(t, exitOK, "verify-blob", "-blob", blobPath, "-commitment", commitmentPath, "-proof", proof))
+	require.Equal(t, "invalid\n", requireRun(t, exitInvalid, "verify-blob", "-blob", blobPath, "-commitment", commitment, "-proof", commitment))
+
+	// Evaluation proofs.
+	z := ckzgtest.RandomFieldElement(2)
+	lines := strings.Fields(requireRun(t, exitOK, "prove", "-blob", blobPath, "-z", z.String()))
+	require.Len(t, lines, 2)
+	proof, y := lines[0], lines[1]
+	require.Equal(t, "valid\n", requireRun(t, exitOK, "verify", "-commitment", commitment, "-z", z.String(), "-y", y, "-proof", proof))
+	require.Equal(t, "invalid\n", requireRun(t, exitInvalid, "verify", "-commitment", commitment, "-z", z.String(), "-y", z.String(), "-proof", proof))
+}
+
+func TestErrors(t *testing.T) {
+	dir := t.TempDir()
+	blob := ckzgtest.RandomBlob(0)
+	blobPath := writeFile(t, dir, "blob", blob[:])
+
+	status, _, stderr := runCLI(t)
+	require.Equal(t, exitError, status)
+	require.Contains(t, stderr, "verify-blob")
+	status, _, _ = runCLI(t, "help")
+	require.Equal(t, exitOK, status)
+	status, _, stderr = runCLI(t, "frobnicate")
+	require.Equal(t, exitError, status)
+	require.Contains(t, stderr, `unknown command "frobnicate"`)
+
+	requireRun(t, exitError, "commit")
+	requireRun(t, exitError, "commit", "-blob", blobPath, "extra")
+	requireRun(t, exitError, "commit", "-blob", filepath.Join(dir, "missing"))
+	requireRun(t, exitError, "commit", "-blob", writeFile(t, dir, "short", blob[:100]))
+	requireRun(t, exitError, "prove", "-blob", blobPath, "-z", "0x00")
+	status, _, stderr = runCLI(t, "commit", "-setup", "", "-blob", blobPath)
+	require.Equal(t, exitError, status)
+	require.Contains(t, stderr, "no trusted setup")
+
+	// A non-canonical field element is rejected by the library.
+	nonCanonical := ckzgtest.NonCanonicalFieldElement()
+	requireRun(t, exitError, "prove", "-blob", blobPath, "-z", nonCanonical.String())
+}
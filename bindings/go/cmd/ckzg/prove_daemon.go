@@ -0,0 +1,46 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"runtime"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+	"github.com/ethereum/c-kzg-4844/bindings/go/prover"
+)
+
+func init() {
+	commands["prove-daemon"] = command{
+		usage:   "-dir DIR [-addr ADDRESS] [-workers N]",
+		summary: "Serve blob proving jobs over HTTP, persisting them in a directory.",
+		run:     runProveDaemon,
+	}
+}
+
+func runProveDaemon(fs *flag.FlagSet, args []string, stdout io.Writer) error {
+	setup := setupFlag(fs)
+	dir := fs.String("dir", "", "directory holding the jobs")
+	addr := fs.String("addr", "localhost:8546", "address to listen on")
+	workers := fs.Int("workers", runtime.NumCPU(), "number of blobs proven at once")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs, "dir"); err != nil {
+		return err
+	}
+	if *workers < 1 {
+		return errors.New("-workers must be positive")
+	}
+	if err := loadSetup(*setup); err != nil {
+		return err
+	}
+	defer ckzg4844.FreeTrustedSetup()
+
+	p, err := prover.Open(ckzg4844.DefaultBackend, *dir, *workers)
+	if err != nil {
+		return err
+	}
+	defer p.Close()
+	return listenAndServe(*addr, prover.Handler(p), stdout)
+}
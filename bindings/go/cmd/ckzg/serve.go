@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"runtime"
+	"syscall"
+	"time"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+	"github.com/ethereum/c-kzg-4844/bindings/go/jsonrpc"
+	"github.com/ethereum/c-kzg-4844/bindings/go/limit"
+	"github.com/ethereum/c-kzg-4844/bindings/go/rest"
+)
+
+func init() {
+	commands["serve"] = command{
+		usage:   "[-addr ADDRESS] [-concurrency N]",
+		summary: "Serve the KZG operations over JSON-RPC and REST, until interrupted.",
+		run:     runServe,
+	}
+}
+
+// shutdownTimeout is how long servers wait for the requests in progress when
+// interrupted.
+const shutdownTimeout = 10 * time.Second
+
+func runServe(fs *flag.FlagSet, args []string, stdout io.Writer) error {
+	setup := setupFlag(fs)
+	addr := fs.String("addr", "localhost:8545", "address to listen on")
+	concurrency := fs.Int("concurrency", runtime.NumCPU(), "number of operations run at once, across both protocols")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs); err != nil {
+		return err
+	}
+	if *concurrency < 1 {
+		return errors.New("-concurrency must be positive")
+	}
+	if err := loadSetup(*setup); err != nil {
+		return err
+	}
+	defer ckzg4844.FreeTrustedSetup()
+
+	return listenAndServe(*addr, serveHandler(*concurrency), stdout)
+}
+
+// listenAndServe serves handler on addr until interrupted, then waits for
+// the requests in progress.
+func listenAndServe(addr string, handler http.Handler, stdout io.Writer) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	server := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	errs := make(chan error, 1)
+	go func() { errs <- server.Serve(listener) }()
+	fmt.Fprintf(stdout, "listening on %v\n", listener.Addr())
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errs; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// serveHandler returns the handler of serve, with the REST endpoints at
+// their paths and the JSON-RPC server at /.
+func serveHandler(concurrency int) http.Handler {
+	limiter := limit.New(ckzg4844.DefaultBackend, limit.Config{MaxConcurrent: concurrency})
+	server := rest.NewServer(ckzg4844.DefaultBackend)
+	server.Limiter = limiter
+	mux := http.NewServeMux()
+	for _, endpoint := range []string{"/commitments", "/proofs", "/verify-batch", "/recover"} {
+		mux.Handle(endpoint, server)
+	}
+	mux.Handle("/", jsonrpc.NewServer(limiter))
+	return mux
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServeHandler(t *testing.T) {
+	require.NoError(t, loadSetup(trustedSetupFile))
+	defer ckzg4844.FreeTrustedSetup()
+	server := httptest.NewServer(serveHandler(1))
+	defer server.Close()
+
+	infinity := `"0xc0` + strings.Repeat("00", 47) + `"`
+	zero := `"0x` + strings.Repeat("00", 32) + `"`
+	resp, err := http.Post(server.URL, "application/json", strings.NewReader(
+		`{"jsonrpc":"2.0","id":1,"method":"kzg_verifyKZGProof","params":[`+infinity+`,`+zero+`,`+zero+`,`+infinity+`]}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"jsonrpc":"2.0","id":1,"result":true}`, string(body))
+
+	resp, err = http.Post(server.URL+"/commitments", "application/json", strings.NewReader(`{"items":[{"blob":"0x"}]}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Contains(t, string(body), `"error":"invalid blob`)
+}
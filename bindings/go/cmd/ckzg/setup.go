@@ -0,0 +1,351 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+)
+
+const (
+	bytesPerG1 = 48
+	bytesPerG2 = 96
+
+	// maxSetupSize bounds the size of a downloaded trusted setup.
+	maxSetupSize = 4 << 20
+)
+
+/*
+The trusted setup formats:
+
+  - txt, the text format of src/trusted_setup.txt: the number of G1 and G2
+    points, followed by each point in hex.
+  - json, the format published by the KZG ceremony, with g1_lagrange and
+    g2_monomial arrays of 0x-prefixed hex points.
+  - bin, the number of G1 and G2 points as big-endian uint64s followed by
+    the compressed points. Its SHA-256 digest is the fingerprint of the
+    setup, see ckzg4844.FingerprintTrustedSetup.
+
+The format of a file is given by its extension, and is txt for other
+extensions.
+*/
+var setupFormats = []string{"txt", "json", "bin"}
+
+// setupCommands are the subcommands of ckzg setup.
+var setupCommands = map[string]command{
+	"fetch": {
+		usage:   "-url URL [-digest HEX] -o FILE",
+		summary: "Download a trusted setup in the text format and check its SHA-256 digest.",
+		run:     runSetupFetch,
+	},
+	"verify": {
+		usage:   "-setup FILE",
+		summary: "Check that a trusted setup is well-formed, with pairing checks.",
+		run:     runSetupVerify,
+	},
+	"convert": {
+		usage:   "-setup FILE [-to FORMAT] [-o FILE]",
+		summary: "Convert a trusted setup between the txt, json and bin formats.",
+		run:     runSetupConvert,
+	},
+	"info": {
+		usage:   "-setup FILE",
+		summary: "Show the parameters and digests of a trusted setup.",
+		run:     runSetupInfo,
+	},
+}
+
+func init() {
+	commands["setup"] = command{
+		usage:   "fetch|verify|convert|info [flags]",
+		summary: "Fetch, verify, convert and inspect trusted setups.",
+		run:     runSetup,
+	}
+}
+
+func runSetup(fs *flag.FlagSet, args []string, stdout io.Writer) error {
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: %v <subcommand> [flags]\n\n", fs.Name())
+		setupUsage(fs.Output())
+	}
+	if len(args) == 0 {
+		fs.Usage()
+		return errors.New("missing subcommand")
+	}
+	name := args[0]
+	if name == "-h" || name == "-help" || name == "help" {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+	cmd, ok := setupCommands[name]
+	if !ok {
+		fs.Usage()
+		return fmt.Errorf("unknown subcommand %q", name)
+	}
+	sub := flag.NewFlagSet(fs.Name()+" "+name, flag.ContinueOnError)
+	sub.SetOutput(fs.Output())
+	sub.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: %v %v\n\n%v\n\n", sub.Name(), cmd.usage, cmd.summary)
+		sub.PrintDefaults()
+	}
+	return cmd.run(sub, args[1:], stdout)
+}
+
+// setupUsage lists the subcommands of ckzg setup, for its -h.
+func setupUsage(w io.Writer) {
+	names := make([]string, 0, len(setupCommands))
+	for name := range setupCommands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprint(w, "subcommands:\n")
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-12v %v\n", name, setupCommands[name].summary)
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Formats
+///////////////////////////////////////////////////////////////////////////////
+
+// setupFormat returns the format of the setup file at path.
+func setupFormat(path string) string {
+	switch ext := strings.TrimPrefix(filepath.Ext(path), "."); ext {
+	case "json", "bin":
+		return ext
+	}
+	return "txt"
+}
+
+// loadSetupData loads the trusted setup in data, in the json or bin format.
+func loadSetupData(data []byte, format string) error {
+	if format == "json" {
+		return ckzg4844.LoadTrustedSetupJSON(data)
+	}
+	if len(data) < 16 {
+		return errors.New("binary setup is too short")
+	}
+	numG1 := binary.BigEndian.Uint64(data[:8])
+	numG2 := binary.BigEndian.Uint64(data[8:16])
+	points := data[16:]
+	if numG1 > uint64(len(points))/bytesPerG1 || numG2 > uint64(len(points))/bytesPerG2 ||
+		numG1*bytesPerG1+numG2*bytesPerG2 != uint64(len(points)) {
+		return errors.New("binary setup length does not match its point counts")
+	}
+	return ckzg4844.LoadTrustedSetup(points[:numG1*bytesPerG1], points[numG1*bytesPerG1:])
+}
+
+// writeSetupFormat writes the loaded trusted setup to w in the given format.
+func writeSetupFormat(w io.Writer, format string) error {
+	switch format {
+	case "json":
+		g1Bytes, g2Bytes := ckzg4844.TrustedSetupBytes()
+		encoded, err := json.MarshalIndent(struct {
+			G1Lagrange []string `json:"g1_lagrange"`
+			G2Monomial []string `json:"g2_monomial"`
+		}{hexPoints(g1Bytes, bytesPerG1), hexPoints(g2Bytes, bytesPerG2)}, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(append(encoded, '\n'))
+		return err
+	case "bin":
+		g1Bytes, g2Bytes := ckzg4844.TrustedSetupBytes()
+		var header [16]byte
+		binary.BigEndian.PutUint64(header[:8], uint64(len(g1Bytes)/bytesPerG1))
+		binary.BigEndian.PutUint64(header[8:], uint64(len(g2Bytes)/bytesPerG2))
+		for _, b := range [][]byte{header[:], g1Bytes, g2Bytes} {
+			if _, err := w.Write(b); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return ckzg4844.SaveTrustedSetup(w)
+}
+
+// hexPoints splits points into points of size bytes in 0x-prefixed hex.
+func hexPoints(points []byte, size int) []string {
+	out := make([]string, 0, len(points)/size)
+	for i := 0; i < len(points); i += size {
+		out = append(out, "0x"+hex.EncodeToString(points[i:i+size]))
+	}
+	return out
+}
+
+// writeOutput writes the output of write to the file at path, or to stdout if
+// path is empty. The file is only created if write succeeds.
+func writeOutput(path string, stdout io.Writer, write func(w io.Writer) error) error {
+	if path == "" {
+		return write(stdout)
+	}
+	var buf bytes.Buffer
+	if err := write(&buf); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Subcommands
+///////////////////////////////////////////////////////////////////////////////
+
+func runSetupFetch(fs *flag.FlagSet, args []string, stdout io.Writer) error {
+	url := fs.String("url", "", "URL of the trusted setup in the text format, at an immutable tag or commit")
+	digestArg := fs.String("digest", ckzg4844.MainnetTrustedSetupDigest.String(), "expected SHA-256 digest of the file")
+	out := fs.String("o", "", "file to write the trusted setup to")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs, "url", "digest", "o"); err != nil {
+		return err
+	}
+	var digest ckzg4844.Bytes32
+	if err := digest.UnmarshalText([]byte(*digestArg)); err != nil {
+		return errors.New("-digest is not 32 bytes of hex")
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, *url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %v", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSetupSize+1))
+	if err != nil {
+		return err
+	}
+	if len(data) > maxSetupSize {
+		return fmt.Errorf("response exceeds %v bytes", maxSetupSize)
+	}
+	if got := ckzg4844.Bytes32(sha256.Sum256(data)); got != digest {
+		return fmt.Errorf("%w: got %v, expected %v", ckzg4844.ErrTrustedSetupDigestMismatch, got, digest)
+	}
+	if err := os.WriteFile(*out, data, 0o644); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(stdout, "%v\n", *out)
+	return err
+}
+
+func runSetupVerify(fs *flag.FlagSet, args []string, stdout io.Writer) error {
+	setup := setupFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs, "setup"); err != nil {
+		return err
+	}
+	if err := loadSetup(*setup); err != nil {
+		return err
+	}
+	defer ckzg4844.FreeTrustedSetup()
+
+	if err := ckzg4844.VerifyTrustedSetup(); err != nil {
+		if errors.Is(err, ckzg4844.ErrInvalidTrustedSetup) {
+			return errInvalid
+		}
+		return err
+	}
+	_, err := fmt.Fprintln(stdout, "valid")
+	return err
+}
+
+func runSetupConvert(fs *flag.FlagSet, args []string, stdout io.Writer) error {
+	setup := setupFlag(fs)
+	to := fs.String("to", "", "output format: txt, json or bin (default the format of -o)")
+	out := fs.String("o", "", "output file (default stdout)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs, "setup"); err != nil {
+		return err
+	}
+	format := *to
+	if format == "" {
+		if *out == "" {
+			return errors.New("missing -to or -o")
+		}
+		format = setupFormat(*out)
+	}
+	if !isSetupFormat(format) {
+		return fmt.Errorf("unknown format %q, expected one of %v", format, strings.Join(setupFormats, ", "))
+	}
+	if err := loadSetup(*setup); err != nil {
+		return err
+	}
+	defer ckzg4844.FreeTrustedSetup()
+
+	return writeOutput(*out, stdout, func(w io.Writer) error {
+		return writeSetupFormat(w, format)
+	})
+}
+
+func runSetupInfo(fs *flag.FlagSet, args []string, stdout io.Writer) error {
+	setup := setupFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs, "setup"); err != nil {
+		return err
+	}
+	data, err := os.ReadFile(*setup)
+	if err != nil {
+		return err
+	}
+	if err := loadSetup(*setup); err != nil {
+		return err
+	}
+	defer ckzg4844.FreeTrustedSetup()
+
+	g1Bytes, g2Bytes := ckzg4844.TrustedSetupBytes()
+	numG1, numG2 := len(g1Bytes)/bytesPerG1, len(g2Bytes)/bytesPerG2
+	digest := ckzg4844.Bytes32(sha256.Sum256(data))
+	info := []struct {
+		name  string
+		value interface{}
+	}{
+		{"format", setupFormat(*setup)},
+		{"g1 points", numG1},
+		{"g2 points", numG2},
+		{"field elements per blob", ckzg4844.FieldElementsPerBlob},
+		{"sha256", digest},
+		{"fingerprint", ckzg4844.TrustedSetupFingerprint()},
+		{"mainnet file", digest == ckzg4844.MainnetTrustedSetupDigest},
+		{"memory", fmt.Sprintf("%v bytes", ckzg4844.EstimateSetupMemory(numG1, numG2, ckzg4844.SetupOptions{}))},
+	}
+	for _, line := range info {
+		if _, err := fmt.Fprintf(stdout, "%-24v %v\n", line.name+":", line.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isSetupFormat(format string) bool {
+	for _, f := range setupFormats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"crypto/sha256"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+	"github.com/stretchr/testify/require"
+)
+
+// infoField returns the value of a field in the output of ckzg setup info.
+func infoField(t *testing.T, info, name string) string {
+	t.Helper()
+	for _, line := range strings.Split(info, "\n") {
+		if strings.HasPrefix(line, name+":") {
+			return strings.TrimSpace(strings.TrimPrefix(line, name+":"))
+		}
+	}
+	t.Fatalf("no %v in %q", name, info)
+	return ""
+}
+
+func TestSetupConvert(t *testing.T) {
+	dir := t.TempDir()
+	info := requireRun(t, exitOK, "setup", "info")
+	fingerprint := infoField(t, info, "fingerprint")
+	require.Equal(t, "4096", infoField(t, info, "g1 points"))
+	require.Equal(t, "65", infoField(t, info, "g2 points"))
+	require.Equal(t, "true", infoField(t, info, "mainnet file"))
+
+	// Convert through every format and back, keeping the same points.
+	jsonPath := filepath.Join(dir, "setup.json")
+	binPath := filepath.Join(dir, "setup.bin")
+	txtPath := filepath.Join(dir, "setup.txt")
+	requireRun(t, exitOK, "setup", "convert", "-o", jsonPath)
+	requireRunWithSetup(t, exitOK, jsonPath, "setup", "convert", "-o", binPath)
+	requireRunWithSetup(t, exitOK, binPath, "setup", "convert", "-o", txtPath)
+	for _, path := range []string{jsonPath, binPath, txtPath} {
+		info := requireRunWithSetup(t, exitOK, path, "setup", "info")
+		require.Equal(t, fingerprint, infoField(t, info, "fingerprint"), path)
+	}
+
+	// The digest of the binary format is the fingerprint.
+	data, err := os.ReadFile(binPath)
+	require.NoError(t, err)
+	require.Equal(t, fingerprint, ckzg4844.Bytes32(sha256.Sum256(data)).String())
+
+	// Without -o, the setup is written to stdout.
+	text := requireRun(t, exitOK, "setup", "convert", "-to", "txt")
+	require.True(t, strings.HasPrefix(text, "4096\n65\n"))
+	requireRun(t, exitError, "setup", "convert", "-to", "yaml")
+	requireRun(t, exitError, "setup", "convert")
+}
+
+func TestSetupVerify(t *testing.T) {
+	require.Equal(t, "valid\n", requireRun(t, exitOK, "setup", "verify"))
+
+	// Swapping two G1 points keeps every point valid but breaks the setup.
+	data, err := os.ReadFile(trustedSetupFile)
+	require.NoError(t, err)
+	lines := strings.Split(string(data), "\n")
+	lines[2], lines[3] = lines[3], lines[2]
+	path := filepath.Join(t.TempDir(), "swapped.txt")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0o644))
+	require.Equal(t, "invalid\n", requireRunWithSetup(t, exitInvalid, path, "setup", "verify"))
+}
+
+func TestSetupFetch(t *testing.T) {
+	data, err := os.ReadFile(trustedSetupFile)
+	require.NoError(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/trusted_setup.txt" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(data)
+	}))
+	defer server.Close()
+	dir := t.TempDir()
+
+	out := filepath.Join(dir, "fetched.txt")
+	status, stdout, stderr := runCLI(t, "setup", "fetch", "-url", server.URL+"/trusted_setup.txt", "-o", out)
+	require.Equal(t, exitOK, status, stderr)
+	require.Equal(t, out+"\n", stdout)
+	fetched, err := os.ReadFile(out)
+	require.NoError(t, err)
+	require.Equal(t, data, fetched)
+
+	out = filepath.Join(dir, "pinned.txt")
+	status, _, stderr = runCLI(t, "setup", "fetch", "-url", server.URL+"/trusted_setup.txt", "-digest", ckzg4844.Bytes32{}.String(), "-o", out)
+	require.Equal(t, exitError, status)
+	require.Contains(t, stderr, "digest mismatch")
+	require.NoFileExists(t, out)
+
+	status, _, stderr = runCLI(t, "setup", "fetch", "-url", server.URL+"/missing", "-o", out)
+	require.Equal(t, exitError, status)
+	require.Contains(t, stderr, "404")
+}
+
+func TestSetupUsage(t *testing.T) {
+	status, _, stderr := runCLI(t, "setup")
+	require.Equal(t, exitError, status)
+	require.Contains(t, stderr, "convert")
+	status, _, _ = runCLI(t, "setup", "-h")
+	require.Equal(t, exitOK, status)
+	status, _, stderr = runCLI(t, "setup", "frobnicate")
+	require.Equal(t, exitError, status)
+	require.Contains(t, stderr, `unknown subcommand "frobnicate"`)
+}
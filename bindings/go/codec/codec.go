@@ -0,0 +1,95 @@
+// Package codec packs arbitrary data into blobs and back.
+//
+// The encoding treats a sequence of blobs as a stream of field elements. Each
+// field element carries 31 bytes of payload in bytes 1..31; byte 0 is always
+// zero, so every field element is canonical. The payload stream starts with
+// an 8-byte big-endian length, followed by the data, followed by zero
+// padding up to the end of the last blob.
+//
+// Encoding is deterministic and decoding is strict: a blob sequence decodes
+// only if it is exactly what EncodeToBlobs would produce for the decoded
+// data.
+package codec
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+)
+
+const (
+	// BytesPerFieldElementPayload is the number of data bytes stored in each
+	// field element.
+	BytesPerFieldElementPayload = ckzg4844.BytesPerFieldElement - 1
+	// BytesPerBlobPayload is the number of payload bytes stored in each blob,
+	// including the length prefix.
+	BytesPerBlobPayload = ckzg4844.FieldElementsPerBlob * BytesPerFieldElementPayload
+	// lengthPrefixSize is the size of the big-endian length prefix.
+	lengthPrefixSize = 8
+)
+
+var (
+	ErrNoBlobs         = errors.New("no blobs to decode")
+	ErrNonZeroHighByte = errors.New("field element has a non-zero first byte")
+	ErrInvalidLength   = errors.New("encoded length exceeds blob capacity")
+	ErrInvalidPadding  = errors.New("invalid padding after data")
+)
+
+// NumBlobs returns how many blobs EncodeToBlobs uses for dataLen bytes.
+func NumBlobs(dataLen int) int {
+	return (lengthPrefixSize + dataLen + BytesPerBlobPayload - 1) / BytesPerBlobPayload
+}
+
+// EncodeToBlobs packs data into as few blobs as possible. It always returns
+// at least one blob, so that empty data round-trips.
+func EncodeToBlobs(data []byte) []ckzg4844.Blob {
+	stream := make([]byte, lengthPrefixSize+len(data))
+	binary.BigEndian.PutUint64(stream, uint64(len(data)))
+	copy(stream[lengthPrefixSize:], data)
+
+	blobs := make([]ckzg4844.Blob, NumBlobs(len(data)))
+	for i := range blobs {
+		for j := 0; j < ckzg4844.FieldElementsPerBlob && len(stream) > 0; j++ {
+			offset := j*ckzg4844.BytesPerFieldElement + 1
+			n := copy(blobs[i][offset:offset+BytesPerFieldElementPayload], stream)
+			stream = stream[n:]
+		}
+	}
+	return blobs
+}
+
+// DecodeFromBlobs reverses EncodeToBlobs.
+func DecodeFromBlobs(blobs []ckzg4844.Blob) ([]byte, error) {
+	if len(blobs) == 0 {
+		return nil, ErrNoBlobs
+	}
+
+	stream := make([]byte, 0, len(blobs)*BytesPerBlobPayload)
+	for i := range blobs {
+		for j := 0; j < ckzg4844.FieldElementsPerBlob; j++ {
+			offset := j * ckzg4844.BytesPerFieldElement
+			if blobs[i][offset] != 0 {
+				return nil, fmt.Errorf("%w: blob %v field element %v", ErrNonZeroHighByte, i, j)
+			}
+			stream = append(stream, blobs[i][offset+1:offset+ckzg4844.BytesPerFieldElement]...)
+		}
+	}
+
+	length := binary.BigEndian.Uint64(stream)
+	if length > uint64(len(stream)-lengthPrefixSize) {
+		return nil, fmt.Errorf("%w: %v bytes in %v blobs", ErrInvalidLength, length, len(blobs))
+	}
+	if NumBlobs(int(length)) != len(blobs) {
+		return nil, fmt.Errorf("%w: %v bytes need %v blobs, got %v",
+			ErrInvalidPadding, length, NumBlobs(int(length)), len(blobs))
+	}
+	end := lengthPrefixSize + int(length)
+	for _, b := range stream[end:] {
+		if b != 0 {
+			return nil, ErrInvalidPadding
+		}
+	}
+	return stream[lengthPrefixSize:end], nil
+}
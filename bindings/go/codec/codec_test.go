@@ -0,0 +1,63 @@
+package codec
+
+import (
+	"math/rand"
+	"testing"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	for _, n := range []int{
+		0, 1, 30, 31, 32,
+		BytesPerBlobPayload - lengthPrefixSize,
+		BytesPerBlobPayload - lengthPrefixSize + 1,
+		3*BytesPerBlobPayload + 17,
+	} {
+		data := make([]byte, n)
+		r.Read(data)
+
+		blobs := EncodeToBlobs(data)
+		require.Len(t, blobs, NumBlobs(n))
+		for i := range blobs {
+			require.NoError(t, ckzg4844.ValidateBlob(&blobs[i]))
+		}
+		decoded, err := DecodeFromBlobs(blobs)
+		require.NoError(t, err)
+		require.Equal(t, data, decoded)
+	}
+}
+
+func TestNumBlobs(t *testing.T) {
+	require.Equal(t, 1, NumBlobs(0))
+	require.Equal(t, 1, NumBlobs(BytesPerBlobPayload-lengthPrefixSize))
+	require.Equal(t, 2, NumBlobs(BytesPerBlobPayload-lengthPrefixSize+1))
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	_, err := DecodeFromBlobs(nil)
+	require.ErrorIs(t, err, ErrNoBlobs)
+
+	blobs := EncodeToBlobs([]byte("hello"))
+	blobs[0][ckzg4844.BytesPerFieldElement] = 1
+	_, err = DecodeFromBlobs(blobs)
+	require.ErrorIs(t, err, ErrNonZeroHighByte)
+
+	blobs = EncodeToBlobs([]byte("hello"))
+	blobs[0][ckzg4844.BytesPerBlob-1] = 1
+	_, err = DecodeFromBlobs(blobs)
+	require.ErrorIs(t, err, ErrInvalidPadding)
+
+	// A trailing empty blob is not part of the canonical encoding.
+	blobs = append(EncodeToBlobs([]byte("hello")), ckzg4844.Blob{})
+	_, err = DecodeFromBlobs(blobs)
+	require.ErrorIs(t, err, ErrInvalidPadding)
+
+	// A length larger than the blobs can hold.
+	blobs = EncodeToBlobs(nil)
+	blobs[0][1] = 0xff
+	_, err = DecodeFromBlobs(blobs)
+	require.ErrorIs(t, err, ErrInvalidLength)
+}
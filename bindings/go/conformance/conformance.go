@@ -0,0 +1,278 @@
+// Package conformance runs spec-format YAML test vectors against any
+// implementation of ckzg4844.Backend, so that alternative backends can be
+// checked to behave exactly like the C library.
+//
+// The vectors are laid out as in the tests directory of this repository and
+// of the consensus-specs releases: <dir>/<operation>/<suite>/<case>/data.yaml,
+// each holding an input and the expected output, which is null when the
+// operation must fail.
+package conformance
+
+import (
+	"encoding"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// runner runs the vector in a data.yaml file against a backend.
+type runner func(t *testing.T, data []byte, backend ckzg4844.Backend)
+
+// runners maps the operations to the functions running their vectors.
+var runners = map[string]runner{
+	"blob_to_kzg_commitment":      runBlobToKZGCommitment,
+	"compute_kzg_proof":           runComputeKZGProof,
+	"compute_blob_kzg_proof":      runComputeBlobKZGProof,
+	"verify_kzg_proof":            runVerifyKZGProof,
+	"verify_blob_kzg_proof":       runVerifyBlobKZGProof,
+	"verify_blob_kzg_proof_batch": runVerifyBlobKZGProofBatch,
+}
+
+// operations lists the keys of runners, in the order Run executes them.
+var operations = []string{
+	"blob_to_kzg_commitment",
+	"compute_kzg_proof",
+	"compute_blob_kzg_proof",
+	"verify_kzg_proof",
+	"verify_blob_kzg_proof",
+	"verify_blob_kzg_proof_batch",
+}
+
+// Operations returns the names of the operations whose vectors Run executes.
+func Operations() []string {
+	return append([]string(nil), operations...)
+}
+
+/*
+Run executes every vector under dir against backend, each as a subtest named
+after its directory relative to dir. Directories of operations that are not in Operations are
+ignored, as are missing ones, but Run fails if dir holds no vectors at all.
+The backend must use the trusted setup the vectors were generated with,
+which is the mainnet setup for the vectors of this repository.
+*/
+func Run(t *testing.T, dir string, backend ckzg4844.Backend) {
+	total := 0
+	for _, operation := range operations {
+		paths, err := filepath.Glob(filepath.Join(dir, operation, "*", "*", "data.yaml"))
+		require.NoError(t, err)
+		total += len(paths)
+		for _, path := range paths {
+			path := path
+			name, err := filepath.Rel(dir, path)
+			require.NoError(t, err)
+			t.Run(filepath.Dir(name), func(t *testing.T) {
+				data, err := os.ReadFile(path)
+				require.NoError(t, err)
+				runners[operation](t, data, backend)
+			})
+		}
+	}
+	require.NotZero(t, total, "no test vectors in %v", dir)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Helper Functions
+///////////////////////////////////////////////////////////////////////////////
+
+// decode parses the hex strings into the values, stopping at the first that
+// is malformed. Vectors with malformed inputs expect the operation to fail.
+func decode(pairs ...interface{}) bool {
+	for i := 0; i < len(pairs); i += 2 {
+		if pairs[i+1].(encoding.TextUnmarshaler).UnmarshalText([]byte(pairs[i].(string))) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// decodeList parses a list of hex strings.
+func decodeList[T any, PT interface {
+	*T
+	encoding.TextUnmarshaler
+}](list []string) ([]T, bool) {
+	out := make([]T, len(list))
+	for i, s := range list {
+		if PT(&out[i]).UnmarshalText([]byte(s)) != nil {
+			return nil, false
+		}
+	}
+	return out, true
+}
+
+// parse decodes the vector in data into test.
+func parse(t *testing.T, data []byte, test interface{}) {
+	require.NoError(t, yaml.Unmarshal(data, test))
+}
+
+// requireBool checks the result of a verification against the vector.
+func requireBool(t *testing.T, expected *bool, valid bool, err error) {
+	if err != nil {
+		require.Nil(t, expected, "unexpected error: %v", err)
+		return
+	}
+	require.NotNil(t, expected, "expected an error")
+	require.Equal(t, *expected, valid)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Operations
+///////////////////////////////////////////////////////////////////////////////
+
+func runBlobToKZGCommitment(t *testing.T, data []byte, backend ckzg4844.Backend) {
+	var test struct {
+		Input struct {
+			Blob string `yaml:"blob"`
+		}
+		Output *string `yaml:"output"`
+	}
+	parse(t, data, &test)
+
+	blob := new(ckzg4844.Blob)
+	if !decode(test.Input.Blob, blob) {
+		require.Nil(t, test.Output)
+		return
+	}
+	commitment, err := backend.BlobToKZGCommitment(blob)
+	if err != nil {
+		require.Nil(t, test.Output, "unexpected error: %v", err)
+		return
+	}
+	require.NotNil(t, test.Output, "expected an error")
+	var expected ckzg4844.KZGCommitment
+	require.True(t, decode(*test.Output, &expected))
+	require.Equal(t, expected, commitment)
+}
+
+func runComputeKZGProof(t *testing.T, data []byte, backend ckzg4844.Backend) {
+	var test struct {
+		Input struct {
+			Blob string `yaml:"blob"`
+			Z    string `yaml:"z"`
+		}
+		Output *[]string `yaml:"output"`
+	}
+	parse(t, data, &test)
+
+	blob := new(ckzg4844.Blob)
+	var z ckzg4844.Bytes32
+	if !decode(test.Input.Blob, blob, test.Input.Z, &z) {
+		require.Nil(t, test.Output)
+		return
+	}
+	proof, y, err := backend.ComputeKZGProof(blob, z)
+	if err != nil {
+		require.Nil(t, test.Output, "unexpected error: %v", err)
+		return
+	}
+	require.NotNil(t, test.Output, "expected an error")
+	require.Len(t, *test.Output, 2)
+	var expectedProof ckzg4844.KZGProof
+	var expectedY ckzg4844.Bytes32
+	require.True(t, decode((*test.Output)[0], &expectedProof, (*test.Output)[1], &expectedY))
+	require.Equal(t, expectedProof, proof)
+	require.Equal(t, expectedY, y)
+}
+
+func runComputeBlobKZGProof(t *testing.T, data []byte, backend ckzg4844.Backend) {
+	var test struct {
+		Input struct {
+			Blob       string `yaml:"blob"`
+			Commitment string `yaml:"commitment"`
+		}
+		Output *string `yaml:"output"`
+	}
+	parse(t, data, &test)
+
+	blob := new(ckzg4844.Blob)
+	var commitment ckzg4844.Bytes48
+	if !decode(test.Input.Blob, blob, test.Input.Commitment, &commitment) {
+		require.Nil(t, test.Output)
+		return
+	}
+	proof, err := backend.ComputeBlobKZGProof(blob, commitment)
+	if err != nil {
+		require.Nil(t, test.Output, "unexpected error: %v", err)
+		return
+	}
+	require.NotNil(t, test.Output, "expected an error")
+	var expected ckzg4844.KZGProof
+	require.True(t, decode(*test.Output, &expected))
+	require.Equal(t, expected, proof)
+}
+
+func runVerifyKZGProof(t *testing.T, data []byte, backend ckzg4844.Backend) {
+	var test struct {
+		Input struct {
+			Commitment string `yaml:"commitment"`
+			Z          string `yaml:"z"`
+			Y          string `yaml:"y"`
+			Proof      string `yaml:"proof"`
+		}
+		Output *bool `yaml:"output"`
+	}
+	parse(t, data, &test)
+
+	var commitment, proof ckzg4844.Bytes48
+	var z, y ckzg4844.Bytes32
+	if !decode(test.Input.Commitment, &commitment, test.Input.Z, &z, test.Input.Y, &y, test.Input.Proof, &proof) {
+		require.Nil(t, test.Output)
+		return
+	}
+	valid, err := backend.VerifyKZGProof(commitment, z, y, proof)
+	requireBool(t, test.Output, valid, err)
+}
+
+func runVerifyBlobKZGProof(t *testing.T, data []byte, backend ckzg4844.Backend) {
+	var test struct {
+		Input struct {
+			Blob       string `yaml:"blob"`
+			Commitment string `yaml:"commitment"`
+			Proof      string `yaml:"proof"`
+		}
+		Output *bool `yaml:"output"`
+	}
+	parse(t, data, &test)
+
+	blob := new(ckzg4844.Blob)
+	var commitment, proof ckzg4844.Bytes48
+	if !decode(test.Input.Blob, blob, test.Input.Commitment, &commitment, test.Input.Proof, &proof) {
+		require.Nil(t, test.Output)
+		return
+	}
+	valid, err := backend.VerifyBlobKZGProof(blob, commitment, proof)
+	requireBool(t, test.Output, valid, err)
+}
+
+func runVerifyBlobKZGProofBatch(t *testing.T, data []byte, backend ckzg4844.Backend) {
+	var test struct {
+		Input struct {
+			Blobs       []string `yaml:"blobs"`
+			Commitments []string `yaml:"commitments"`
+			Proofs      []string `yaml:"proofs"`
+		}
+		Output *bool `yaml:"output"`
+	}
+	parse(t, data, &test)
+
+	blobs, ok := decodeList[ckzg4844.Blob](test.Input.Blobs)
+	if !ok {
+		require.Nil(t, test.Output)
+		return
+	}
+	commitments, ok := decodeList[ckzg4844.Bytes48](test.Input.Commitments)
+	if !ok {
+		require.Nil(t, test.Output)
+		return
+	}
+	proofs, ok := decodeList[ckzg4844.Bytes48](test.Input.Proofs)
+	if !ok {
+		require.Nil(t, test.Output)
+		return
+	}
+	valid, err := backend.VerifyBlobKZGProofBatch(blobs, commitments, proofs)
+	requireBool(t, test.Output, valid, err)
+}
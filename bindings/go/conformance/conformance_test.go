@@ -0,0 +1,22 @@
+package conformance
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+)
+
+func TestMain(m *testing.M) {
+	if err := ckzg4844.LoadTrustedSetupFile("../../../src/trusted_setup.txt"); err != nil {
+		panic(fmt.Sprintf("failed to load trusted setup: %v", err))
+	}
+	defer ckzg4844.FreeTrustedSetup()
+	code := m.Run()
+	os.Exit(code)
+}
+
+func TestDefaultBackend(t *testing.T) {
+	Run(t, "../../../tests", ckzg4844.DefaultBackend)
+}
@@ -0,0 +1,22 @@
+//go:build cgo && !ckzg_nocgo && ckzg_debug_log
+
+package ckzg4844
+
+// #cgo CFLAGS: -DC_KZG_DEBUG_LOG
+// extern void (*c_kzg_log_callback)(const char *msg);
+// extern void ckzgLog(char *msg);
+import "C"
+
+// With the ckzg_debug_log build tag, the C library is compiled with its debug
+// logging, which explains why a trusted setup was rejected and which entry of
+// a batch could not be verified. Its messages are passed to the logger at
+// LogLevelDebug.
+
+func init() {
+	C.c_kzg_log_callback = (*[0]byte)(C.ckzgLog)
+}
+
+//export ckzgLog
+func ckzgLog(msg *C.char) {
+	logf(LogLevelDebug, "c-kzg-4844: %s", C.GoString(msg))
+}
@@ -0,0 +1,39 @@
+//go:build cgo && !ckzg_nocgo && ckzg_debug_log
+
+// The debug logging tests only build with the ckzg_debug_log tag:
+//
+//	go test -tags ckzg_debug_log -run DebugLog
+
+package ckzg4844
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugLogVerifyBlobKZGProofBatch(t *testing.T) {
+	var mu sync.Mutex
+	var messages []string
+	SetLogger(func(level, msg string) {
+		mu.Lock()
+		defer mu.Unlock()
+		if level == LogLevelDebug {
+			messages = append(messages, msg)
+		}
+	})
+	defer SetLogger(nil)
+
+	blobs := make([]Blob, 2)
+	commitments := make([]Bytes48, 2)
+	proofs := make([]Bytes48, 2)
+	for i := range blobs {
+		commitments[i][0] = 0xc0
+		proofs[i][0] = 0xc0
+	}
+	commitments[1][0] = 0xff
+	_, err := VerifyBlobKZGProofBatch(blobs, commitments, proofs)
+	require.ErrorIs(t, err, ErrBadArgs)
+	require.Contains(t, messages, "c-kzg-4844: verify_blob_kzg_proof_batch: invalid commitment 1")
+}
@@ -0,0 +1,243 @@
+//go:build (darwin || linux) && (amd64 || arm64)
+
+/*
+Package dynamic calls a shared build of the C library, libckzg, which it loads
+at run time with purego instead of linking with cgo. Programs using it build
+with CGO_ENABLED=0, which suits cross-compilation, and run on 64-bit Linux
+and macOS wherever the shared library is present. Sizes are passed as
+uintptr, which has the size of size_t. Build the library with make shared
+in the src directory.
+
+A Library is a ckzg4844.Backend, taking and returning the types and errors of
+ckzg4844, so it can replace the cgo build wherever a Backend is used. Like
+the C library, a Library must not load or free its trusted setup while other
+calls are running, but is otherwise safe for concurrent use.
+*/
+package dynamic
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ebitengine/purego"
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+)
+
+const (
+	bytesPerG1 = 48
+	bytesPerG2 = 96
+)
+
+var (
+	// ErrNotLoaded is returned by the operations if no trusted setup is
+	// loaded.
+	ErrNotLoaded = errors.New("trusted setup is not loaded")
+	// ErrAlreadyLoaded is returned when loading a trusted setup while one is
+	// loaded.
+	ErrAlreadyLoaded = errors.New("trusted setup is already loaded")
+)
+
+// retOK is C_KZG_OK. The other values of C_KZG_RET are those of the
+// corresponding ckzg4844.ErrorCode.
+const retOK = 0
+
+// kzgSettings has the layout of KZGSettings. Its pointers are to memory
+// allocated by the library, which the garbage collector does not track.
+type kzgSettings struct {
+	maxWidth     uint64
+	rootsOfUnity uintptr
+	g1Values     uintptr
+	g2Values     uintptr
+}
+
+// Library is a loaded libckzg and its trusted setup.
+type Library struct {
+	handle   uintptr
+	settings kzgSettings
+	loaded   bool
+
+	loadTrustedSetup        func(out *kzgSettings, g1Bytes *byte, n1 uintptr, g2Bytes *byte, n2 uintptr) int32
+	freeTrustedSetup        func(s *kzgSettings)
+	blobToKZGCommitment     func(out *ckzg4844.KZGCommitment, blob *ckzg4844.Blob, s *kzgSettings) int32
+	computeKZGProof         func(proofOut *ckzg4844.KZGProof, yOut *ckzg4844.Bytes32, blob *ckzg4844.Blob, z *ckzg4844.Bytes32, s *kzgSettings) int32
+	computeBlobKZGProof     func(out *ckzg4844.KZGProof, blob *ckzg4844.Blob, commitment *ckzg4844.Bytes48, s *kzgSettings) int32
+	verifyKZGProof          func(ok *bool, commitment *ckzg4844.Bytes48, z, y *ckzg4844.Bytes32, proof *ckzg4844.Bytes48, s *kzgSettings) int32
+	verifyBlobKZGProof      func(ok *bool, blob *ckzg4844.Blob, commitment, proof *ckzg4844.Bytes48, s *kzgSettings) int32
+	verifyBlobKZGProofBatch func(ok *bool, blobs *ckzg4844.Blob, commitments, proofs *ckzg4844.Bytes48, n uintptr, s *kzgSettings) int32
+}
+
+var _ ckzg4844.Backend = (*Library)(nil)
+
+// Open loads the shared library at path, which is searched for as by
+// dlopen if it has no slash.
+func Open(path string) (*Library, error) {
+	handle, err := purego.Dlopen(path, purego.RTLD_NOW|purego.RTLD_LOCAL)
+	if err != nil {
+		return nil, err
+	}
+	l := &Library{handle: handle}
+	symbols := []struct {
+		fptr interface{}
+		name string
+	}{
+		{&l.loadTrustedSetup, "load_trusted_setup"},
+		{&l.freeTrustedSetup, "free_trusted_setup"},
+		{&l.blobToKZGCommitment, "blob_to_kzg_commitment"},
+		{&l.computeKZGProof, "compute_kzg_proof"},
+		{&l.computeBlobKZGProof, "compute_blob_kzg_proof"},
+		{&l.verifyKZGProof, "verify_kzg_proof"},
+		{&l.verifyBlobKZGProof, "verify_blob_kzg_proof"},
+		{&l.verifyBlobKZGProofBatch, "verify_blob_kzg_proof_batch"},
+	}
+	for _, symbol := range symbols {
+		// RegisterLibFunc panics on missing symbols, so look them up first.
+		if _, err := purego.Dlsym(handle, symbol.name); err != nil {
+			_ = purego.Dlclose(handle)
+			return nil, fmt.Errorf("%v is not libckzg: %w", path, err)
+		}
+		purego.RegisterLibFunc(symbol.fptr, handle, symbol.name)
+	}
+	return l, nil
+}
+
+// Close frees the trusted setup, if it is loaded, and unloads the library.
+// The Library must not be used afterwards.
+func (l *Library) Close() error {
+	l.FreeTrustedSetup()
+	return purego.Dlclose(l.handle)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Helper Functions
+///////////////////////////////////////////////////////////////////////////////
+
+func makeError(ret int32) error {
+	if ret == retOK {
+		return nil
+	}
+	return ckzg4844.ErrorCode(ret)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Interface Functions
+///////////////////////////////////////////////////////////////////////////////
+
+// LoadTrustedSetup is the binding for load_trusted_setup. The G1 points are
+// in Lagrange form.
+func (l *Library) LoadTrustedSetup(g1Bytes, g2Bytes []byte) error {
+	if l.loaded {
+		return ErrAlreadyLoaded
+	}
+	if len(g1Bytes) == 0 || len(g1Bytes)%bytesPerG1 != 0 || len(g2Bytes) == 0 || len(g2Bytes)%bytesPerG2 != 0 {
+		return ckzg4844.ErrBadArgs
+	}
+	ret := l.loadTrustedSetup(&l.settings, &g1Bytes[0], uintptr(len(g1Bytes)/bytesPerG1), &g2Bytes[0], uintptr(len(g2Bytes)/bytesPerG2))
+	if ret == retOK {
+		l.loaded = true
+	}
+	return makeError(ret)
+}
+
+// LoadTrustedSetupFile loads a trusted setup in the text format of
+// load_trusted_setup_file, such as src/trusted_setup.txt. The file is parsed
+// in Go, by ckzg4844.ParseTrustedSetup, so no C stdio is involved.
+func (l *Library) LoadTrustedSetupFile(trustedSetupFile string) error {
+	data, err := os.ReadFile(trustedSetupFile)
+	if err != nil {
+		return err
+	}
+	g1Bytes, g2Bytes, err := ckzg4844.ParseTrustedSetup(data)
+	if err != nil {
+		return err
+	}
+	return l.LoadTrustedSetup(g1Bytes, g2Bytes)
+}
+
+// FreeTrustedSetup is the binding for free_trusted_setup. It does nothing
+// if no trusted setup is loaded.
+func (l *Library) FreeTrustedSetup() {
+	if l.loaded {
+		l.freeTrustedSetup(&l.settings)
+		l.loaded = false
+	}
+}
+
+// BlobToKZGCommitment is the binding for blob_to_kzg_commitment.
+func (l *Library) BlobToKZGCommitment(blob *ckzg4844.Blob) (ckzg4844.KZGCommitment, error) {
+	var commitment ckzg4844.KZGCommitment
+	if !l.loaded {
+		return commitment, ErrNotLoaded
+	}
+	if blob == nil {
+		return commitment, ckzg4844.ErrBadArgs
+	}
+	err := makeError(l.blobToKZGCommitment(&commitment, blob, &l.settings))
+	return commitment, err
+}
+
+// ComputeKZGProof is the binding for compute_kzg_proof.
+func (l *Library) ComputeKZGProof(blob *ckzg4844.Blob, zBytes ckzg4844.Bytes32) (ckzg4844.KZGProof, ckzg4844.Bytes32, error) {
+	var proof ckzg4844.KZGProof
+	var y ckzg4844.Bytes32
+	if !l.loaded {
+		return proof, y, ErrNotLoaded
+	}
+	if blob == nil {
+		return proof, y, ckzg4844.ErrBadArgs
+	}
+	err := makeError(l.computeKZGProof(&proof, &y, blob, &zBytes, &l.settings))
+	return proof, y, err
+}
+
+// ComputeBlobKZGProof is the binding for compute_blob_kzg_proof.
+func (l *Library) ComputeBlobKZGProof(blob *ckzg4844.Blob, commitmentBytes ckzg4844.Bytes48) (ckzg4844.KZGProof, error) {
+	var proof ckzg4844.KZGProof
+	if !l.loaded {
+		return proof, ErrNotLoaded
+	}
+	if blob == nil {
+		return proof, ckzg4844.ErrBadArgs
+	}
+	err := makeError(l.computeBlobKZGProof(&proof, blob, &commitmentBytes, &l.settings))
+	return proof, err
+}
+
+// VerifyKZGProof is the binding for verify_kzg_proof.
+func (l *Library) VerifyKZGProof(commitmentBytes ckzg4844.Bytes48, zBytes, yBytes ckzg4844.Bytes32, proofBytes ckzg4844.Bytes48) (bool, error) {
+	if !l.loaded {
+		return false, ErrNotLoaded
+	}
+	var ok bool
+	err := makeError(l.verifyKZGProof(&ok, &commitmentBytes, &zBytes, &yBytes, &proofBytes, &l.settings))
+	return ok, err
+}
+
+// VerifyBlobKZGProof is the binding for verify_blob_kzg_proof.
+func (l *Library) VerifyBlobKZGProof(blob *ckzg4844.Blob, commitmentBytes, proofBytes ckzg4844.Bytes48) (bool, error) {
+	if !l.loaded {
+		return false, ErrNotLoaded
+	}
+	if blob == nil {
+		return false, ckzg4844.ErrBadArgs
+	}
+	var ok bool
+	err := makeError(l.verifyBlobKZGProof(&ok, blob, &commitmentBytes, &proofBytes, &l.settings))
+	return ok, err
+}
+
+// VerifyBlobKZGProofBatch is the binding for verify_blob_kzg_proof_batch.
+func (l *Library) VerifyBlobKZGProofBatch(blobs []ckzg4844.Blob, commitmentsBytes, proofsBytes []ckzg4844.Bytes48) (bool, error) {
+	if !l.loaded {
+		return false, ErrNotLoaded
+	}
+	if len(blobs) != len(commitmentsBytes) || len(blobs) != len(proofsBytes) {
+		return false, ckzg4844.ErrBadArgs
+	}
+	if len(blobs) == 0 {
+		return true, nil
+	}
+	var ok bool
+	err := makeError(l.verifyBlobKZGProofBatch(&ok, &blobs[0], &commitmentsBytes[0], &proofsBytes[0], uintptr(len(blobs)), &l.settings))
+	return ok, err
+}
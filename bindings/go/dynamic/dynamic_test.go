@@ -0,0 +1,100 @@
+//go:build (darwin || linux) && (amd64 || arm64)
+
+package dynamic
+
+import (
+	"flag"
+	"path/filepath"
+	"testing"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+	"github.com/ethereum/c-kzg-4844/bindings/go/conformance"
+	"github.com/stretchr/testify/require"
+)
+
+var library = flag.String("dynamic.library", "", "libckzg shared library to test, built with make shared")
+
+const (
+	trustedSetupFile = "../../../src/trusted_setup.txt"
+	testsDir         = "../../../tests"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// Helper Functions
+///////////////////////////////////////////////////////////////////////////////
+
+// openLibrary opens the library given by -dynamic.library, with the trusted
+// setup loaded. The tests calling it are skipped without one.
+func openLibrary(t *testing.T) *Library {
+	if *library == "" {
+		t.Skip("no -dynamic.library")
+	}
+	l, err := Open(*library)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, l.Close()) })
+	require.NoError(t, l.LoadTrustedSetupFile(trustedSetupFile))
+	return l
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Tests
+///////////////////////////////////////////////////////////////////////////////
+
+func TestConformance(t *testing.T) {
+	conformance.Run(t, testsDir, openLibrary(t))
+}
+
+func TestProveAndVerify(t *testing.T) {
+	l := openLibrary(t)
+	blobs := make([]ckzg4844.Blob, 2)
+	for i := range blobs {
+		for j := 0; j < ckzg4844.FieldElementsPerBlob; j++ {
+			blobs[i][j*ckzg4844.BytesPerFieldElement+31] = byte(i + j)
+		}
+	}
+	commitments := make([]ckzg4844.Bytes48, len(blobs))
+	proofs := make([]ckzg4844.Bytes48, len(blobs))
+	for i := range blobs {
+		commitment, err := l.BlobToKZGCommitment(&blobs[i])
+		require.NoError(t, err)
+		proof, err := l.ComputeBlobKZGProof(&blobs[i], ckzg4844.Bytes48(commitment))
+		require.NoError(t, err)
+		commitments[i], proofs[i] = ckzg4844.Bytes48(commitment), ckzg4844.Bytes48(proof)
+	}
+	valid, err := l.VerifyBlobKZGProofBatch(blobs, commitments, proofs)
+	require.NoError(t, err)
+	require.True(t, valid)
+	valid, err = l.VerifyBlobKZGProofBatch(blobs, commitments, []ckzg4844.Bytes48{proofs[1], proofs[0]})
+	require.NoError(t, err)
+	require.False(t, valid)
+
+	z := ckzg4844.Bytes32{31: 5}
+	proof, y, err := l.ComputeKZGProof(&blobs[0], z)
+	require.NoError(t, err)
+	valid, err = l.VerifyKZGProof(commitments[0], z, y, ckzg4844.Bytes48(proof))
+	require.NoError(t, err)
+	require.True(t, valid)
+}
+
+func TestErrors(t *testing.T) {
+	l := openLibrary(t)
+	require.ErrorIs(t, l.LoadTrustedSetupFile(trustedSetupFile), ErrAlreadyLoaded)
+	_, err := l.VerifyBlobKZGProofBatch(make([]ckzg4844.Blob, 1), nil, nil)
+	require.ErrorIs(t, err, ckzg4844.ErrBadArgs)
+	_, err = l.BlobToKZGCommitment(nil)
+	require.ErrorIs(t, err, ckzg4844.ErrBadArgs)
+	_, _, err = l.ComputeKZGProof(nil, ckzg4844.Bytes32{})
+	require.ErrorIs(t, err, ckzg4844.ErrBadArgs)
+	_, err = l.ComputeBlobKZGProof(nil, ckzg4844.Bytes48{})
+	require.ErrorIs(t, err, ckzg4844.ErrBadArgs)
+	_, err = l.VerifyBlobKZGProof(nil, ckzg4844.Bytes48{}, ckzg4844.Bytes48{})
+	require.ErrorIs(t, err, ckzg4844.ErrBadArgs)
+
+	l.FreeTrustedSetup()
+	_, err = l.BlobToKZGCommitment(new(ckzg4844.Blob))
+	require.ErrorIs(t, err, ErrNotLoaded)
+	require.ErrorIs(t, l.LoadTrustedSetup(nil, nil), ckzg4844.ErrBadArgs)
+
+	_, err = Open(filepath.Join(t.TempDir(), "libckzg.so"))
+	require.Error(t, err)
+}
@@ -0,0 +1,118 @@
+package ckzg4844
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrorCode identifies a category of failure. Every error returned by this
+// package is, or wraps, an ErrorCode, so callers can match it with errors.Is
+// or extract it with errors.As (or CodeOf) and switch on it.
+//
+// The numeric values are stable across releases. ErrBadArgs, ErrError and
+// ErrMalloc have the values of the corresponding C_KZG_RET codes of the C
+// library; the other codes are reported by checks done in Go. New codes are
+// only ever added.
+type ErrorCode int
+
+const (
+	ErrBadArgs ErrorCode = 1
+	ErrError   ErrorCode = 2
+	ErrMalloc  ErrorCode = 3
+
+	ErrInvalidProof               ErrorCode = 100
+	ErrVersionedHashMismatch      ErrorCode = 101
+	ErrNoBlobs                    ErrorCode = 102
+	ErrInvalidHashVersion         ErrorCode = 103
+	ErrBlobFull                   ErrorCode = 104
+	ErrInvalidCompression         ErrorCode = 105
+	ErrTrustedSetupDigestMismatch ErrorCode = 106
+	ErrInvalidTrustedSetup        ErrorCode = 107
+	ErrNotChecked                 ErrorCode = 108
+	ErrInternal                   ErrorCode = 109
+)
+
+// errorCodes lists every ErrorCode, in order of value.
+var errorCodes = []ErrorCode{
+	ErrBadArgs,
+	ErrError,
+	ErrMalloc,
+	ErrInvalidProof,
+	ErrVersionedHashMismatch,
+	ErrNoBlobs,
+	ErrInvalidHashVersion,
+	ErrBlobFull,
+	ErrInvalidCompression,
+	ErrTrustedSetupDigestMismatch,
+	ErrInvalidTrustedSetup,
+	ErrNotChecked,
+	ErrInternal,
+}
+
+// Error returns the description of the code.
+func (c ErrorCode) Error() string {
+	switch c {
+	case ErrBadArgs:
+		return "bad arguments"
+	case ErrError:
+		return "unexpected error"
+	case ErrMalloc:
+		return "malloc failed"
+	case ErrInvalidProof:
+		return "invalid proof"
+	case ErrVersionedHashMismatch:
+		return "versioned hash does not match commitment"
+	case ErrNoBlobs:
+		return "blob transaction has no blobs"
+	case ErrInvalidHashVersion:
+		return "versioned hash has an unsupported version"
+	case ErrBlobFull:
+		return "blob capacity exceeded"
+	case ErrInvalidCompression:
+		return "invalid compressed blob"
+	case ErrTrustedSetupDigestMismatch:
+		return "trusted setup digest mismatch"
+	case ErrInvalidTrustedSetup:
+		return "trusted setup is not well-formed"
+	case ErrNotChecked:
+		return "not checked after an earlier failure"
+	case ErrInternal:
+		return "internal error"
+	}
+	return fmt.Sprintf("unknown error code %d", int(c))
+}
+
+// ErrorCodes returns every ErrorCode this version of the package can report,
+// in order of value.
+func ErrorCodes() []ErrorCode {
+	return append([]ErrorCode(nil), errorCodes...)
+}
+
+// CodeOf returns the first ErrorCode in err's chain, and false if there is
+// none (including when err is nil).
+func CodeOf(err error) (ErrorCode, bool) {
+	var code ErrorCode
+	ok := errors.As(err, &code)
+	return code, ok
+}
+
+// explainError returns err, unless it is ErrBadArgs, in which case it returns
+// the first error from checks. The checks repeat the C library's input
+// validation in Go so that the error names the rejected input, and only run
+// after a call has failed. If no check fails, ErrBadArgs is returned as is.
+// The failure of the C function fn is logged, as an error unless it was
+// caused by bad arguments.
+func explainError(fn string, err error, checks ...func() error) error {
+	if err != ErrBadArgs {
+		logf(LogLevelError, "%v failed: %v", fn, err)
+		return err
+	}
+	for _, check := range checks {
+		if checkErr := check(); checkErr != nil {
+			err = checkErr
+			break
+		}
+	}
+	logf(LogLevelDebug, "%v failed: %v", fn, err)
+	return err
+}
@@ -0,0 +1,51 @@
+package ckzg4844
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorCodes(t *testing.T) {
+	// The values are part of the API; they must never change.
+	require.Equal(t, ErrorCode(1), ErrBadArgs)
+	require.Equal(t, ErrorCode(2), ErrError)
+	require.Equal(t, ErrorCode(3), ErrMalloc)
+	require.Equal(t, ErrorCode(100), ErrInvalidProof)
+	require.Equal(t, ErrorCode(108), ErrNotChecked)
+
+	messages := make(map[string]bool)
+	codes := ErrorCodes()
+	for i, code := range codes {
+		if i > 0 {
+			require.Less(t, codes[i-1], code)
+		}
+		require.NotContains(t, code.Error(), "unknown")
+		require.False(t, messages[code.Error()])
+		messages[code.Error()] = true
+	}
+	require.Equal(t, "unknown error code 4", ErrorCode(4).Error())
+}
+
+func TestCodeOf(t *testing.T) {
+	_, ok := CodeOf(nil)
+	require.False(t, ok)
+	_, ok = CodeOf(errors.New("other"))
+	require.False(t, ok)
+
+	err := fmt.Errorf("blob 2: %w", ErrInvalidProof)
+	code, ok := CodeOf(err)
+	require.True(t, ok)
+	require.Equal(t, ErrInvalidProof, code)
+	require.ErrorIs(t, err, ErrInvalidProof)
+	require.NotErrorIs(t, err, ErrBadArgs)
+
+	var blob Blob
+	blob[0] = 0xff
+	_, err = BlobToKZGCommitment(&blob)
+	code, ok = CodeOf(err)
+	require.True(t, ok)
+	require.Equal(t, ErrBadArgs, code)
+}
@@ -0,0 +1,18 @@
+//go:build cgo && !ckzg_nocgo && !windows
+
+package ckzg4844
+
+// #include <stdio.h>
+// #include <stdlib.h>
+import "C"
+import "unsafe"
+
+// fopen opens a file for reading with the C library, returning nil if it
+// cannot be opened.
+func fopen(path string) *C.FILE {
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+	cMode := C.CString("r")
+	defer C.free(unsafe.Pointer(cMode))
+	return C.fopen(cPath, cMode)
+}
@@ -0,0 +1,24 @@
+//go:build cgo && !ckzg_nocgo
+
+package ckzg4844
+
+// #include <stdio.h>
+// #include <wchar.h>
+import "C"
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// fopen opens a file for reading with the C library, returning nil if it
+// cannot be opened. On Windows, fopen takes paths in the ANSI code page
+// rather than UTF-8, so the path is converted to UTF-16 for _wfopen.
+func fopen(path string) *C.FILE {
+	cPath, err := syscall.UTF16FromString(path)
+	if err != nil {
+		return nil
+	}
+	cMode := []uint16{'r', 0}
+	return C._wfopen((*C.wchar_t)(unsafe.Pointer(&cPath[0])), (*C.wchar_t)(unsafe.Pointer(&cMode[0])))
+}
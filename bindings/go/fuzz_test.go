@@ -0,0 +1,377 @@
+package ckzg4844
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// Helper Functions
+///////////////////////////////////////////////////////////////////////////////
+
+// decodeFuzzSeed decodes a hex string from a reference test. Strings that are
+// not valid hex are used as is, which makes for malformed inputs as well.
+func decodeFuzzSeed(s string) []byte {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return []byte(s)
+	}
+	return b
+}
+
+// concatFuzzSeeds decodes and concatenates a list of hex strings.
+func concatFuzzSeeds(list []string) []byte {
+	var out []byte
+	for _, s := range list {
+		out = append(out, decodeFuzzSeed(s)...)
+	}
+	return out
+}
+
+// addFuzzSeeds decodes the input of every reference test matching pattern
+// into T and calls add with it.
+func addFuzzSeeds[T any](f *testing.F, pattern string, add func(input T)) {
+	tests, err := filepath.Glob(pattern)
+	require.NoError(f, err)
+	require.True(f, len(tests) > 0)
+	for _, testPath := range tests {
+		data, err := os.ReadFile(testPath)
+		require.NoError(f, err)
+		var test struct {
+			Input T `yaml:"input"`
+		}
+		require.NoError(f, yaml.Unmarshal(data, &test))
+		add(test.Input)
+	}
+}
+
+// splitFuzzInput cuts data into chunks of size bytes, dropping a partial
+// chunk at the end.
+func splitFuzzInput(data []byte, size int) [][]byte {
+	chunks := make([][]byte, 0, len(data)/size)
+	for len(data) >= size {
+		chunks = append(chunks, data[:size])
+		data = data[size:]
+	}
+	return chunks
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Fuzz Tests
+///////////////////////////////////////////////////////////////////////////////
+
+func FuzzBlobToKZGCommitment(f *testing.F) {
+	addFuzzSeeds(f, blobToKZGCommitmentTests, func(input struct {
+		Blob string `yaml:"blob"`
+	}) {
+		f.Add(decodeFuzzSeed(input.Blob))
+	})
+
+	f.Fuzz(func(t *testing.T, blobBytes []byte) {
+		blob := new(Blob)
+		if err := blob.UnmarshalBinary(blobBytes); err != nil {
+			require.NotEqual(t, BytesPerBlob, len(blobBytes))
+			return
+		}
+		commitment, err := BlobToKZGCommitment(blob)
+		if err != nil {
+			require.ErrorIs(t, err, ErrBadArgs)
+			require.Equal(t, err, ValidateBlob(blob))
+			return
+		}
+		require.NoError(t, ValidateBlob(blob))
+		require.NoError(t, ValidateG1(Bytes48(commitment)))
+	})
+}
+
+func FuzzComputeKZGProof(f *testing.F) {
+	addFuzzSeeds(f, computeKZGProofTests, func(input struct {
+		Blob string `yaml:"blob"`
+		Z    string `yaml:"z"`
+	}) {
+		f.Add(decodeFuzzSeed(input.Blob), decodeFuzzSeed(input.Z))
+	})
+
+	f.Fuzz(func(t *testing.T, blobBytes, zBytes []byte) {
+		blob := new(Blob)
+		var z Bytes32
+		if blob.UnmarshalBinary(blobBytes) != nil || z.UnmarshalBinary(zBytes) != nil {
+			return
+		}
+		proof, y, err := ComputeKZGProof(blob, z)
+		if err != nil {
+			require.ErrorIs(t, err, ErrBadArgs)
+			require.True(t, ValidateBlob(blob) != nil || ValidateFieldElement(z) != nil)
+			return
+		}
+		commitment, err := BlobToKZGCommitment(blob)
+		require.NoError(t, err)
+		ok, err := VerifyKZGProof(Bytes48(commitment), z, y, Bytes48(proof))
+		require.NoError(t, err)
+		require.True(t, ok)
+	})
+}
+
+func FuzzVerifyKZGProof(f *testing.F) {
+	addFuzzSeeds(f, verifyKZGProofTests, func(input struct {
+		Commitment string `yaml:"commitment"`
+		Z          string `yaml:"z"`
+		Y          string `yaml:"y"`
+		Proof      string `yaml:"proof"`
+	}) {
+		f.Add(decodeFuzzSeed(input.Commitment), decodeFuzzSeed(input.Z), decodeFuzzSeed(input.Y), decodeFuzzSeed(input.Proof))
+	})
+
+	f.Fuzz(func(t *testing.T, commitmentBytes, zBytes, yBytes, proofBytes []byte) {
+		var commitment, proof Bytes48
+		var z, y Bytes32
+		if commitment.UnmarshalBinary(commitmentBytes) != nil || z.UnmarshalBinary(zBytes) != nil ||
+			y.UnmarshalBinary(yBytes) != nil || proof.UnmarshalBinary(proofBytes) != nil {
+			return
+		}
+		valid := ValidateG1(commitment) == nil && ValidateFieldElement(z) == nil &&
+			ValidateFieldElement(y) == nil && ValidateG1(proof) == nil
+		_, err := VerifyKZGProof(commitment, z, y, proof)
+		if valid {
+			require.NoError(t, err)
+		} else {
+			require.ErrorIs(t, err, ErrBadArgs)
+		}
+	})
+}
+
+func FuzzVerifyBlobKZGProof(f *testing.F) {
+	addFuzzSeeds(f, verifyBlobKZGProofTests, func(input struct {
+		Blob       string `yaml:"blob"`
+		Commitment string `yaml:"commitment"`
+		Proof      string `yaml:"proof"`
+	}) {
+		f.Add(decodeFuzzSeed(input.Blob), decodeFuzzSeed(input.Commitment), decodeFuzzSeed(input.Proof))
+	})
+
+	f.Fuzz(func(t *testing.T, blobBytes, commitmentBytes, proofBytes []byte) {
+		blob := new(Blob)
+		var commitment, proof Bytes48
+		if blob.UnmarshalBinary(blobBytes) != nil || commitment.UnmarshalBinary(commitmentBytes) != nil ||
+			proof.UnmarshalBinary(proofBytes) != nil {
+			return
+		}
+		valid := ValidateBlob(blob) == nil && ValidateG1(commitment) == nil && ValidateG1(proof) == nil
+		_, err := VerifyBlobKZGProof(blob, commitment, proof)
+		if valid {
+			require.NoError(t, err)
+		} else {
+			require.ErrorIs(t, err, ErrBadArgs)
+		}
+	})
+}
+
+func FuzzVerifyBlobKZGProofBatch(f *testing.F) {
+	addFuzzSeeds(f, verifyBlobKZGProofBatchTests, func(input struct {
+		Blobs       []string `yaml:"blobs"`
+		Commitments []string `yaml:"commitments"`
+		Proofs      []string `yaml:"proofs"`
+	}) {
+		f.Add(concatFuzzSeeds(input.Blobs), concatFuzzSeeds(input.Commitments), concatFuzzSeeds(input.Proofs))
+	})
+
+	f.Fuzz(func(t *testing.T, blobsBytes, commitmentsBytes, proofsBytes []byte) {
+		var blobs []Blob
+		for _, chunk := range splitFuzzInput(blobsBytes, BytesPerBlob) {
+			blobs = append(blobs, *(*Blob)(chunk))
+		}
+		var commitments, proofs []Bytes48
+		for _, chunk := range splitFuzzInput(commitmentsBytes, BytesPerCommitment) {
+			commitments = append(commitments, *(*Bytes48)(chunk))
+		}
+		for _, chunk := range splitFuzzInput(proofsBytes, BytesPerProof) {
+			proofs = append(proofs, *(*Bytes48)(chunk))
+		}
+
+		ok, err := VerifyBlobKZGProofBatch(blobs, commitments, proofs)
+		if len(blobs) != len(commitments) || len(blobs) != len(proofs) {
+			require.Equal(t, ErrBadArgs, err)
+			return
+		}
+		// The batch must agree with verifying each blob on its own.
+		allOk, anyErr := true, false
+		for i := range blobs {
+			single, err := VerifyBlobKZGProof(&blobs[i], commitments[i], proofs[i])
+			anyErr = anyErr || err != nil
+			allOk = allOk && single
+		}
+		if anyErr {
+			require.ErrorIs(t, err, ErrBadArgs)
+		} else {
+			require.NoError(t, err)
+			require.Equal(t, allOk, ok)
+		}
+	})
+}
+
+func FuzzDecompressBlob(f *testing.F) {
+	var blob Blob
+	fillBlobRandom(&blob, 0)
+	f.Add(CompressBlob(&blob))
+	f.Add(CompressBlob(new(Blob)))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		blob, err := DecompressBlob(data)
+		if err != nil {
+			return
+		}
+		require.Equal(t, data, CompressBlob(blob))
+	})
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Trusted Setup Fuzz Tests
+///////////////////////////////////////////////////////////////////////////////
+
+// freeTrustedSetupForFuzzing frees the trusted setup loaded by TestMain, so
+// that the fuzz function can load its own, and loads it back when f is done.
+func freeTrustedSetupForFuzzing(f *testing.F) {
+	g1Bytes, g2Bytes := TrustedSetupBytes()
+	FreeTrustedSetup()
+	f.Cleanup(func() {
+		if loaded {
+			FreeTrustedSetup()
+		}
+		require.NoError(f, LoadTrustedSetup(g1Bytes, g2Bytes))
+	})
+}
+
+// addTrustedSetupFileSeeds adds the start of the mainnet setup file and a few
+// malformed variants of it. The fuzzer makes next to no progress with inputs
+// as large as a whole setup, so none is added.
+func addTrustedSetupFileSeeds(f *testing.F) {
+	data, err := os.ReadFile("../../src/trusted_setup.txt")
+	require.NoError(f, err)
+	lines := strings.SplitN(string(data), "\n", 4)
+	g1Point := lines[2]
+	g2Point := strings.Fields(string(data))[2+4096]
+	f.Add([]byte(strings.Join(lines[:3], "\n")))
+	f.Add([]byte("1 1\n" + g1Point + "\n" + g2Point + "\n"))
+	f.Add([]byte("1 1 " + g2Point + " " + g1Point))
+	f.Add([]byte("4096 65"))
+	f.Add([]byte("4096 65 00"))
+	f.Add([]byte("-1 65"))
+	f.Add([]byte{})
+}
+
+func FuzzParseTrustedSetup(f *testing.F) {
+	addTrustedSetupFileSeeds(f)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		g1Bytes, g2Bytes, err := ParseTrustedSetup(data)
+		if err != nil {
+			require.Equal(t, ErrBadArgs, err)
+			return
+		}
+		require.Zero(t, len(g1Bytes)%bytesPerG1)
+		require.Zero(t, len(g2Bytes)%bytesPerG2)
+
+		// The points must survive a round trip through the text format.
+		var text strings.Builder
+		text.WriteString(strconv.Itoa(len(g1Bytes)/bytesPerG1) + " " + strconv.Itoa(len(g2Bytes)/bytesPerG2) + "\n")
+		for _, chunk := range splitFuzzInput(g1Bytes, bytesPerG1) {
+			text.WriteString(hex.EncodeToString(chunk) + "\n")
+		}
+		for _, chunk := range splitFuzzInput(g2Bytes, bytesPerG2) {
+			text.WriteString(hex.EncodeToString(chunk) + "\n")
+		}
+		reparsedG1, reparsedG2, err := ParseTrustedSetup([]byte(text.String()))
+		require.NoError(t, err)
+		require.Equal(t, g1Bytes, reparsedG1)
+		require.Equal(t, g2Bytes, reparsedG2)
+	})
+}
+
+func FuzzLoadTrustedSetupFile(f *testing.F) {
+	addTrustedSetupFileSeeds(f)
+	freeTrustedSetupForFuzzing(f)
+	path := filepath.Join(f.TempDir(), "trusted_setup.txt")
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		require.NoError(t, os.WriteFile(path, data, 0o644))
+		var loadedG1, loadedG2 []byte
+		if err := LoadTrustedSetupFile(path); err == nil {
+			loadedG1, loadedG2 = TrustedSetupBytes()
+			FreeTrustedSetup()
+		} else {
+			require.ErrorIs(t, err, ErrBadArgs)
+		}
+
+		// The C parser is more lenient than the Go one, so it must accept
+		// every setup the Go parser does, with the same points.
+		g1Bytes, g2Bytes, err := ParseTrustedSetup(data)
+		if err != nil || LoadTrustedSetup(g1Bytes, g2Bytes) != nil {
+			return
+		}
+		FreeTrustedSetup()
+		require.Equal(t, g1Bytes, loadedG1, "only the Go parser accepted the setup")
+		require.Equal(t, g2Bytes, loadedG2)
+	})
+}
+
+func FuzzLoadTrustedSetup(f *testing.F) {
+	g1Bytes, g2Bytes := TrustedSetupBytes()
+	f.Add(g1Bytes, g2Bytes)
+	f.Add(g1Bytes[:bytesPerG1], g2Bytes[:bytesPerG2])
+	f.Add(g2Bytes[:2*bytesPerG2], g1Bytes[:2*bytesPerG1])
+	f.Add([]byte{}, []byte{})
+	freeTrustedSetupForFuzzing(f)
+
+	f.Fuzz(func(t *testing.T, g1Bytes, g2Bytes []byte) {
+		// Lengths that are not a multiple of the point sizes are a
+		// programming error, which LoadTrustedSetup panics on.
+		if len(g1Bytes)%bytesPerG1 != 0 || len(g2Bytes)%bytesPerG2 != 0 {
+			return
+		}
+		if err := LoadTrustedSetup(g1Bytes, g2Bytes); err != nil {
+			require.ErrorIs(t, err, ErrBadArgs)
+			return
+		}
+		loadedG1, loadedG2 := TrustedSetupBytes()
+		FreeTrustedSetup()
+		require.Equal(t, g1Bytes, loadedG1)
+		require.Equal(t, g2Bytes, loadedG2)
+	})
+}
+
+func FuzzLoadTrustedSetupJSON(f *testing.F) {
+	g1Bytes, g2Bytes := TrustedSetupBytes()
+	encode := func(points []byte, size int) []string {
+		var out []string
+		for _, chunk := range splitFuzzInput(points, size) {
+			out = append(out, "0x"+hex.EncodeToString(chunk))
+		}
+		return out
+	}
+	for _, keys := range [][2]string{{"g1_lagrange", "g2_monomial"}, {"g1_monomial", "g2_monomial"}} {
+		data, err := json.Marshal(map[string][]string{
+			keys[0]: encode(g1Bytes[:2*bytesPerG1], bytesPerG1),
+			keys[1]: encode(g2Bytes[:2*bytesPerG2], bytesPerG2),
+		})
+		require.NoError(f, err)
+		f.Add(data)
+	}
+	f.Add([]byte(`{"g1_lagrange": ["0x00"], "g2_monomial": []}`))
+	f.Add([]byte(`{"g1_monomial": [], "g2_monomial": null}`))
+	f.Add([]byte(`[]`))
+	freeTrustedSetupForFuzzing(f)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if err := LoadTrustedSetupJSON(data); err == nil {
+			FreeTrustedSetup()
+		}
+	})
+}
@@ -0,0 +1,54 @@
+package ckzg4844
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sync/atomic"
+)
+
+var panicGuard atomic.Bool
+
+/*
+SetPanicGuard enables or disables the panic guard. It is disabled by default.
+
+While it is enabled, a panic inside LoadTrustedSetup, LoadTrustedSetupFile,
+BlobToKZGCommitment, ComputeKZGProof, ComputeBlobKZGProof, VerifyKZGProof,
+VerifyBlobKZGProof or VerifyBlobKZGProofBatch is recovered and returned as a
+*PanicError, which wraps ErrInternal. This includes the panics for using the
+trusted setup before it is loaded or loading it twice. The other results of
+the call are zero values.
+*/
+func SetPanicGuard(enabled bool) {
+	panicGuard.Store(enabled)
+}
+
+// PanicError is returned, by functions covered by the panic guard, in place
+// of a panic.
+type PanicError struct {
+	// Value is the value passed to panic.
+	Value interface{}
+	// Stack is the stack trace of the goroutine at the time of the panic.
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("%v: panic: %v", ErrInternal, e.Value)
+}
+
+// Unwrap returns ErrInternal.
+func (e *PanicError) Unwrap() error {
+	return ErrInternal
+}
+
+// recoverPanic must be deferred directly. If the panic guard is enabled, it
+// recovers from a panic and sets *err to a *PanicError.
+func recoverPanic(err *error) {
+	if !panicGuard.Load() {
+		return
+	}
+	if r := recover(); r != nil {
+		panicErr := &PanicError{Value: r, Stack: debug.Stack()}
+		logf(LogLevelError, "%v\n%s", panicErr, panicErr.Stack)
+		*err = panicErr
+	}
+}
@@ -0,0 +1,60 @@
+package ckzg4844
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPanicGuard(t *testing.T) {
+	g1Bytes, g2Bytes := TrustedSetupBytes()
+	require.Panics(t, func() { _ = LoadTrustedSetup(g1Bytes, g2Bytes) })
+
+	SetPanicGuard(true)
+	defer SetPanicGuard(false)
+
+	err := LoadTrustedSetup(g1Bytes, g2Bytes)
+	require.ErrorIs(t, err, ErrInternal)
+	var panicErr *PanicError
+	require.True(t, errors.As(err, &panicErr))
+	require.Equal(t, "trusted setup is already loaded", panicErr.Value)
+	require.Contains(t, string(panicErr.Stack), "LoadTrustedSetup")
+	require.EqualError(t, err, "internal error: panic: trusted setup is already loaded")
+
+	// The setup is still usable, and calls that don't panic are unaffected.
+	var blob Blob
+	fillBlobRandom(&blob, 0)
+	commitment, err := BlobToKZGCommitment(&blob)
+	require.NoError(t, err)
+	_, err = ComputeBlobKZGProof(&blob, Bytes48(commitment))
+	require.NoError(t, err)
+
+	FreeTrustedSetup()
+	defer func() { require.NoError(t, LoadTrustedSetup(g1Bytes, g2Bytes)) }()
+	ok, err := VerifyBlobKZGProofBatch(nil, nil, nil)
+	require.ErrorIs(t, err, ErrInternal)
+	require.False(t, ok)
+}
+
+func TestPanicGuardWithHooks(t *testing.T) {
+	var after []HookEvent
+	SetHooks(Hooks{After: func(event HookEvent) { after = append(after, event) }})
+	defer SetHooks(Hooks{})
+	SetPanicGuard(true)
+	defer SetPanicGuard(false)
+
+	g1Bytes, g2Bytes := TrustedSetupBytes()
+	FreeTrustedSetup()
+	defer func() { require.NoError(t, LoadTrustedSetup(g1Bytes, g2Bytes)) }()
+	var blob Blob
+	_, err := BlobToKZGCommitment(&blob)
+	require.ErrorIs(t, err, ErrInternal)
+
+	// The After hook sees the error the panic was converted to.
+	require.Len(t, after, 1)
+	require.Equal(t, "blob_to_kzg_commitment", after[0].Operation)
+	var panicErr *PanicError
+	require.True(t, errors.As(after[0].Err, &panicErr))
+	require.Equal(t, err, after[0].Err)
+}
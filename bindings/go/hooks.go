@@ -0,0 +1,75 @@
+package ckzg4844
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// HookEvent describes a call into the C library, as passed to Hooks.
+type HookEvent struct {
+	// Operation is the name of the C function, e.g. "verify_kzg_proof".
+	Operation string
+	// Count is the number of blobs for batch operations and 1 otherwise.
+	Count int
+	// InputBytes is the total size of the serialized inputs. It is zero for
+	// load_trusted_setup_file, whose input is a file. It is an int64 so that
+	// large batches do not overflow it on 32-bit platforms.
+	InputBytes int64
+	// Duration is the time taken by the call. It is only set for After.
+	Duration time.Duration
+	// Valid is the result of verification operations, and false for the
+	// others. It is only set for After.
+	Valid bool
+	// Err is the error returned by the call. It is only set for After.
+	Err error
+}
+
+// Hooks are called around every call made by LoadTrustedSetup,
+// LoadTrustedSetupFile, BlobToKZGCommitment, ComputeKZGProof,
+// ComputeBlobKZGProof, VerifyKZGProof, VerifyBlobKZGProof and
+// VerifyBlobKZGProofBatch, including the calls made by other functions of
+// this package. Either function may be nil. They may be called from several
+// goroutines at once and should return quickly.
+type Hooks struct {
+	Before func(event HookEvent)
+	After  func(event HookEvent)
+}
+
+var hooks atomic.Pointer[Hooks]
+
+// SetHooks installs h, replacing any previous hooks. Passing the zero Hooks
+// removes them.
+func SetHooks(h Hooks) {
+	if h.Before == nil && h.After == nil {
+		hooks.Store(nil)
+		return
+	}
+	hooks.Store(&h)
+}
+
+// callHooks calls the Before hook and returns a function, to be deferred,
+// that calls the After hook with the outcome of the call. valid may be nil
+// for operations that don't verify anything. It must be deferred before
+// recoverPanic, so that it sees the error the panic guard sets.
+func callHooks(operation string, count int, inputBytes int64) func(valid *bool, err *error) {
+	h := hooks.Load()
+	if h == nil {
+		return func(*bool, *error) {}
+	}
+	event := HookEvent{Operation: operation, Count: count, InputBytes: inputBytes}
+	if h.Before != nil {
+		h.Before(event)
+	}
+	start := time.Now()
+	return func(valid *bool, err *error) {
+		if h.After == nil {
+			return
+		}
+		event.Duration = time.Since(start)
+		if valid != nil {
+			event.Valid = *valid
+		}
+		event.Err = *err
+		h.After(event)
+	}
+}
@@ -0,0 +1,58 @@
+package ckzg4844
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetHooks(t *testing.T) {
+	var before, after []HookEvent
+	SetHooks(Hooks{
+		Before: func(event HookEvent) { before = append(before, event) },
+		After:  func(event HookEvent) { after = append(after, event) },
+	})
+	defer SetHooks(Hooks{})
+
+	blobs, commitments, proofs, _ := getBundle(t, 2)
+	require.Len(t, before, 4)
+	require.Equal(t, HookEvent{Operation: "blob_to_kzg_commitment", Count: 1, InputBytes: BytesPerBlob}, before[0])
+	require.Equal(t, "compute_blob_kzg_proof", after[1].Operation)
+	require.NoError(t, after[1].Err)
+
+	before, after = nil, nil
+	ok, err := VerifyBlobKZGProofBatch(blobs, commitments, proofs)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Len(t, after, 1)
+	require.Equal(t, "verify_blob_kzg_proof_batch", after[0].Operation)
+	require.Equal(t, 2, after[0].Count)
+	require.Equal(t, int64(2*(BytesPerBlob+BytesPerCommitment+BytesPerProof)), after[0].InputBytes)
+	require.True(t, after[0].Valid)
+	require.Positive(t, after[0].Duration)
+
+	before, after = nil, nil
+	ok, err = VerifyBlobKZGProof(&blobs[0], commitments[0], Bytes48{})
+	require.ErrorIs(t, err, ErrBadArgs)
+	require.False(t, ok)
+	require.Len(t, before, 1)
+	require.ErrorIs(t, after[0].Err, ErrBadArgs)
+	require.False(t, after[0].Valid)
+
+	before, after = nil, nil
+	SetHooks(Hooks{})
+	_, err = VerifyBlobKZGProofBatch(blobs, commitments, proofs)
+	require.NoError(t, err)
+	require.Empty(t, before)
+	require.Empty(t, after)
+}
+
+func TestHooksLoadTrustedSetupFile(t *testing.T) {
+	FreeTrustedSetup()
+	var before []HookEvent
+	SetHooks(Hooks{Before: func(event HookEvent) { before = append(before, event) }})
+	defer SetHooks(Hooks{})
+
+	require.NoError(t, LoadTrustedSetupFile("../../src/trusted_setup.txt"))
+	require.Equal(t, []HookEvent{{Operation: "load_trusted_setup_file", Count: 1}}, before)
+}
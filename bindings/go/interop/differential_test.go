@@ -0,0 +1,230 @@
+//go:build differential
+
+// The differential tests run every operation through both this binding and
+// go-kzg-4844 on the same random inputs and fail on any disagreement. They
+// are slow, so they only build with the differential tag:
+//
+//	go test -tags differential ./interop -differential.iterations=100
+
+package interop
+
+import (
+	"encoding/hex"
+	"flag"
+	"math/rand"
+	"sync"
+	"testing"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	iterations = flag.Int("differential.iterations", 8, "number of random inputs per operation")
+	seed       = flag.Int64("differential.seed", 0, "seed of the first random input")
+)
+
+// g1Generator is the compressed generator of G1. go-kzg-4844 only reads the
+// first monomial point of the setup, which is always the generator.
+const g1Generator = "0x97f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb"
+
+var (
+	contextOnce sync.Once
+	goContext   *gokzg4844.Context
+	contextErr  error
+)
+
+// getContext loads the mainnet trusted setup into this binding and builds a
+// go-kzg-4844 context from the same points.
+func getContext(t *testing.T) *gokzg4844.Context {
+	contextOnce.Do(func() {
+		if contextErr = ckzg4844.LoadTrustedSetupFile("../../../src/trusted_setup.txt"); contextErr != nil {
+			return
+		}
+		g1Bytes, g2Bytes := ckzg4844.TrustedSetupBytes()
+		setup := gokzg4844.JSONTrustedSetup{}
+		setup.SetupG1[0] = g1Generator
+		for i := range setup.SetupG1Lagrange {
+			setup.SetupG1Lagrange[i] = "0x" + hex.EncodeToString(g1Bytes[i*48:(i+1)*48])
+		}
+		for i := 0; i < len(g2Bytes)/96; i++ {
+			setup.SetupG2 = append(setup.SetupG2, "0x"+hex.EncodeToString(g2Bytes[i*96:(i+1)*96]))
+		}
+		goContext, contextErr = gokzg4844.NewContext4096(&setup)
+	})
+	require.NoError(t, contextErr)
+	return goContext
+}
+
+// randomInput produces blobs, field elements and points that are valid most
+// of the time, and otherwise malformed in one of the ways the libraries must
+// both reject.
+type randomInput struct {
+	*rand.Rand
+}
+
+func newRandomInput(t *testing.T, i int) randomInput {
+	s := *seed + int64(i)
+	t.Logf("seed %v", s)
+	return randomInput{rand.New(rand.NewSource(s))}
+}
+
+func (r randomInput) fieldElement() ckzg4844.Bytes32 {
+	var fieldElement ckzg4844.Bytes32
+	r.Read(fieldElement[1:])
+	if r.Intn(16) == 0 {
+		fieldElement[0] = 0xff
+	}
+	return fieldElement
+}
+
+func (r randomInput) blob() *ckzg4844.Blob {
+	blob := new(ckzg4844.Blob)
+	for i := 0; i < ckzg4844.FieldElementsPerBlob; i++ {
+		r.Read(blob[i*ckzg4844.BytesPerFieldElement+1 : (i+1)*ckzg4844.BytesPerFieldElement])
+	}
+	if r.Intn(16) == 0 {
+		blob[r.Intn(ckzg4844.FieldElementsPerBlob)*ckzg4844.BytesPerFieldElement] = 0xff
+	}
+	return blob
+}
+
+// corrupt returns point, or, some of the time, a different point or bytes
+// that are not a point at all.
+func (r randomInput) corrupt(point ckzg4844.Bytes48, other ckzg4844.Bytes48) ckzg4844.Bytes48 {
+	switch r.Intn(8) {
+	case 0:
+		return other
+	case 1:
+		var garbage ckzg4844.Bytes48
+		r.Read(garbage[:])
+		return garbage
+	case 2:
+		return ckzg4844.Bytes48(gokzg4844.PointAtInfinity)
+	}
+	return point
+}
+
+// requireSameError fails unless both errors are nil or both are not.
+func requireSameError(t *testing.T, ckzgErr, gokzgErr error) {
+	if ckzgErr == nil {
+		require.NoError(t, gokzgErr, "only go-kzg-4844 failed")
+	} else {
+		require.Error(t, gokzgErr, "only c-kzg-4844 failed: %v", ckzgErr)
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Differential Tests
+///////////////////////////////////////////////////////////////////////////////
+
+func TestDifferentialBlobToKZGCommitment(t *testing.T) {
+	ctx := getContext(t)
+	for i := 0; i < *iterations; i++ {
+		blob := newRandomInput(t, i).blob()
+		commitment, err := ckzg4844.BlobToKZGCommitment(blob)
+		goCommitment, goErr := ctx.BlobToKZGCommitment(*BlobToGoKZG(blob), 1)
+		requireSameError(t, err, goErr)
+		if err == nil {
+			require.Equal(t, commitment, CommitmentFromGoKZG(goCommitment))
+		}
+	}
+}
+
+func TestDifferentialComputeKZGProof(t *testing.T) {
+	ctx := getContext(t)
+	for i := 0; i < *iterations; i++ {
+		r := newRandomInput(t, i)
+		blob, z := r.blob(), r.fieldElement()
+		proof, y, err := ckzg4844.ComputeKZGProof(blob, z)
+		goProof, goY, goErr := ctx.ComputeKZGProof(*BlobToGoKZG(blob), ScalarToGoKZG(z), 1)
+		requireSameError(t, err, goErr)
+		if err == nil {
+			require.Equal(t, proof, ProofFromGoKZG(goProof))
+			require.Equal(t, y, ScalarFromGoKZG(goY))
+		}
+	}
+}
+
+func TestDifferentialComputeBlobKZGProof(t *testing.T) {
+	ctx := getContext(t)
+	for i := 0; i < *iterations; i++ {
+		r := newRandomInput(t, i)
+		blob := r.blob()
+		commitment, _ := ckzg4844.BlobToKZGCommitment(blob)
+		commitmentBytes := r.corrupt(ckzg4844.Bytes48(commitment), ckzg4844.Bytes48{})
+		proof, err := ckzg4844.ComputeBlobKZGProof(blob, commitmentBytes)
+		goProof, goErr := ctx.ComputeBlobKZGProof(*BlobToGoKZG(blob), CommitmentToGoKZG(commitmentBytes), 1)
+		requireSameError(t, err, goErr)
+		if err == nil {
+			require.Equal(t, proof, ProofFromGoKZG(goProof))
+		}
+	}
+}
+
+func TestDifferentialVerifyKZGProof(t *testing.T) {
+	ctx := getContext(t)
+	for i := 0; i < *iterations; i++ {
+		r := newRandomInput(t, i)
+		blob, z := r.blob(), r.fieldElement()
+		commitment, _ := ckzg4844.BlobToKZGCommitment(blob)
+		proof, y, _ := ckzg4844.ComputeKZGProof(blob, z)
+		if r.Intn(4) == 0 {
+			y = r.fieldElement()
+		}
+		commitmentBytes := r.corrupt(ckzg4844.Bytes48(commitment), ckzg4844.Bytes48(proof))
+		proofBytes := r.corrupt(ckzg4844.Bytes48(proof), ckzg4844.Bytes48(commitment))
+
+		ok, err := ckzg4844.VerifyKZGProof(commitmentBytes, z, y, proofBytes)
+		goErr := ctx.VerifyKZGProof(CommitmentToGoKZG(commitmentBytes), ScalarToGoKZG(z), ScalarToGoKZG(y), ProofToGoKZG(proofBytes))
+		requireSameError(t, checkResult(ok, err), goErr)
+	}
+}
+
+func TestDifferentialVerifyBlobKZGProof(t *testing.T) {
+	ctx := getContext(t)
+	for i := 0; i < *iterations; i++ {
+		r := newRandomInput(t, i)
+		blob := r.blob()
+		commitment, _ := ckzg4844.BlobToKZGCommitment(blob)
+		proof, _ := ckzg4844.ComputeBlobKZGProof(blob, ckzg4844.Bytes48(commitment))
+		commitmentBytes := r.corrupt(ckzg4844.Bytes48(commitment), ckzg4844.Bytes48(proof))
+		proofBytes := r.corrupt(ckzg4844.Bytes48(proof), ckzg4844.Bytes48(commitment))
+
+		ok, err := ckzg4844.VerifyBlobKZGProof(blob, commitmentBytes, proofBytes)
+		goErr := ctx.VerifyBlobKZGProof(*BlobToGoKZG(blob), CommitmentToGoKZG(commitmentBytes), ProofToGoKZG(proofBytes))
+		requireSameError(t, checkResult(ok, err), goErr)
+	}
+}
+
+func TestDifferentialVerifyBlobKZGProofBatch(t *testing.T) {
+	ctx := getContext(t)
+	for i := 0; i < *iterations; i++ {
+		r := newRandomInput(t, i)
+		n := r.Intn(4)
+		blobs := make([]ckzg4844.Blob, n)
+		commitments := make([]ckzg4844.Bytes48, n)
+		proofs := make([]ckzg4844.Bytes48, n)
+		for j := range blobs {
+			blobs[j] = *r.blob()
+			commitment, _ := ckzg4844.BlobToKZGCommitment(&blobs[j])
+			proof, _ := ckzg4844.ComputeBlobKZGProof(&blobs[j], ckzg4844.Bytes48(commitment))
+			commitments[j] = r.corrupt(ckzg4844.Bytes48(commitment), ckzg4844.Bytes48(proof))
+			proofs[j] = r.corrupt(ckzg4844.Bytes48(proof), ckzg4844.Bytes48(commitment))
+		}
+
+		ok, err := ckzg4844.VerifyBlobKZGProofBatch(blobs, commitments, proofs)
+		goErr := ctx.VerifyBlobKZGProofBatch(BlobsToGoKZG(blobs), CommitmentsToGoKZG(commitments), ProofsToGoKZG(proofs))
+		requireSameError(t, checkResult(ok, err), goErr)
+	}
+}
+
+// checkResult folds the result of a verification into an error, as
+// go-kzg-4844 reports it.
+func checkResult(ok bool, err error) error {
+	if err == nil && !ok {
+		return ckzg4844.ErrInvalidProof
+	}
+	return err
+}
@@ -0,0 +1,103 @@
+// Package interop converts between the types of this package and those of
+// github.com/crate-crypto/go-kzg-4844, for clients that run both
+// implementations side by side. Both libraries use the same serialized
+// encodings, so every conversion is a lossless copy of the raw bytes.
+package interop
+
+import (
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// To go-kzg-4844
+///////////////////////////////////////////////////////////////////////////////
+
+// BlobToGoKZG returns the blob as a go-kzg-4844 blob. The result shares
+// memory with blob.
+func BlobToGoKZG(blob *ckzg4844.Blob) *gokzg4844.Blob {
+	return (*gokzg4844.Blob)(blob)
+}
+
+func BlobsToGoKZG(blobs []ckzg4844.Blob) []gokzg4844.Blob {
+	out := make([]gokzg4844.Blob, len(blobs))
+	for i := range blobs {
+		out[i] = gokzg4844.Blob(blobs[i])
+	}
+	return out
+}
+
+func CommitmentToGoKZG(commitment ckzg4844.Bytes48) gokzg4844.KZGCommitment {
+	return gokzg4844.KZGCommitment(commitment)
+}
+
+func CommitmentsToGoKZG(commitments []ckzg4844.Bytes48) []gokzg4844.KZGCommitment {
+	out := make([]gokzg4844.KZGCommitment, len(commitments))
+	for i := range commitments {
+		out[i] = CommitmentToGoKZG(commitments[i])
+	}
+	return out
+}
+
+func ProofToGoKZG(proof ckzg4844.Bytes48) gokzg4844.KZGProof {
+	return gokzg4844.KZGProof(proof)
+}
+
+func ProofsToGoKZG(proofs []ckzg4844.Bytes48) []gokzg4844.KZGProof {
+	out := make([]gokzg4844.KZGProof, len(proofs))
+	for i := range proofs {
+		out[i] = ProofToGoKZG(proofs[i])
+	}
+	return out
+}
+
+func ScalarToGoKZG(fieldElement ckzg4844.Bytes32) gokzg4844.Scalar {
+	return gokzg4844.Scalar(fieldElement)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// From go-kzg-4844
+///////////////////////////////////////////////////////////////////////////////
+
+// BlobFromGoKZG returns the go-kzg-4844 blob as a blob of this package. The
+// result shares memory with blob.
+func BlobFromGoKZG(blob *gokzg4844.Blob) *ckzg4844.Blob {
+	return (*ckzg4844.Blob)(blob)
+}
+
+func BlobsFromGoKZG(blobs []gokzg4844.Blob) []ckzg4844.Blob {
+	out := make([]ckzg4844.Blob, len(blobs))
+	for i := range blobs {
+		out[i] = ckzg4844.Blob(blobs[i])
+	}
+	return out
+}
+
+func CommitmentFromGoKZG(commitment gokzg4844.KZGCommitment) ckzg4844.KZGCommitment {
+	return ckzg4844.KZGCommitment(commitment)
+}
+
+func CommitmentsFromGoKZG(commitments []gokzg4844.KZGCommitment) []ckzg4844.Bytes48 {
+	out := make([]ckzg4844.Bytes48, len(commitments))
+	for i := range commitments {
+		out[i] = ckzg4844.Bytes48(commitments[i])
+	}
+	return out
+}
+
+func ProofFromGoKZG(proof gokzg4844.KZGProof) ckzg4844.KZGProof {
+	return ckzg4844.KZGProof(proof)
+}
+
+func ProofsFromGoKZG(proofs []gokzg4844.KZGProof) []ckzg4844.Bytes48 {
+	out := make([]ckzg4844.Bytes48, len(proofs))
+	for i := range proofs {
+		out[i] = ckzg4844.Bytes48(proofs[i])
+	}
+	return out
+}
+
+func ScalarFromGoKZG(scalar gokzg4844.Scalar) ckzg4844.Bytes32 {
+	return ckzg4844.Bytes32(scalar)
+}
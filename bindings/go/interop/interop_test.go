@@ -0,0 +1,38 @@
+package interop
+
+import (
+	"math/rand"
+	"testing"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	blobs := make([]ckzg4844.Blob, 2)
+	commitments := make([]ckzg4844.Bytes48, 2)
+	proofs := make([]ckzg4844.Bytes48, 2)
+	for i := range blobs {
+		r.Read(blobs[i][:])
+		r.Read(commitments[i][:])
+		r.Read(proofs[i][:])
+	}
+	var z ckzg4844.Bytes32
+	r.Read(z[:])
+
+	require.Equal(t, blobs, BlobsFromGoKZG(BlobsToGoKZG(blobs)))
+	require.Equal(t, commitments, CommitmentsFromGoKZG(CommitmentsToGoKZG(commitments)))
+	require.Equal(t, proofs, ProofsFromGoKZG(ProofsToGoKZG(proofs)))
+	require.Equal(t, ckzg4844.KZGCommitment(commitments[0]), CommitmentFromGoKZG(CommitmentToGoKZG(commitments[0])))
+	require.Equal(t, ckzg4844.KZGProof(proofs[0]), ProofFromGoKZG(ProofToGoKZG(proofs[0])))
+	require.Equal(t, z, ScalarFromGoKZG(ScalarToGoKZG(z)))
+}
+
+func TestBlobSharesMemory(t *testing.T) {
+	blob := new(ckzg4844.Blob)
+	goBlob := BlobToGoKZG(blob)
+	goBlob[0] = 1
+	require.Equal(t, byte(1), blob[0])
+	require.Same(t, blob, BlobFromGoKZG(goBlob))
+}
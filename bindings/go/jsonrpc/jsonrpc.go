@@ -0,0 +1,287 @@
+// Package jsonrpc implements a JSON-RPC 2.0 server for the operations of
+// ckzg4844.Backend, so that any JSON-RPC client, such as those of Ethereum
+// tooling, can call them over HTTP.
+//
+// As in the Ethereum JSON-RPC API, methods are named <namespace>_<method>,
+// parameters are positional, and byte strings are 0x-prefixed hex. The
+// methods are:
+//
+//	kzg_blobToKZGCommitment(blob) -> commitment
+//	kzg_computeKZGProof(blob, z) -> [proof, y]
+//	kzg_computeBlobKZGProof(blob, commitment) -> proof
+//	kzg_verifyKZGProof(commitment, z, y, proof) -> bool
+//	kzg_verifyBlobKZGProof(blob, commitment, proof) -> bool
+//	kzg_verifyBlobKZGProofBatch([blobs], [commitments], [proofs]) -> bool
+//
+// Batches of calls and notifications are supported.
+package jsonrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+	"github.com/ethereum/c-kzg-4844/bindings/go/limit"
+)
+
+// The error codes defined by the JSON-RPC 2.0 specification.
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+)
+
+// DefaultMaxRequestSize is the default limit on the size of request bodies,
+// which fits a batch of 64 blobs.
+const DefaultMaxRequestSize = 64 * 2 * ckzg4844.BytesPerBlob * 11 / 10
+
+// Server serves the operations of a backend over JSON-RPC. It implements
+// http.Handler, accepting calls in POST requests.
+type Server struct {
+	// Backend runs the operations. Its trusted setup must be loaded. If it
+	// is a *limit.Limiter, the calls of a request give up when the request
+	// is canceled.
+	Backend ckzg4844.Backend
+	// MaxRequestSize is the limit on the size of request bodies. If zero,
+	// DefaultMaxRequestSize is used.
+	MaxRequestSize int64
+}
+
+// NewServer returns a server for backend.
+func NewServer(backend ckzg4844.Backend) *Server {
+	return &Server{Backend: backend}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Wire Format
+///////////////////////////////////////////////////////////////////////////////
+
+type request struct {
+	Version string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+type response struct {
+	Version string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// Error is a JSON-RPC error object.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// nullID is the id of responses to requests whose id could not be read.
+var nullID = json.RawMessage("null")
+
+///////////////////////////////////////////////////////////////////////////////
+// Methods
+///////////////////////////////////////////////////////////////////////////////
+
+// method decodes the parameters of a call and runs it against a backend.
+type method func(backend ckzg4844.Backend, params []json.RawMessage) (interface{}, error)
+
+var methods = map[string]method{
+	"kzg_blobToKZGCommitment": func(backend ckzg4844.Backend, params []json.RawMessage) (interface{}, error) {
+		blob := new(ckzg4844.Blob)
+		if err := decodeParams(params, blob); err != nil {
+			return nil, err
+		}
+		return backend.BlobToKZGCommitment(blob)
+	},
+	"kzg_computeKZGProof": func(backend ckzg4844.Backend, params []json.RawMessage) (interface{}, error) {
+		blob := new(ckzg4844.Blob)
+		var z ckzg4844.Bytes32
+		if err := decodeParams(params, blob, &z); err != nil {
+			return nil, err
+		}
+		proof, y, err := backend.ComputeKZGProof(blob, z)
+		if err != nil {
+			return nil, err
+		}
+		return []interface{}{proof, y}, nil
+	},
+	"kzg_computeBlobKZGProof": func(backend ckzg4844.Backend, params []json.RawMessage) (interface{}, error) {
+		blob := new(ckzg4844.Blob)
+		var commitment ckzg4844.Bytes48
+		if err := decodeParams(params, blob, &commitment); err != nil {
+			return nil, err
+		}
+		return backend.ComputeBlobKZGProof(blob, commitment)
+	},
+	"kzg_verifyKZGProof": func(backend ckzg4844.Backend, params []json.RawMessage) (interface{}, error) {
+		var commitment, proof ckzg4844.Bytes48
+		var z, y ckzg4844.Bytes32
+		if err := decodeParams(params, &commitment, &z, &y, &proof); err != nil {
+			return nil, err
+		}
+		return backend.VerifyKZGProof(commitment, z, y, proof)
+	},
+	"kzg_verifyBlobKZGProof": func(backend ckzg4844.Backend, params []json.RawMessage) (interface{}, error) {
+		blob := new(ckzg4844.Blob)
+		var commitment, proof ckzg4844.Bytes48
+		if err := decodeParams(params, blob, &commitment, &proof); err != nil {
+			return nil, err
+		}
+		return backend.VerifyBlobKZGProof(blob, commitment, proof)
+	},
+	"kzg_verifyBlobKZGProofBatch": func(backend ckzg4844.Backend, params []json.RawMessage) (interface{}, error) {
+		var blobs []ckzg4844.Blob
+		var commitments, proofs []ckzg4844.Bytes48
+		if err := decodeParams(params, &blobs, &commitments, &proofs); err != nil {
+			return nil, err
+		}
+		return backend.VerifyBlobKZGProofBatch(blobs, commitments, proofs)
+	},
+}
+
+// decodeParams decodes the positional parameters into values, which must
+// all be given.
+func decodeParams(params []json.RawMessage, values ...interface{}) error {
+	if len(params) != len(values) {
+		return &Error{Code: CodeInvalidParams, Message: "wrong number of parameters"}
+	}
+	for i, param := range params {
+		if err := json.Unmarshal(param, values[i]); err != nil {
+			return &Error{Code: CodeInvalidParams, Message: "invalid parameter: " + err.Error()}
+		}
+	}
+	return nil
+}
+
+// toError converts an error of a method to an error object. Failures caused
+// by the parameters, such as points that are not on the curve, are invalid
+// parameters; the others are internal errors.
+func toError(err error) *Error {
+	var rpcErr *Error
+	if errors.As(err, &rpcErr) {
+		return rpcErr
+	}
+	if errors.Is(err, ckzg4844.ErrBadArgs) {
+		return &Error{Code: CodeInvalidParams, Message: err.Error()}
+	}
+	return &Error{Code: CodeInternalError, Message: err.Error()}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Server
+///////////////////////////////////////////////////////////////////////////////
+
+// ServeHTTP handles a single call or a batch of calls.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	maxRequestSize := s.MaxRequestSize
+	if maxRequestSize == 0 {
+		maxRequestSize = DefaultMaxRequestSize
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestSize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, "request too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "failed to read request", http.StatusBadRequest)
+		return
+	}
+
+	backend := s.Backend
+	if limiter, ok := backend.(*limit.Limiter); ok {
+		backend = limiter.WithContext(r.Context())
+	}
+	var result interface{}
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		var batch []json.RawMessage
+		if err := json.Unmarshal(body, &batch); err != nil {
+			result = errorResponse(nullID, &Error{Code: CodeParseError, Message: "parse error"})
+		} else if len(batch) == 0 {
+			result = errorResponse(nullID, &Error{Code: CodeInvalidRequest, Message: "empty batch"})
+		} else {
+			var responses []*response
+			for _, call := range batch {
+				if resp := s.call(backend, call); resp != nil {
+					responses = append(responses, resp)
+				}
+			}
+			if len(responses) > 0 {
+				result = responses
+			}
+		}
+	} else if resp := s.call(backend, body); resp != nil {
+		result = resp
+	}
+
+	// Notifications, and batches of them only, get no response.
+	if result == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(result)
+}
+
+// call runs a single call against backend. It returns nil for
+// notifications.
+func (s *Server) call(backend ckzg4844.Backend, data json.RawMessage) *response {
+	if !json.Valid(data) {
+		return errorResponse(nullID, &Error{Code: CodeParseError, Message: "parse error"})
+	}
+	var req request
+	if err := json.Unmarshal(data, &req); err != nil {
+		return errorResponse(nullID, &Error{Code: CodeInvalidRequest, Message: "invalid request"})
+	}
+	if req.Version != "2.0" || req.Method == "" {
+		id := req.ID
+		if id == nil {
+			id = nullID
+		}
+		return errorResponse(id, &Error{Code: CodeInvalidRequest, Message: "invalid request"})
+	}
+
+	result, err := run(backend, req)
+	if req.ID == nil {
+		return nil
+	}
+	if err != nil {
+		return errorResponse(req.ID, toError(err))
+	}
+	return &response{Version: "2.0", ID: req.ID, Result: result}
+}
+
+// run finds the method of a call and runs it against backend.
+func run(backend ckzg4844.Backend, req request) (interface{}, error) {
+	m, ok := methods[req.Method]
+	if !ok {
+		return nil, &Error{Code: CodeMethodNotFound, Message: "method not found: " + req.Method}
+	}
+	var params []json.RawMessage
+	if len(req.Params) > 0 && !bytes.Equal(req.Params, nullID) {
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, &Error{Code: CodeInvalidParams, Message: "parameters must be an array"}
+		}
+	}
+	return m(backend, params)
+}
+
+func errorResponse(id json.RawMessage, err *Error) *response {
+	return &response{Version: "2.0", ID: id, Error: err}
+}
@@ -0,0 +1,195 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+	"github.com/ethereum/c-kzg-4844/bindings/go/ckzgtest"
+	"github.com/ethereum/c-kzg-4844/bindings/go/limit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	if err := ckzg4844.LoadTrustedSetupFile("../../../src/trusted_setup.txt"); err != nil {
+		panic(fmt.Sprintf("failed to load trusted setup: %v", err))
+	}
+	defer ckzg4844.FreeTrustedSetup()
+	os.Exit(m.Run())
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Helper Functions
+///////////////////////////////////////////////////////////////////////////////
+
+// post sends body to the server and returns the status and response body.
+func post(t *testing.T, server *httptest.Server, body string) (int, string) {
+	t.Helper()
+	resp, err := http.Post(server.URL, "application/json", strings.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, buf.String()
+}
+
+type testResponse struct {
+	Version string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result"`
+	Error   *Error          `json:"error"`
+}
+
+// call calls method with params and returns the response.
+func call(t *testing.T, server *httptest.Server, method string, params ...interface{}) testResponse {
+	t.Helper()
+	body, err := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "method": method, "params": params})
+	require.NoError(t, err)
+	status, respBody := post(t, server, string(body))
+	require.Equal(t, http.StatusOK, status)
+	var resp testResponse
+	require.NoError(t, json.Unmarshal([]byte(respBody), &resp))
+	require.Equal(t, "2.0", resp.Version)
+	require.Equal(t, "1", string(resp.ID))
+	return resp
+}
+
+// requireResult requires a call to succeed with result.
+func requireResult(t *testing.T, resp testResponse, result interface{}) {
+	t.Helper()
+	require.Nil(t, resp.Error)
+	expected, err := json.Marshal(result)
+	require.NoError(t, err)
+	require.JSONEq(t, string(expected), string(resp.Result))
+}
+
+// requireError requires a call to fail with code.
+func requireError(t *testing.T, resp testResponse, code int) {
+	t.Helper()
+	require.NotNil(t, resp.Error)
+	require.Equal(t, code, resp.Error.Code, resp.Error.Message)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Tests
+///////////////////////////////////////////////////////////////////////////////
+
+func TestMethods(t *testing.T) {
+	server := httptest.NewServer(NewServer(ckzg4844.DefaultBackend))
+	defer server.Close()
+	blobs, commitments, proofs := ckzgtest.RandomBundle(1, 2)
+	blob := &blobs[0]
+	z := ckzgtest.RandomFieldElement(1)
+	commitment, err := ckzg4844.BlobToKZGCommitment(blob)
+	require.NoError(t, err)
+	proof, y, err := ckzg4844.ComputeKZGProof(blob, z)
+	require.NoError(t, err)
+	blobProof, err := ckzg4844.ComputeBlobKZGProof(blob, ckzg4844.Bytes48(commitment))
+	require.NoError(t, err)
+
+	requireResult(t, call(t, server, "kzg_blobToKZGCommitment", blob), commitment)
+	requireResult(t, call(t, server, "kzg_computeKZGProof", blob, z), []interface{}{proof, y})
+	requireResult(t, call(t, server, "kzg_computeBlobKZGProof", blob, commitment), blobProof)
+	requireResult(t, call(t, server, "kzg_verifyKZGProof", commitment, z, y, proof), true)
+	requireResult(t, call(t, server, "kzg_verifyKZGProof", commitment, z, z, proof), false)
+	requireResult(t, call(t, server, "kzg_verifyBlobKZGProof", blob, commitment, blobProof), true)
+	requireResult(t, call(t, server, "kzg_verifyBlobKZGProofBatch", blobs, commitments, proofs), true)
+	requireResult(t, call(t, server, "kzg_verifyBlobKZGProofBatch", blobs, commitments, []ckzg4844.Bytes48{proofs[1], proofs[0]}), false)
+}
+
+func TestErrors(t *testing.T) {
+	server := httptest.NewServer(NewServer(ckzg4844.DefaultBackend))
+	defer server.Close()
+	blob := ckzgtest.RandomBlob(1)
+
+	requireError(t, call(t, server, "kzg_unknown"), CodeMethodNotFound)
+	requireError(t, call(t, server, "kzg_blobToKZGCommitment"), CodeInvalidParams)
+	requireError(t, call(t, server, "kzg_blobToKZGCommitment", "0x00"), CodeInvalidParams)
+	requireError(t, call(t, server, "kzg_computeBlobKZGProof", blob, ckzgtest.InvalidPoint()), CodeInvalidParams)
+	requireError(t, call(t, server, "kzg_verifyBlobKZGProofBatch", []*ckzg4844.Blob{blob}, []ckzg4844.Bytes48{}, []ckzg4844.Bytes48{}), CodeInvalidParams)
+
+	for body, code := range map[string]int{
+		`{`:  CodeParseError,
+		`[`:  CodeParseError,
+		`[]`: CodeInvalidRequest,
+		`1`:  CodeInvalidRequest,
+		`{"id":1,"method":"kzg_blobToKZGCommitment"}`:                        CodeInvalidRequest,
+		`{"jsonrpc":"2.0","id":1}`:                                           CodeInvalidRequest,
+		`{"jsonrpc":"2.0","id":1,"method":"kzg_unknown"}`:                    CodeMethodNotFound,
+		`{"jsonrpc":"2.0","id":1,"method":"kzg_verifyKZGProof","params":{}}`: CodeInvalidParams,
+	} {
+		status, respBody := post(t, server, body)
+		require.Equal(t, http.StatusOK, status)
+		var resp testResponse
+		require.NoError(t, json.Unmarshal([]byte(respBody), &resp), body)
+		requireError(t, resp, code)
+	}
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+
+	limited := httptest.NewServer(&Server{Backend: ckzg4844.DefaultBackend, MaxRequestSize: 16})
+	defer limited.Close()
+	status, _ := post(t, limited, `{"jsonrpc":"2.0","id":1,"method":"kzg_unknown"}`)
+	require.Equal(t, http.StatusRequestEntityTooLarge, status)
+}
+
+func TestBatch(t *testing.T) {
+	server := httptest.NewServer(NewServer(ckzg4844.DefaultBackend))
+	defer server.Close()
+
+	status, body := post(t, server, `[
+		{"jsonrpc":"2.0","id":"a","method":"kzg_unknown"},
+		{"jsonrpc":"2.0","method":"kzg_unknown"},
+		1,
+		{"jsonrpc":"2.0","id":2,"method":"kzg_verifyKZGProof","params":["0xc0`+strings.Repeat("00", 47)+`","0x`+strings.Repeat("00", 32)+`","0x`+strings.Repeat("00", 32)+`","0xc0`+strings.Repeat("00", 47)+`"]}
+	]`)
+	require.Equal(t, http.StatusOK, status)
+	var responses []testResponse
+	require.NoError(t, json.Unmarshal([]byte(body), &responses))
+	require.Len(t, responses, 3)
+	require.Equal(t, `"a"`, string(responses[0].ID))
+	requireError(t, responses[0], CodeMethodNotFound)
+	require.Equal(t, "null", string(responses[1].ID))
+	requireError(t, responses[1], CodeInvalidRequest)
+	require.Equal(t, "2", string(responses[2].ID))
+	require.Nil(t, responses[2].Error)
+	require.Equal(t, "true", string(responses[2].Result))
+
+	// Notifications get no response.
+	status, body = post(t, server, `[{"jsonrpc":"2.0","method":"kzg_unknown"}]`)
+	require.Equal(t, http.StatusOK, status)
+	require.Empty(t, body)
+	status, body = post(t, server, `{"jsonrpc":"2.0","method":"kzg_unknown"}`)
+	require.Equal(t, http.StatusOK, status)
+	require.Empty(t, body)
+}
+
+func TestCanceledRequest(t *testing.T) {
+	limiter := limit.New(ckzg4844.DefaultBackend, limit.Config{MaxConcurrent: 1})
+	require.NoError(t, limiter.Acquire(context.Background()))
+	defer limiter.Release()
+
+	// The call waits for the slot held above until the request is canceled.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	point, field := "0xc0"+strings.Repeat("00", 47), "0x"+strings.Repeat("00", 32)
+	body := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "kzg_verifyKZGProof", "params": [%q, %q, %q, %q]}`, point, field, field, point)
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)).WithContext(ctx)
+	recorder := httptest.NewRecorder()
+	NewServer(limiter).ServeHTTP(recorder, req)
+	var resp testResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+	requireError(t, resp, CodeInternalError)
+	require.Contains(t, resp.Error.Message, context.Canceled.Error())
+}
@@ -0,0 +1,219 @@
+// Package limit bounds the load that callers can put on a ckzg4844.Backend,
+// so that a burst of verifications, such as during a gossip storm, cannot
+// take every CPU of a node.
+package limit
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync/atomic"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+)
+
+var ErrQueueFull = errors.New("too many calls waiting for the backend")
+
+// Config configures a Limiter. Zero fields take their default.
+type Config struct {
+	// MaxConcurrent is the number of calls into the backend run at once. The
+	// default is runtime.NumCPU().
+	MaxConcurrent int
+	// MaxQueued is the number of calls that may wait for one of those to
+	// return. Calls beyond it fail right away with ErrQueueFull. The default
+	// is 4 * MaxConcurrent.
+	MaxQueued int
+}
+
+/*
+Limiter is a Backend running at most MaxConcurrent calls of another backend
+at once, and letting at most MaxQueued more wait. It is safe for concurrent
+use.
+
+The Backend methods wait as long as needed; the methods with a Context
+suffix give up when their context is done, returning its error.
+*/
+type Limiter struct {
+	backend   ckzg4844.Backend
+	maxQueued int64
+	slots     chan struct{}
+	waiting   atomic.Int64
+}
+
+var _ ckzg4844.Backend = (*Limiter)(nil)
+
+// New returns a Limiter for backend.
+func New(backend ckzg4844.Backend, config Config) *Limiter {
+	if config.MaxConcurrent <= 0 {
+		config.MaxConcurrent = runtime.NumCPU()
+	}
+	if config.MaxQueued <= 0 {
+		config.MaxQueued = 4 * config.MaxConcurrent
+	}
+	return &Limiter{
+		backend:   backend,
+		maxQueued: int64(config.MaxQueued),
+		slots:     make(chan struct{}, config.MaxConcurrent),
+	}
+}
+
+// InFlight returns the number of calls running in the backend.
+func (l *Limiter) InFlight() int {
+	return len(l.slots)
+}
+
+// Waiting returns the number of calls waiting to run.
+func (l *Limiter) Waiting() int {
+	return int(l.waiting.Load())
+}
+
+/*
+Acquire waits for a slot to call the backend, unless too many calls are
+already waiting or ctx is done first. Calls to Release must match those to
+Acquire that return nil. They let work other than calls of the Limiter, such
+as calls into the backend made directly or other CPU-bound operations, count
+towards the same limit.
+*/
+func (l *Limiter) Acquire(ctx context.Context) error {
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	default:
+	}
+	if l.waiting.Add(1) > l.maxQueued {
+		l.waiting.Add(-1)
+		return ErrQueueFull
+	}
+	defer l.waiting.Add(-1)
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Release frees the slot taken by Acquire.
+func (l *Limiter) Release() {
+	<-l.slots
+}
+
+// WithContext returns a Backend whose methods are the Context methods of l,
+// called with ctx, such as to bound the calls made for an HTTP request by its
+// context.
+func (l *Limiter) WithContext(ctx context.Context) ckzg4844.Backend {
+	return contextBackend{limiter: l, ctx: ctx}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Context Functions
+///////////////////////////////////////////////////////////////////////////////
+
+func (l *Limiter) BlobToKZGCommitmentContext(ctx context.Context, blob *ckzg4844.Blob) (ckzg4844.KZGCommitment, error) {
+	if err := l.Acquire(ctx); err != nil {
+		return ckzg4844.KZGCommitment{}, err
+	}
+	defer l.Release()
+	return l.backend.BlobToKZGCommitment(blob)
+}
+
+func (l *Limiter) ComputeKZGProofContext(ctx context.Context, blob *ckzg4844.Blob, zBytes ckzg4844.Bytes32) (ckzg4844.KZGProof, ckzg4844.Bytes32, error) {
+	if err := l.Acquire(ctx); err != nil {
+		return ckzg4844.KZGProof{}, ckzg4844.Bytes32{}, err
+	}
+	defer l.Release()
+	return l.backend.ComputeKZGProof(blob, zBytes)
+}
+
+func (l *Limiter) ComputeBlobKZGProofContext(ctx context.Context, blob *ckzg4844.Blob, commitmentBytes ckzg4844.Bytes48) (ckzg4844.KZGProof, error) {
+	if err := l.Acquire(ctx); err != nil {
+		return ckzg4844.KZGProof{}, err
+	}
+	defer l.Release()
+	return l.backend.ComputeBlobKZGProof(blob, commitmentBytes)
+}
+
+func (l *Limiter) VerifyKZGProofContext(ctx context.Context, commitmentBytes ckzg4844.Bytes48, zBytes, yBytes ckzg4844.Bytes32, proofBytes ckzg4844.Bytes48) (bool, error) {
+	if err := l.Acquire(ctx); err != nil {
+		return false, err
+	}
+	defer l.Release()
+	return l.backend.VerifyKZGProof(commitmentBytes, zBytes, yBytes, proofBytes)
+}
+
+func (l *Limiter) VerifyBlobKZGProofContext(ctx context.Context, blob *ckzg4844.Blob, commitmentBytes, proofBytes ckzg4844.Bytes48) (bool, error) {
+	if err := l.Acquire(ctx); err != nil {
+		return false, err
+	}
+	defer l.Release()
+	return l.backend.VerifyBlobKZGProof(blob, commitmentBytes, proofBytes)
+}
+
+func (l *Limiter) VerifyBlobKZGProofBatchContext(ctx context.Context, blobs []ckzg4844.Blob, commitmentsBytes, proofsBytes []ckzg4844.Bytes48) (bool, error) {
+	if err := l.Acquire(ctx); err != nil {
+		return false, err
+	}
+	defer l.Release()
+	return l.backend.VerifyBlobKZGProofBatch(blobs, commitmentsBytes, proofsBytes)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Backend Functions
+///////////////////////////////////////////////////////////////////////////////
+
+func (l *Limiter) BlobToKZGCommitment(blob *ckzg4844.Blob) (ckzg4844.KZGCommitment, error) {
+	return l.BlobToKZGCommitmentContext(context.Background(), blob)
+}
+
+func (l *Limiter) ComputeKZGProof(blob *ckzg4844.Blob, zBytes ckzg4844.Bytes32) (ckzg4844.KZGProof, ckzg4844.Bytes32, error) {
+	return l.ComputeKZGProofContext(context.Background(), blob, zBytes)
+}
+
+func (l *Limiter) ComputeBlobKZGProof(blob *ckzg4844.Blob, commitmentBytes ckzg4844.Bytes48) (ckzg4844.KZGProof, error) {
+	return l.ComputeBlobKZGProofContext(context.Background(), blob, commitmentBytes)
+}
+
+func (l *Limiter) VerifyKZGProof(commitmentBytes ckzg4844.Bytes48, zBytes, yBytes ckzg4844.Bytes32, proofBytes ckzg4844.Bytes48) (bool, error) {
+	return l.VerifyKZGProofContext(context.Background(), commitmentBytes, zBytes, yBytes, proofBytes)
+}
+
+func (l *Limiter) VerifyBlobKZGProof(blob *ckzg4844.Blob, commitmentBytes, proofBytes ckzg4844.Bytes48) (bool, error) {
+	return l.VerifyBlobKZGProofContext(context.Background(), blob, commitmentBytes, proofBytes)
+}
+
+func (l *Limiter) VerifyBlobKZGProofBatch(blobs []ckzg4844.Blob, commitmentsBytes, proofsBytes []ckzg4844.Bytes48) (bool, error) {
+	return l.VerifyBlobKZGProofBatchContext(context.Background(), blobs, commitmentsBytes, proofsBytes)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Context Backend
+///////////////////////////////////////////////////////////////////////////////
+
+type contextBackend struct {
+	limiter *Limiter
+	ctx     context.Context
+}
+
+func (b contextBackend) BlobToKZGCommitment(blob *ckzg4844.Blob) (ckzg4844.KZGCommitment, error) {
+	return b.limiter.BlobToKZGCommitmentContext(b.ctx, blob)
+}
+
+func (b contextBackend) ComputeKZGProof(blob *ckzg4844.Blob, zBytes ckzg4844.Bytes32) (ckzg4844.KZGProof, ckzg4844.Bytes32, error) {
+	return b.limiter.ComputeKZGProofContext(b.ctx, blob, zBytes)
+}
+
+func (b contextBackend) ComputeBlobKZGProof(blob *ckzg4844.Blob, commitmentBytes ckzg4844.Bytes48) (ckzg4844.KZGProof, error) {
+	return b.limiter.ComputeBlobKZGProofContext(b.ctx, blob, commitmentBytes)
+}
+
+func (b contextBackend) VerifyKZGProof(commitmentBytes ckzg4844.Bytes48, zBytes, yBytes ckzg4844.Bytes32, proofBytes ckzg4844.Bytes48) (bool, error) {
+	return b.limiter.VerifyKZGProofContext(b.ctx, commitmentBytes, zBytes, yBytes, proofBytes)
+}
+
+func (b contextBackend) VerifyBlobKZGProof(blob *ckzg4844.Blob, commitmentBytes, proofBytes ckzg4844.Bytes48) (bool, error) {
+	return b.limiter.VerifyBlobKZGProofContext(b.ctx, blob, commitmentBytes, proofBytes)
+}
+
+func (b contextBackend) VerifyBlobKZGProofBatch(blobs []ckzg4844.Blob, commitmentsBytes, proofsBytes []ckzg4844.Bytes48) (bool, error) {
+	return b.limiter.VerifyBlobKZGProofBatchContext(b.ctx, blobs, commitmentsBytes, proofsBytes)
+}
@@ -0,0 +1,90 @@
+package limit
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+	"github.com/ethereum/c-kzg-4844/bindings/go/conformance"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	if err := ckzg4844.LoadTrustedSetupFile("../../../src/trusted_setup.txt"); err != nil {
+		panic(fmt.Sprintf("failed to load trusted setup: %v", err))
+	}
+	defer ckzg4844.FreeTrustedSetup()
+	os.Exit(m.Run())
+}
+
+// gatedBackend holds every verification until gate is closed.
+type gatedBackend struct {
+	ckzg4844.Backend
+	started chan struct{}
+	gate    chan struct{}
+}
+
+func (b *gatedBackend) VerifyKZGProof(commitmentBytes ckzg4844.Bytes48, zBytes, yBytes ckzg4844.Bytes32, proofBytes ckzg4844.Bytes48) (bool, error) {
+	b.started <- struct{}{}
+	<-b.gate
+	return true, nil
+}
+
+func TestConformance(t *testing.T) {
+	conformance.Run(t, "../../../tests", New(ckzg4844.DefaultBackend, Config{MaxConcurrent: 2}))
+}
+
+func TestLimits(t *testing.T) {
+	backend := &gatedBackend{Backend: ckzg4844.DefaultBackend, started: make(chan struct{}, 4), gate: make(chan struct{})}
+	l := New(backend, Config{MaxConcurrent: 1, MaxQueued: 2})
+	verify := func(ctx context.Context) chan error {
+		errs := make(chan error, 1)
+		go func() {
+			_, err := l.VerifyKZGProofContext(ctx, ckzg4844.Bytes48{}, ckzg4844.Bytes32{}, ckzg4844.Bytes32{}, ckzg4844.Bytes48{})
+			errs <- err
+		}()
+		return errs
+	}
+	waitFor := func(waiting int) {
+		require.Eventually(t, func() bool { return l.Waiting() == waiting }, time.Minute, time.Millisecond)
+	}
+
+	// One call runs, and two wait, one of which gives up.
+	running := verify(context.Background())
+	<-backend.started
+	require.Equal(t, 1, l.InFlight())
+	waiter := verify(context.Background())
+	waitFor(1)
+	ctx, cancel := context.WithCancel(context.Background())
+	canceled := verify(ctx)
+	waitFor(2)
+
+	_, err := l.VerifyKZGProof(ckzg4844.Bytes48{}, ckzg4844.Bytes32{}, ckzg4844.Bytes32{}, ckzg4844.Bytes48{})
+	require.ErrorIs(t, err, ErrQueueFull)
+	cancel()
+	require.ErrorIs(t, <-canceled, context.Canceled)
+	waitFor(1)
+
+	close(backend.gate)
+	require.NoError(t, <-running)
+	require.NoError(t, <-waiter)
+	require.Equal(t, 0, l.InFlight())
+	require.Equal(t, 0, l.Waiting())
+}
+
+func TestWithContext(t *testing.T) {
+	l := New(ckzg4844.DefaultBackend, Config{MaxConcurrent: 1})
+	conformance.Run(t, "../../../tests", l.WithContext(context.Background()))
+
+	// A call waiting for the slot held by Acquire gives up with its context.
+	require.NoError(t, l.Acquire(context.Background()))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := l.WithContext(ctx).VerifyKZGProof(ckzg4844.Bytes48{}, ckzg4844.Bytes32{}, ckzg4844.Bytes32{}, ckzg4844.Bytes48{})
+	require.ErrorIs(t, err, context.Canceled)
+	l.Release()
+	require.Equal(t, 0, l.InFlight())
+}
@@ -0,0 +1,41 @@
+package ckzg4844
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// Log levels passed to the function installed with SetLogger.
+const (
+	LogLevelDebug = "debug"
+	LogLevelError = "error"
+)
+
+var logger atomic.Pointer[func(level, msg string)]
+
+/*
+SetLogger installs fn to receive diagnostic messages, or removes the current
+logger if fn is nil. No logger is installed by default, in which case logging
+costs nothing but a nil check.
+
+The bindings log every failing call into the C library, with the error it
+returned, and the steps taken to isolate failing entries of a batch. Built
+with the ckzg_debug_log tag, the C library also logs why it rejected a
+trusted setup and which entry of a batch it could not verify, in messages
+prefixed with "c-kzg-4844: ". fn may be called from several goroutines at
+once.
+*/
+func SetLogger(fn func(level, msg string)) {
+	if fn == nil {
+		logger.Store(nil)
+		return
+	}
+	logger.Store(&fn)
+}
+
+// logf formats a message and passes it to the logger, if one is installed.
+func logf(level, format string, args ...interface{}) {
+	if fn := logger.Load(); fn != nil {
+		(*fn)(level, fmt.Sprintf(format, args...))
+	}
+}
@@ -0,0 +1,45 @@
+package ckzg4844
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetLogger(t *testing.T) {
+	var messages []string
+	SetLogger(func(level, msg string) {
+		// Messages from the C library, which only logs with the
+		// ckzg_debug_log build tag, are tested in debug_log_test.go.
+		if !strings.HasPrefix(msg, "c-kzg-4844: ") {
+			messages = append(messages, level+": "+msg)
+		}
+	})
+	defer SetLogger(nil)
+
+	blobs, commitments, proofs, _ := getBundle(t, 2)
+	proofs[1] = proofs[0]
+	failed, err := FindInvalidBlobKZGProofs(blobs, commitments, proofs, FullReport)
+	require.NoError(t, err)
+	require.Equal(t, []int{1}, failed)
+	require.Equal(t, []string{
+		"debug: batch entries [0, 2) failed, splitting at 1",
+		"debug: batch entry 1 failed",
+	}, messages)
+
+	messages = nil
+	var blob Blob
+	blob[0] = 0xff
+	_, err = BlobToKZGCommitment(&blob)
+	require.ErrorIs(t, err, ErrBadArgs)
+	require.Equal(t, []string{
+		"debug: blob_to_kzg_commitment failed: bad arguments: blob field element 0 is not canonical",
+	}, messages)
+
+	messages = nil
+	SetLogger(nil)
+	_, err = BlobToKZGCommitment(&blob)
+	require.ErrorIs(t, err, ErrBadArgs)
+	require.Empty(t, messages)
+}
@@ -1,45 +1,72 @@
+//go:build cgo && !ckzg_nocgo
+
 package ckzg4844
 
 // #cgo CFLAGS: -I${SRCDIR}/../../src
-// #cgo CFLAGS: -I${SRCDIR}/blst_headers
 // #include "c_kzg_4844.c"
 import "C"
 
 import (
-	"bytes"
-	"encoding/hex"
-	"errors"
+	"crypto/rand"
 	"fmt"
+	"math/bits"
+	"sync"
 	"unsafe"
-
-	// So its functions are available during compilation.
-	_ "github.com/supranational/blst/bindings/go"
 )
 
-const (
-	BytesPerBlob         = C.BYTES_PER_BLOB
-	BytesPerCommitment   = C.BYTES_PER_COMMITMENT
-	BytesPerFieldElement = C.BYTES_PER_FIELD_ELEMENT
-	BytesPerProof        = C.BYTES_PER_PROOF
-	FieldElementsPerBlob = C.FIELD_ELEMENTS_PER_BLOB
+// The constants in types.go are literals, so that they are also defined
+// without cgo. These fail to compile if they differ from the C library's.
+var (
+	_ [BytesPerBlob - C.BYTES_PER_BLOB]struct{}
+	_ [C.BYTES_PER_BLOB - BytesPerBlob]struct{}
+	_ [BytesPerCommitment - C.BYTES_PER_COMMITMENT]struct{}
+	_ [C.BYTES_PER_COMMITMENT - BytesPerCommitment]struct{}
+	_ [BytesPerFieldElement - C.BYTES_PER_FIELD_ELEMENT]struct{}
+	_ [C.BYTES_PER_FIELD_ELEMENT - BytesPerFieldElement]struct{}
+	_ [BytesPerProof - C.BYTES_PER_PROOF]struct{}
+	_ [C.BYTES_PER_PROOF - BytesPerProof]struct{}
+	_ [FieldElementsPerBlob - C.FIELD_ELEMENTS_PER_BLOB]struct{}
+	_ [C.FIELD_ELEMENTS_PER_BLOB - FieldElementsPerBlob]struct{}
+	_ [bytesPerG1 - C.BYTES_PER_G1]struct{}
+	_ [C.BYTES_PER_G1 - bytesPerG1]struct{}
+	_ [bytesPerG2 - C.BYTES_PER_G2]struct{}
+	_ [C.BYTES_PER_G2 - bytesPerG2]struct{}
+	_ [trustedSetupNumG1Points - C.TRUSTED_SETUP_NUM_G1_POINTS]struct{}
+	_ [C.TRUSTED_SETUP_NUM_G1_POINTS - trustedSetupNumG1Points]struct{}
+	_ [trustedSetupNumG2Points - C.TRUSTED_SETUP_NUM_G2_POINTS]struct{}
+	_ [C.TRUSTED_SETUP_NUM_G2_POINTS - trustedSetupNumG2Points]struct{}
 )
 
-type (
-	Bytes32       [32]byte
-	Bytes48       [48]byte
-	KZGCommitment Bytes48
-	KZGProof      Bytes48
-	Blob          [BytesPerBlob]byte
+// The types above are passed to the C library by converting pointers, so they
+// must have the sizes of their C counterparts. These fail to compile on any
+// platform where they do not.
+var (
+	_ [unsafe.Sizeof(C.Bytes32{}) - unsafe.Sizeof(Bytes32{})]struct{}
+	_ [unsafe.Sizeof(Bytes32{}) - unsafe.Sizeof(C.Bytes32{})]struct{}
+	_ [unsafe.Sizeof(C.Bytes48{}) - unsafe.Sizeof(Bytes48{})]struct{}
+	_ [unsafe.Sizeof(Bytes48{}) - unsafe.Sizeof(C.Bytes48{})]struct{}
+	_ [unsafe.Sizeof(C.Blob{}) - unsafe.Sizeof(Blob{})]struct{}
+	_ [unsafe.Sizeof(Blob{}) - unsafe.Sizeof(C.Blob{})]struct{}
 )
 
+// setupMu guards loaded and settings: it is held for writing while the trusted
+// setup is loaded or freed, and for reading by every call that uses it, so
+// that the setup can be replaced while other goroutines use the library.
+var setupMu sync.RWMutex
+
 var (
-	loaded     = false
-	settings   = C.KZGSettings{}
-	ErrBadArgs = errors.New("bad arguments")
-	ErrError   = errors.New("unexpected error")
-	ErrMalloc  = errors.New("malloc failed")
+	loaded   = false
+	settings = C.KZGSettings{}
 )
 
+// isLoaded reports whether a trusted setup is loaded, for the functions that
+// check it before calling the functions that hold setupMu.
+func isLoaded() bool {
+	setupMu.RLock()
+	defer setupMu.RUnlock()
+	return loaded
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 // Helper Functions
 ///////////////////////////////////////////////////////////////////////////////
@@ -59,59 +86,110 @@ func makeErrorFromRet(ret C.C_KZG_RET) error {
 	return fmt.Errorf("unexpected error from c-library: %v", ret)
 }
 
-///////////////////////////////////////////////////////////////////////////////
-// Unmarshal Functions
-///////////////////////////////////////////////////////////////////////////////
-
-func (b *Bytes32) UnmarshalText(input []byte) error {
-	if bytes.HasPrefix(input, []byte("0x")) {
-		input = input[2:]
-	}
-	if len(input) != 2*len(b) {
-		return ErrBadArgs
-	}
-	l, err := hex.Decode(b[:], input)
-	if err != nil {
-		return err
-	}
-	if l != len(b) {
-		return ErrBadArgs
+// checkFieldElement returns an error wrapping ErrBadArgs if the named field
+// element is not canonical.
+func checkFieldElement(name string, fieldElementBytes Bytes32) error {
+	var fr C.fr_t
+	if C.bytes_to_bls_field(&fr, (*C.Bytes32)(unsafe.Pointer(&fieldElementBytes))) != C.C_KZG_OK {
+		return fmt.Errorf("%w: %v is not canonical", ErrBadArgs, name)
 	}
 	return nil
 }
 
-func (b *Bytes48) UnmarshalText(input []byte) error {
-	if bytes.HasPrefix(input, []byte("0x")) {
-		input = input[2:]
-	}
-	if len(input) != 2*len(b) {
-		return ErrBadArgs
-	}
-	l, err := hex.Decode(b[:], input)
-	if err != nil {
-		return err
-	}
-	if l != len(b) {
-		return ErrBadArgs
+// checkG1 returns an error wrapping ErrBadArgs if the named bytes are not a
+// valid compressed G1 point in the correct subgroup.
+func checkG1(name string, g1Bytes Bytes48) error {
+	var g1 C.g1_t
+	if C.validate_kzg_g1(&g1, (*C.Bytes48)(unsafe.Pointer(&g1Bytes))) != C.C_KZG_OK {
+		return fmt.Errorf("%w: %v is not a valid G1 point", ErrBadArgs, name)
 	}
 	return nil
 }
 
-func (b *Blob) UnmarshalText(input []byte) error {
-	if bytes.HasPrefix(input, []byte("0x")) {
-		input = input[2:]
-	}
-	if len(input) != 2*len(b) {
-		return ErrBadArgs
-	}
-	l, err := hex.Decode(b[:], input)
-	if err != nil {
-		return err
-	}
-	if l != len(b) {
-		return ErrBadArgs
-	}
-	return nil
+// convertG1 performs an FFT over compressed G1 points, in natural order. With
+// inverse set it converts monomial form to Lagrange form, otherwise Lagrange
+// form to monomial form. The number of points must be
+// TRUSTED_SETUP_NUM_G1_POINTS.
+func convertG1(g1Bytes []byte, inverse bool) ([]byte, error) {
+	n := C.TRUSTED_SETUP_NUM_G1_POINTS
+	if len(g1Bytes) != n*C.BYTES_PER_G1 {
+		return nil, ErrBadArgs
+	}
+	points := make([]C.g1_t, n)
+	for i := range points {
+		var affine C.blst_p1_affine
+		if C.blst_p1_uncompress(&affine, (*C.byte)(&g1Bytes[i*C.BYTES_PER_G1])) != C.BLST_SUCCESS {
+			return nil, ErrBadArgs
+		}
+		C.blst_p1_from_affine(&points[i], &affine)
+	}
+
+	// The roots of unity are computed in bit-reversed order.
+	logN := bits.TrailingZeros(uint(n))
+	roots := make([]C.fr_t, n)
+	if ret := C.compute_roots_of_unity(&roots[0], C.uint32_t(logN)); ret != C.C_KZG_OK {
+		return nil, makeErrorFromRet(ret)
+	}
+	root := func(k int) *C.fr_t {
+		if inverse {
+			k = (n - k) % n
+		}
+		return &roots[C.reverse_bits(C.uint32_t(k))>>(32-logN)]
+	}
+
+	if ret := C.bit_reversal_permutation(unsafe.Pointer(&points[0]), C.sizeof_g1_t, C.uint64_t(n)); ret != C.C_KZG_OK {
+		return nil, makeErrorFromRet(ret)
+	}
+	for size := 2; size <= n; size <<= 1 {
+		half, stride := size/2, n/size
+		for start := 0; start < n; start += size {
+			for j := 0; j < half; j++ {
+				t := points[start+j+half]
+				if j != 0 {
+					C.g1_mul(&t, &t, root(j*stride))
+				}
+				C.g1_sub(&points[start+j+half], &points[start+j], &t)
+				C.blst_p1_add_or_double(&points[start+j], &points[start+j], &t)
+			}
+		}
+	}
+	if inverse {
+		var scale C.fr_t
+		C.fr_from_uint64(&scale, C.uint64_t(n))
+		C.blst_fr_eucl_inverse(&scale, &scale)
+		for i := range points {
+			C.g1_mul(&points[i], &points[i], &scale)
+		}
+	}
+
+	out := make([]byte, len(g1Bytes))
+	for i := range points {
+		C.blst_p1_compress((*C.byte)(&out[i*C.BYTES_PER_G1]), &points[i])
+	}
+	return out, nil
+}
+
+// isMonomialForm reports whether the first two G1 and G2 points satisfy
+// e(g1[1], g2[0]) == e(g1[0], g2[1]), which holds for a setup in monomial form.
+func isMonomialForm(g1Bytes, g2Bytes []byte) bool {
+	if len(g1Bytes) < 2*C.BYTES_PER_G1 || len(g2Bytes) < 2*C.BYTES_PER_G2 {
+		return false
+	}
+	var g1 [2]C.g1_t
+	var g2 [2]C.g2_t
+	for i := 0; i < 2; i++ {
+		var g1Affine C.blst_p1_affine
+		if C.blst_p1_uncompress(&g1Affine, (*C.byte)(&g1Bytes[i*C.BYTES_PER_G1])) != C.BLST_SUCCESS {
+			return false
+		}
+		C.blst_p1_from_affine(&g1[i], &g1Affine)
+		var g2Affine C.blst_p2_affine
+		if C.blst_p2_uncompress(&g2Affine, (*C.byte)(&g2Bytes[i*C.BYTES_PER_G2])) != C.BLST_SUCCESS {
+			return false
+		}
+		C.blst_p2_from_affine(&g2[i], &g2Affine)
+	}
+	return bool(C.pairings_verify(&g1[1], &g2[0], &g1[0], &g2[1]))
 }
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -128,7 +206,11 @@ LoadTrustedSetup is the binding for:
 	    const uint8_t *g2_bytes,
 	    size_t n2);
 */
-func LoadTrustedSetup(g1Bytes, g2Bytes []byte) error {
+func LoadTrustedSetup(g1Bytes, g2Bytes []byte) (err error) {
+	defer callHooks("load_trusted_setup", 1, int64(len(g1Bytes))+int64(len(g2Bytes)))(nil, &err)
+	defer recoverPanic(&err)
+	setupMu.Lock()
+	defer setupMu.Unlock()
 	if loaded {
 		panic("trusted setup is already loaded")
 	}
@@ -160,15 +242,15 @@ LoadTrustedSetupFile is the binding for:
 	    KZGSettings *out,
 	    FILE *in);
 */
-func LoadTrustedSetupFile(trustedSetupFile string) error {
+func LoadTrustedSetupFile(trustedSetupFile string) (err error) {
+	defer callHooks("load_trusted_setup_file", 1, 0)(nil, &err)
+	defer recoverPanic(&err)
+	setupMu.Lock()
+	defer setupMu.Unlock()
 	if loaded {
 		panic("trusted setup is already loaded")
 	}
-	cTrustedSetupFile := C.CString(trustedSetupFile)
-	defer C.free(unsafe.Pointer(cTrustedSetupFile))
-	cMode := C.CString("r")
-	defer C.free(unsafe.Pointer(cMode))
-	fp := C.fopen(cTrustedSetupFile, cMode)
+	fp := fopen(trustedSetupFile)
 	if fp == nil {
 		panic("error reading trusted setup")
 	}
@@ -188,6 +270,8 @@ FreeTrustedSetup is the binding for:
 	    KZGSettings *s);
 */
 func FreeTrustedSetup() {
+	setupMu.Lock()
+	defer setupMu.Unlock()
 	if !loaded {
 		panic("trusted setup isn't loaded")
 	}
@@ -195,6 +279,226 @@ func FreeTrustedSetup() {
 	loaded = false
 }
 
+/*
+EstimateSetupMemory returns the peak number of bytes the C library allocates
+while loading a trusted setup with the given numbers of points and keeps
+until FreeTrustedSetup, counting the temporary roots of unity used during the
+load. It does not include the input buffers.
+*/
+func EstimateSetupMemory(numG1, numG2 int, opts SetupOptions) uint64 {
+	maxWidth := uint64(1)
+	for maxWidth < uint64(numG1) {
+		maxWidth <<= 1
+	}
+	persistent := maxWidth*C.sizeof_fr_t + uint64(numG1)*C.sizeof_g1_t + uint64(numG2)*C.sizeof_g2_t
+	temporary := (maxWidth + 1) * C.sizeof_fr_t
+	return persistent + temporary
+}
+
+/*
+TrustedSetupBytes returns the loaded trusted setup as the compressed G1 points
+in Lagrange form and G2 points in monomial form, in the layout accepted by
+LoadTrustedSetup. The bit-reversal applied during loading is undone.
+*/
+func TrustedSetupBytes() (g1Bytes, g2Bytes []byte) {
+	setupMu.RLock()
+	defer setupMu.RUnlock()
+	if !loaded {
+		panic("trusted setup isn't loaded")
+	}
+	g1Values := unsafe.Slice(settings.g1_values, C.TRUSTED_SETUP_NUM_G1_POINTS)
+	g2Values := unsafe.Slice(settings.g2_values, C.TRUSTED_SETUP_NUM_G2_POINTS)
+	unusedBits := 32 - bits.TrailingZeros32(C.TRUSTED_SETUP_NUM_G1_POINTS)
+
+	g1Bytes = make([]byte, len(g1Values)*C.BYTES_PER_G1)
+	for i := range g1Values {
+		j := C.reverse_bits(C.uint32_t(i)) >> unusedBits
+		C.blst_p1_compress((*C.byte)(&g1Bytes[i*C.BYTES_PER_G1]), &g1Values[j])
+	}
+	g2Bytes = make([]byte, len(g2Values)*C.BYTES_PER_G2)
+	for i := range g2Values {
+		C.blst_p2_compress((*C.byte)(&g2Bytes[i*C.BYTES_PER_G2]), &g2Values[i])
+	}
+	return g1Bytes, g2Bytes
+}
+
+/*
+GenerateInsecureSetup returns a trusted setup for the given secret, in the
+layout accepted by LoadTrustedSetup. Anyone who knows the secret can forge
+proofs, so this is only for tests and devnets. The size is the number of G1
+points, which must be FieldElementsPerBlob; other sizes return ErrBadArgs.
+*/
+func GenerateInsecureSetup(secret Bytes32, size int) (g1Bytes, g2Bytes []byte, err error) {
+	if size != C.TRUSTED_SETUP_NUM_G1_POINTS {
+		return nil, nil, ErrBadArgs
+	}
+	var s, one, sPow C.fr_t
+	C.hash_to_bls_field(&s, (*C.Bytes32)(unsafe.Pointer(&secret)))
+	C.fr_from_uint64(&one, 1)
+	C.fr_pow(&sPow, &s, C.uint64_t(size))
+	// The secret must not be zero or in the domain, where L_i(s) is 0 or 1.
+	if C.fr_is_zero(&s) || C.fr_is_one(&sPow) {
+		return nil, nil, ErrBadArgs
+	}
+
+	logN := bits.TrailingZeros(uint(size))
+	roots := make([]C.fr_t, size)
+	if ret := C.compute_roots_of_unity(&roots[0], C.uint32_t(logN)); ret != C.C_KZG_OK {
+		return nil, nil, makeErrorFromRet(ret)
+	}
+
+	// L_i(s) = w_i / n * (s^n - 1) / (s - w_i).
+	var scale C.fr_t
+	C.fr_from_uint64(&scale, C.uint64_t(size))
+	C.blst_fr_eucl_inverse(&scale, &scale)
+	C.blst_fr_sub(&sPow, &sPow, &one)
+	C.blst_fr_mul(&scale, &scale, &sPow)
+	differences := make([]C.fr_t, size)
+	inverses := make([]C.fr_t, size)
+	for k := range roots {
+		C.blst_fr_sub(&differences[k], &s, &roots[k])
+	}
+	if ret := C.fr_batch_inv(&inverses[0], &differences[0], C.int(size)); ret != C.C_KZG_OK {
+		return nil, nil, makeErrorFromRet(ret)
+	}
+
+	// The roots are in bit-reversed order, so point k goes to position rev(k).
+	g1Bytes = make([]byte, size*C.BYTES_PER_G1)
+	for k := range roots {
+		var l C.fr_t
+		var point C.g1_t
+		C.blst_fr_mul(&l, &inverses[k], &roots[k])
+		C.blst_fr_mul(&l, &l, &scale)
+		C.g1_mul(&point, C.blst_p1_generator(), &l)
+		i := C.reverse_bits(C.uint32_t(k)) >> (32 - logN)
+		C.blst_p1_compress((*C.byte)(&g1Bytes[int(i)*C.BYTES_PER_G1]), &point)
+	}
+
+	g2Bytes = make([]byte, C.TRUSTED_SETUP_NUM_G2_POINTS*C.BYTES_PER_G2)
+	power := one
+	for j := 0; j < C.TRUSTED_SETUP_NUM_G2_POINTS; j++ {
+		var point C.g2_t
+		C.g2_mul(&point, C.blst_p2_generator(), &power)
+		C.blst_p2_compress((*C.byte)(&g2Bytes[j*C.BYTES_PER_G2]), &point)
+		C.blst_fr_mul(&power, &power, &s)
+	}
+	return g1Bytes, g2Bytes, nil
+}
+
+// randomFieldElement returns a uniformly random field element for the checks
+// in VerifyTrustedSetup.
+func randomFieldElement() (C.fr_t, error) {
+	var b Bytes32
+	var out C.fr_t
+	if _, err := rand.Read(b[:]); err != nil {
+		return out, err
+	}
+	C.hash_to_bls_field(&out, (*C.Bytes32)(unsafe.Pointer(&b)))
+	return out, nil
+}
+
+/*
+VerifyTrustedSetup checks that the loaded trusted setup is well-formed, i.e.
+that for some secret s the G1 points are [L_i(s)]G1 for the Lagrange basis of
+the blob domain and the G2 points are [s^i]G2. It returns
+ErrInvalidTrustedSetup otherwise.
+
+Writing M_j for the monomial points [s^j]G1, which are linear combinations of
+the Lagrange points, the checks are that M_0 and the first G2 point are the
+generators, that e(M_{j+1}, G2) == e(M_j, [s]G2) for every j and that
+e(M_1, [s^j]G2) == e(G1, [s^(j+1)]G2) for every j. Each set of pairing checks
+is batched with a random linear combination, so the whole verification costs
+two multi-scalar multiplications of the G1 points and two pairing checks.
+*/
+func VerifyTrustedSetup() error {
+	setupMu.RLock()
+	defer setupMu.RUnlock()
+	if !loaded {
+		panic("trusted setup isn't loaded")
+	}
+	n := C.TRUSTED_SETUP_NUM_G1_POINTS
+	g1Values := unsafe.Slice(settings.g1_values, n)
+	g2Values := unsafe.Slice(settings.g2_values, C.TRUSTED_SETUP_NUM_G2_POINTS)
+	// Both arrays are in the same bit-reversed order, so roots[k] is the
+	// domain element of the Lagrange point g1Values[k].
+	roots := unsafe.Slice(settings.roots_of_unity, n)
+
+	// M_0 is the sum of the Lagrange points and M_1 their combination with the
+	// domain elements.
+	var m0, m1 C.g1_t
+	if ret := C.g1_lincomb_fast(&m1, &g1Values[0], &roots[0], C.uint64_t(n)); ret != C.C_KZG_OK {
+		return makeErrorFromRet(ret)
+	}
+	for k := range g1Values {
+		C.blst_p1_add_or_double(&m0, &m0, &g1Values[k])
+	}
+	if !C.blst_p1_is_equal(&m0, C.blst_p1_generator()) || !C.blst_p2_is_equal(&g2Values[0], C.blst_p2_generator()) {
+		return ErrInvalidTrustedSetup
+	}
+
+	// With R(x) = sum_{j<n-1} (r x)^j, sum_j r^j M_j is the combination of the
+	// Lagrange points with c_k = R(w_k) = ((r w_k)^(n-1) - 1) / (r w_k - 1),
+	// and sum_j r^j M_{j+1} the combination with w_k c_k. Since w_k^n = 1,
+	// (r w_k)^(n-1) = r^(n-1) / w_k.
+	r, err := randomFieldElement()
+	if err != nil {
+		return err
+	}
+	var one, rPow C.fr_t
+	C.fr_from_uint64(&one, 1)
+	C.fr_pow(&rPow, &r, C.uint64_t(n-1))
+	numerators := make([]C.fr_t, n)
+	denominators := make([]C.fr_t, n)
+	if ret := C.fr_batch_inv(&numerators[0], &roots[0], C.int(n)); ret != C.C_KZG_OK {
+		return makeErrorFromRet(ret)
+	}
+	for k := range roots {
+		C.blst_fr_mul(&numerators[k], &numerators[k], &rPow)
+		C.blst_fr_sub(&numerators[k], &numerators[k], &one)
+		C.blst_fr_mul(&denominators[k], &r, &roots[k])
+		C.blst_fr_sub(&denominators[k], &denominators[k], &one)
+	}
+	lower := make([]C.fr_t, n)
+	upper := make([]C.fr_t, n)
+	if ret := C.fr_batch_inv(&lower[0], &denominators[0], C.int(n)); ret != C.C_KZG_OK {
+		return makeErrorFromRet(ret)
+	}
+	for k := range roots {
+		C.blst_fr_mul(&lower[k], &lower[k], &numerators[k])
+		C.blst_fr_mul(&upper[k], &lower[k], &roots[k])
+	}
+	var lowerSum, upperSum C.g1_t
+	if ret := C.g1_lincomb_fast(&lowerSum, &g1Values[0], &lower[0], C.uint64_t(n)); ret != C.C_KZG_OK {
+		return makeErrorFromRet(ret)
+	}
+	if ret := C.g1_lincomb_fast(&upperSum, &g1Values[0], &upper[0], C.uint64_t(n)); ret != C.C_KZG_OK {
+		return makeErrorFromRet(ret)
+	}
+	if !C.pairings_verify(&upperSum, &g2Values[0], &lowerSum, &g2Values[1]) {
+		return ErrInvalidTrustedSetup
+	}
+
+	// The G2 points are checked the same way against M_1 = [s]G1.
+	t, err := randomFieldElement()
+	if err != nil {
+		return err
+	}
+	var lowerG2, upperG2 C.g2_t
+	power := one
+	for j := 0; j+1 < len(g2Values); j++ {
+		var term C.g2_t
+		C.g2_mul(&term, &g2Values[j], &power)
+		C.blst_p2_add_or_double(&lowerG2, &lowerG2, &term)
+		C.g2_mul(&term, &g2Values[j+1], &power)
+		C.blst_p2_add_or_double(&upperG2, &upperG2, &term)
+		C.blst_fr_mul(&power, &power, &t)
+	}
+	if !C.pairings_verify(&m1, &lowerG2, C.blst_p1_generator(), &upperG2) {
+		return ErrInvalidTrustedSetup
+	}
+	return nil
+}
+
 /*
 BlobToKZGCommitment is the binding for:
 
@@ -203,7 +507,11 @@ BlobToKZGCommitment is the binding for:
 	    const Blob *blob,
 	    const KZGSettings *s);
 */
-func BlobToKZGCommitment(blob *Blob) (KZGCommitment, error) {
+func BlobToKZGCommitment(blob *Blob) (_ KZGCommitment, err error) {
+	defer callHooks("blob_to_kzg_commitment", 1, BytesPerBlob)(nil, &err)
+	defer recoverPanic(&err)
+	setupMu.RLock()
+	defer setupMu.RUnlock()
 	if !loaded {
 		panic("trusted setup isn't loaded")
 	}
@@ -218,7 +526,8 @@ func BlobToKZGCommitment(blob *Blob) (KZGCommitment, error) {
 		&settings)
 
 	if ret != C.C_KZG_OK {
-		return KZGCommitment{}, makeErrorFromRet(ret)
+		return KZGCommitment{}, explainError("blob_to_kzg_commitment", makeErrorFromRet(ret),
+			func() error { return checkBlob("blob", blob) })
 	}
 	return commitment, nil
 }
@@ -233,7 +542,11 @@ ComputeKZGProof is the binding for:
 	    const Bytes32 *z_bytes,
 	    const KZGSettings *s);
 */
-func ComputeKZGProof(blob *Blob, zBytes Bytes32) (KZGProof, Bytes32, error) {
+func ComputeKZGProof(blob *Blob, zBytes Bytes32) (_ KZGProof, _ Bytes32, err error) {
+	defer callHooks("compute_kzg_proof", 1, BytesPerBlob+BytesPerFieldElement)(nil, &err)
+	defer recoverPanic(&err)
+	setupMu.RLock()
+	defer setupMu.RUnlock()
 	if !loaded {
 		panic("trusted setup isn't loaded")
 	}
@@ -252,7 +565,9 @@ func ComputeKZGProof(blob *Blob, zBytes Bytes32) (KZGProof, Bytes32, error) {
 		&settings)
 
 	if ret != C.C_KZG_OK {
-		return KZGProof{}, Bytes32{}, makeErrorFromRet(ret)
+		return KZGProof{}, Bytes32{}, explainError("compute_kzg_proof", makeErrorFromRet(ret),
+			func() error { return checkBlob("blob", blob) },
+			func() error { return checkFieldElement("z", zBytes) })
 	}
 	return proof, y, nil
 }
@@ -266,7 +581,11 @@ ComputeBlobKZGProof is the binding for:
 	    const Bytes48 *commitment_bytes,
 	    const KZGSettings *s);
 */
-func ComputeBlobKZGProof(blob *Blob, commitmentBytes Bytes48) (KZGProof, error) {
+func ComputeBlobKZGProof(blob *Blob, commitmentBytes Bytes48) (_ KZGProof, err error) {
+	defer callHooks("compute_blob_kzg_proof", 1, BytesPerBlob+BytesPerCommitment)(nil, &err)
+	defer recoverPanic(&err)
+	setupMu.RLock()
+	defer setupMu.RUnlock()
 	if !loaded {
 		panic("trusted setup isn't loaded")
 	}
@@ -281,7 +600,9 @@ func ComputeBlobKZGProof(blob *Blob, commitmentBytes Bytes48) (KZGProof, error)
 		&settings)
 
 	if ret != C.C_KZG_OK {
-		return KZGProof{}, makeErrorFromRet(ret)
+		return KZGProof{}, explainError("compute_blob_kzg_proof", makeErrorFromRet(ret),
+			func() error { return checkBlob("blob", blob) },
+			func() error { return checkG1("commitment", commitmentBytes) })
 	}
 	return proof, nil
 }
@@ -297,7 +618,11 @@ VerifyKZGProof is the binding for:
 	    const Bytes48 *proof_bytes,
 	    const KZGSettings *s);
 */
-func VerifyKZGProof(commitmentBytes Bytes48, zBytes, yBytes Bytes32, proofBytes Bytes48) (bool, error) {
+func VerifyKZGProof(commitmentBytes Bytes48, zBytes, yBytes Bytes32, proofBytes Bytes48) (valid bool, err error) {
+	defer callHooks("verify_kzg_proof", 1, BytesPerCommitment+2*BytesPerFieldElement+BytesPerProof)(&valid, &err)
+	defer recoverPanic(&err)
+	setupMu.RLock()
+	defer setupMu.RUnlock()
 	if !loaded {
 		panic("trusted setup isn't loaded")
 	}
@@ -311,7 +636,11 @@ func VerifyKZGProof(commitmentBytes Bytes48, zBytes, yBytes Bytes32, proofBytes
 		&settings)
 
 	if ret != C.C_KZG_OK {
-		return false, makeErrorFromRet(ret)
+		return false, explainError("verify_kzg_proof", makeErrorFromRet(ret),
+			func() error { return checkG1("commitment", commitmentBytes) },
+			func() error { return checkFieldElement("z", zBytes) },
+			func() error { return checkFieldElement("y", yBytes) },
+			func() error { return checkG1("proof", proofBytes) })
 	}
 	return bool(result), nil
 }
@@ -326,7 +655,11 @@ VerifyBlobKZGProof is the binding for:
 	    const Bytes48 *proof_bytes,
 	    const KZGSettings *s);
 */
-func VerifyBlobKZGProof(blob *Blob, commitmentBytes, proofBytes Bytes48) (bool, error) {
+func VerifyBlobKZGProof(blob *Blob, commitmentBytes, proofBytes Bytes48) (valid bool, err error) {
+	defer callHooks("verify_blob_kzg_proof", 1, BytesPerBlob+BytesPerCommitment+BytesPerProof)(&valid, &err)
+	defer recoverPanic(&err)
+	setupMu.RLock()
+	defer setupMu.RUnlock()
 	if !loaded {
 		panic("trusted setup isn't loaded")
 	}
@@ -343,7 +676,10 @@ func VerifyBlobKZGProof(blob *Blob, commitmentBytes, proofBytes Bytes48) (bool,
 		&settings)
 
 	if ret != C.C_KZG_OK {
-		return false, makeErrorFromRet(ret)
+		return false, explainError("verify_blob_kzg_proof", makeErrorFromRet(ret),
+			func() error { return checkBlob("blob", blob) },
+			func() error { return checkG1("commitment", commitmentBytes) },
+			func() error { return checkG1("proof", proofBytes) })
 	}
 	return bool(result), nil
 }
@@ -358,7 +694,12 @@ VerifyBlobKZGProofBatch is the binding for:
 	    const Bytes48 *proofs_bytes,
 	    const KZGSettings *s);
 */
-func VerifyBlobKZGProofBatch(blobs []Blob, commitmentsBytes, proofsBytes []Bytes48) (bool, error) {
+func VerifyBlobKZGProofBatch(blobs []Blob, commitmentsBytes, proofsBytes []Bytes48) (valid bool, err error) {
+	defer callHooks("verify_blob_kzg_proof_batch", len(blobs),
+		int64(len(blobs))*BytesPerBlob+int64(len(commitmentsBytes))*BytesPerCommitment+int64(len(proofsBytes))*BytesPerProof)(&valid, &err)
+	defer recoverPanic(&err)
+	setupMu.RLock()
+	defer setupMu.RUnlock()
 	if !loaded {
 		panic("trusted setup isn't loaded")
 	}
@@ -376,7 +717,20 @@ func VerifyBlobKZGProofBatch(blobs []Blob, commitmentsBytes, proofsBytes []Bytes
 		&settings)
 
 	if ret != C.C_KZG_OK {
-		return false, makeErrorFromRet(ret)
+		return false, explainError("verify_blob_kzg_proof_batch", makeErrorFromRet(ret), func() error {
+			for i := range blobs {
+				if err := checkBlob(fmt.Sprintf("blob %v", i), &blobs[i]); err != nil {
+					return err
+				}
+				if err := checkG1(fmt.Sprintf("commitment %v", i), commitmentsBytes[i]); err != nil {
+					return err
+				}
+				if err := checkG1(fmt.Sprintf("proof %v", i), proofsBytes[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
 	}
 	return bool(result), nil
 }
@@ -0,0 +1,17 @@
+//go:build cgo && !ckzg_nocgo
+
+package ckzg4844
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateSetupMemory(t *testing.T) {
+	// 4096 roots of unity, 4096 G1 points, 65 G2 points and 4097 temporary
+	// roots of unity.
+	expected := uint64(4096*32 + 4096*144 + 65*288 + 4097*32)
+	require.Equal(t, expected, EstimateSetupMemory(FieldElementsPerBlob, 65, SetupOptions{}))
+	require.Less(t, EstimateSetupMemory(16, 2, SetupOptions{}), expected)
+}
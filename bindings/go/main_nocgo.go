@@ -0,0 +1,695 @@
+//go:build !cgo || ckzg_nocgo
+
+// This file implements the package in Go, with go-kzg-4844 and gnark-crypto,
+// when cgo is disabled or the ckzg_nocgo tag is set. The results are the same
+// as those of the C library, but verification is about two times slower, loading
+// a trusted setup several times slower and every operation allocates more, so
+// cgo should be used wherever it is available.
+
+package ckzg4844
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"os"
+	"runtime"
+	"sync"
+	"unsafe"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+)
+
+// primitiveRoot is PRIMITIVE_ROOT_OF_UNITY of the specification, from which
+// the roots of unity of the blob domain are derived.
+const primitiveRoot = 7
+
+// setupMu guards loaded and settings: it is held for writing while the trusted
+// setup is loaded or freed, and for reading by every call that uses it, so
+// that the setup can be replaced while other goroutines use the library.
+var setupMu sync.RWMutex
+
+var (
+	loaded   = false
+	settings struct {
+		context *gokzg4844.Context
+		// The points as given to LoadTrustedSetup, in natural order.
+		g1Values []bls12381.G1Affine
+		g2Values []bls12381.G2Affine
+	}
+)
+
+// isLoaded reports whether a trusted setup is loaded, for the functions that
+// check it before calling the functions that hold setupMu.
+func isLoaded() bool {
+	setupMu.RLock()
+	defer setupMu.RUnlock()
+	return loaded
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Helper Functions
+///////////////////////////////////////////////////////////////////////////////
+
+// checkFieldElement returns an error wrapping ErrBadArgs if the named field
+// element is not canonical.
+func checkFieldElement(name string, fieldElementBytes Bytes32) error {
+	if _, err := gokzg4844.DeserializeScalar(gokzg4844.Scalar(fieldElementBytes)); err != nil {
+		return fmt.Errorf("%w: %v is not canonical", ErrBadArgs, name)
+	}
+	return nil
+}
+
+// checkG1 returns an error wrapping ErrBadArgs if the named bytes are not a
+// valid compressed G1 point in the correct subgroup.
+func checkG1(name string, g1Bytes Bytes48) error {
+	if _, err := gokzg4844.DeserializeKZGProof(gokzg4844.KZGProof(g1Bytes)); err != nil {
+		return fmt.Errorf("%w: %v is not a valid G1 point", ErrBadArgs, name)
+	}
+	return nil
+}
+
+// checkInputs returns the first error from checks, which validate the inputs
+// of fn before go-kzg-4844 is called, so that invalid inputs fail with the
+// same errors as with the C library.
+func checkInputs(fn string, checks ...func() error) error {
+	for _, check := range checks {
+		if err := check(); err != nil {
+			logf(LogLevelDebug, "%v failed: %v", fn, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// rootsOfUnity returns the n-th roots of unity of the blob domain, in natural
+// order.
+func rootsOfUnity(n int) []fr.Element {
+	exponent := new(big.Int).Sub(fr.Modulus(), big.NewInt(1))
+	exponent.Div(exponent, big.NewInt(int64(n)))
+	var root fr.Element
+	root.SetUint64(primitiveRoot)
+	root.Exp(root, exponent)
+	roots := make([]fr.Element, n)
+	roots[0].SetOne()
+	for i := 1; i < n; i++ {
+		roots[i].Mul(&roots[i-1], &root)
+	}
+	return roots
+}
+
+// pairingsVerify reports whether e(a1, a2) == e(b1, b2).
+func pairingsVerify(a1 *bls12381.G1Affine, a2 *bls12381.G2Affine, b1 *bls12381.G1Affine, b2 *bls12381.G2Affine) bool {
+	var negB1 bls12381.G1Affine
+	negB1.Neg(b1)
+	ok, err := bls12381.PairingCheck([]bls12381.G1Affine{*a1, negB1}, []bls12381.G2Affine{*a2, *b2})
+	return err == nil && ok
+}
+
+// decodeG1Points decompresses concatenated G1 points, returning ErrBadArgs
+// if any of them is invalid.
+func decodeG1Points(g1Bytes []byte) ([]bls12381.G1Affine, error) {
+	points := make([]bls12381.G1Affine, len(g1Bytes)/bytesPerG1)
+	for i := range points {
+		if _, err := points[i].SetBytes(g1Bytes[i*bytesPerG1 : (i+1)*bytesPerG1]); err != nil {
+			return nil, ErrBadArgs
+		}
+	}
+	return points, nil
+}
+
+// decodeG2Points decompresses concatenated G2 points, returning ErrBadArgs
+// if any of them is invalid.
+func decodeG2Points(g2Bytes []byte) ([]bls12381.G2Affine, error) {
+	points := make([]bls12381.G2Affine, len(g2Bytes)/bytesPerG2)
+	for i := range points {
+		if _, err := points[i].SetBytes(g2Bytes[i*bytesPerG2 : (i+1)*bytesPerG2]); err != nil {
+			return nil, ErrBadArgs
+		}
+	}
+	return points, nil
+}
+
+// convertG1 performs an FFT over compressed G1 points, in natural order. With
+// inverse set it converts monomial form to Lagrange form, otherwise Lagrange
+// form to monomial form. The number of points must be
+// trustedSetupNumG1Points.
+func convertG1(g1Bytes []byte, inverse bool) ([]byte, error) {
+	n := trustedSetupNumG1Points
+	if len(g1Bytes) != n*bytesPerG1 {
+		return nil, ErrBadArgs
+	}
+	affine, err := decodeG1Points(g1Bytes)
+	if err != nil {
+		return nil, err
+	}
+	logN := bits.TrailingZeros(uint(n))
+	points := make([]bls12381.G1Jac, n)
+	for i := range points {
+		points[bits.Reverse32(uint32(i))>>(32-logN)].FromAffine(&affine[i])
+	}
+
+	roots := rootsOfUnity(n)
+	root := func(k int) *big.Int {
+		if inverse {
+			k = (n - k) % n
+		}
+		return roots[k].BigInt(new(big.Int))
+	}
+	for size := 2; size <= n; size <<= 1 {
+		half, stride := size/2, n/size
+		for start := 0; start < n; start += size {
+			for j := 0; j < half; j++ {
+				t := points[start+j+half]
+				if j != 0 {
+					t.ScalarMultiplication(&t, root(j*stride))
+				}
+				points[start+j+half].Set(&points[start+j]).SubAssign(&t)
+				points[start+j].AddAssign(&t)
+			}
+		}
+	}
+	if inverse {
+		var scale fr.Element
+		scale.SetUint64(uint64(n))
+		scale.Inverse(&scale)
+		scaleBig := scale.BigInt(new(big.Int))
+		for i := range points {
+			points[i].ScalarMultiplication(&points[i], scaleBig)
+		}
+	}
+
+	out := make([]byte, len(g1Bytes))
+	for i, point := range bls12381.BatchJacobianToAffineG1(points) {
+		compressed := point.Bytes()
+		copy(out[i*bytesPerG1:], compressed[:])
+	}
+	return out, nil
+}
+
+// isMonomialForm reports whether the first two G1 and G2 points satisfy
+// e(g1[1], g2[0]) == e(g1[0], g2[1]), which holds for a setup in monomial form.
+func isMonomialForm(g1Bytes, g2Bytes []byte) bool {
+	if len(g1Bytes) < 2*bytesPerG1 || len(g2Bytes) < 2*bytesPerG2 {
+		return false
+	}
+	g1, err := decodeG1Points(g1Bytes[:2*bytesPerG1])
+	if err != nil {
+		return false
+	}
+	g2, err := decodeG2Points(g2Bytes[:2*bytesPerG2])
+	if err != nil {
+		return false
+	}
+	return pairingsVerify(&g1[1], &g2[0], &g1[0], &g2[1])
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Interface Functions
+///////////////////////////////////////////////////////////////////////////////
+
+/*
+LoadTrustedSetup loads a trusted setup of G1 points in Lagrange form and G2
+points in monomial form, like load_trusted_setup of the C library. It returns
+ErrBadArgs if the numbers of points are wrong, a point is invalid or the G1
+points are in monomial form.
+*/
+func LoadTrustedSetup(g1Bytes, g2Bytes []byte) (err error) {
+	defer callHooks("load_trusted_setup", 1, int64(len(g1Bytes))+int64(len(g2Bytes)))(nil, &err)
+	defer recoverPanic(&err)
+	setupMu.Lock()
+	defer setupMu.Unlock()
+	return loadTrustedSetup(g1Bytes, g2Bytes)
+}
+
+// loadTrustedSetup implements LoadTrustedSetup, without calling the hooks,
+// for the functions that load a setup. setupMu must be held for writing.
+func loadTrustedSetup(g1Bytes, g2Bytes []byte) error {
+	if loaded {
+		panic("trusted setup is already loaded")
+	}
+	if len(g1Bytes)%bytesPerG1 != 0 {
+		panic(fmt.Sprintf("len(g1Bytes) is not a multiple of %v", bytesPerG1))
+	}
+	if len(g2Bytes)%bytesPerG2 != 0 {
+		panic(fmt.Sprintf("len(g2Bytes) is not a multiple of %v", bytesPerG2))
+	}
+	if len(g1Bytes)/bytesPerG1 != trustedSetupNumG1Points || len(g2Bytes)/bytesPerG2 != trustedSetupNumG2Points {
+		return ErrBadArgs
+	}
+	g1Values, err := decodeG1Points(g1Bytes)
+	if err != nil {
+		return err
+	}
+	g2Values, err := decodeG2Points(g2Bytes)
+	if err != nil {
+		return err
+	}
+	if pairingsVerify(&g1Values[1], &g2Values[0], &g1Values[0], &g2Values[1]) {
+		return ErrBadArgs
+	}
+
+	// go-kzg-4844 takes the G1 generator from the monomial points, and only
+	// uses the first of them.
+	_, _, g1Generator, _ := bls12381.Generators()
+	g1GeneratorBytes := g1Generator.Bytes()
+	setup := gokzg4844.JSONTrustedSetup{}
+	setup.SetupG1[0] = "0x" + hex.EncodeToString(g1GeneratorBytes[:])
+	for i := range setup.SetupG1Lagrange {
+		setup.SetupG1Lagrange[i] = "0x" + hex.EncodeToString(g1Bytes[i*bytesPerG1:(i+1)*bytesPerG1])
+	}
+	for i := range g2Values {
+		setup.SetupG2 = append(setup.SetupG2, "0x"+hex.EncodeToString(g2Bytes[i*bytesPerG2:(i+1)*bytesPerG2]))
+	}
+	context, err := gokzg4844.NewContext4096(&setup)
+	if err != nil {
+		return ErrBadArgs
+	}
+	settings.context = context
+	settings.g1Values = g1Values
+	settings.g2Values = g2Values
+	loaded = true
+	return nil
+}
+
+/*
+LoadTrustedSetupFile loads a trusted setup in the text format of
+load_trusted_setup_file of the C library.
+*/
+func LoadTrustedSetupFile(trustedSetupFile string) (err error) {
+	defer callHooks("load_trusted_setup_file", 1, 0)(nil, &err)
+	defer recoverPanic(&err)
+	setupMu.Lock()
+	defer setupMu.Unlock()
+	if loaded {
+		panic("trusted setup is already loaded")
+	}
+	data, err := os.ReadFile(trustedSetupFile)
+	if err != nil {
+		panic("error reading trusted setup")
+	}
+	g1Bytes, g2Bytes, err := ParseTrustedSetup(data)
+	if err != nil {
+		return err
+	}
+	return loadTrustedSetup(g1Bytes, g2Bytes)
+}
+
+// FreeTrustedSetup releases the loaded trusted setup.
+func FreeTrustedSetup() {
+	setupMu.Lock()
+	defer setupMu.Unlock()
+	if !loaded {
+		panic("trusted setup isn't loaded")
+	}
+	settings.context = nil
+	settings.g1Values = nil
+	settings.g2Values = nil
+	loaded = false
+}
+
+/*
+EstimateSetupMemory returns the number of bytes allocated while loading a
+trusted setup with the given numbers of points and kept until
+FreeTrustedSetup: the points, the domain and the copy of the Lagrange points
+kept by go-kzg-4844. The temporary allocations of the load, which are several
+times larger, are not counted, nor are the input buffers.
+*/
+func EstimateSetupMemory(numG1, numG2 int, opts SetupOptions) uint64 {
+	maxWidth := uint64(1)
+	for maxWidth < uint64(numG1) {
+		maxWidth <<= 1
+	}
+	g1 := uint64(unsafe.Sizeof(bls12381.G1Affine{}))
+	g2 := uint64(unsafe.Sizeof(bls12381.G2Affine{}))
+	frSize := uint64(unsafe.Sizeof(fr.Element{}))
+	return 2*uint64(numG1)*g1 + uint64(numG2)*g2 + 2*maxWidth*frSize
+}
+
+/*
+TrustedSetupBytes returns the loaded trusted setup as the compressed G1 points
+in Lagrange form and G2 points in monomial form, in the layout accepted by
+LoadTrustedSetup.
+*/
+func TrustedSetupBytes() (g1Bytes, g2Bytes []byte) {
+	setupMu.RLock()
+	defer setupMu.RUnlock()
+	if !loaded {
+		panic("trusted setup isn't loaded")
+	}
+	g1Bytes = make([]byte, len(settings.g1Values)*bytesPerG1)
+	for i := range settings.g1Values {
+		compressed := settings.g1Values[i].Bytes()
+		copy(g1Bytes[i*bytesPerG1:], compressed[:])
+	}
+	g2Bytes = make([]byte, len(settings.g2Values)*bytesPerG2)
+	for i := range settings.g2Values {
+		compressed := settings.g2Values[i].Bytes()
+		copy(g2Bytes[i*bytesPerG2:], compressed[:])
+	}
+	return g1Bytes, g2Bytes
+}
+
+/*
+GenerateInsecureSetup returns a trusted setup for the given secret, in the
+layout accepted by LoadTrustedSetup. Anyone who knows the secret can forge
+proofs, so this is only for tests and devnets. The size is the number of G1
+points, which must be FieldElementsPerBlob; other sizes return ErrBadArgs.
+*/
+func GenerateInsecureSetup(secret Bytes32, size int) (g1Bytes, g2Bytes []byte, err error) {
+	if size != trustedSetupNumG1Points {
+		return nil, nil, ErrBadArgs
+	}
+	var s, one, sPow fr.Element
+	s.SetBytes(secret[:])
+	one.SetOne()
+	sPow.Exp(s, big.NewInt(int64(size)))
+	// The secret must not be zero or in the domain, where L_i(s) is 0 or 1.
+	if s.IsZero() || sPow.IsOne() {
+		return nil, nil, ErrBadArgs
+	}
+
+	// L_i(s) = w_i / n * (s^n - 1) / (s - w_i).
+	roots := rootsOfUnity(size)
+	var scale fr.Element
+	scale.SetUint64(uint64(size))
+	scale.Inverse(&scale)
+	sPow.Sub(&sPow, &one)
+	scale.Mul(&scale, &sPow)
+	lagrange := make([]fr.Element, size)
+	for i := range roots {
+		lagrange[i].Sub(&s, &roots[i])
+	}
+	lagrange = fr.BatchInvert(lagrange)
+	for i := range roots {
+		lagrange[i].Mul(&lagrange[i], &roots[i])
+		lagrange[i].Mul(&lagrange[i], &scale)
+	}
+	_, _, g1Generator, g2Generator := bls12381.Generators()
+	g1Bytes = make([]byte, size*bytesPerG1)
+	for i, point := range bls12381.BatchScalarMultiplicationG1(&g1Generator, lagrange) {
+		compressed := point.Bytes()
+		copy(g1Bytes[i*bytesPerG1:], compressed[:])
+	}
+
+	g2Bytes = make([]byte, trustedSetupNumG2Points*bytesPerG2)
+	powers := make([]fr.Element, trustedSetupNumG2Points)
+	powers[0].SetOne()
+	for j := 1; j < len(powers); j++ {
+		powers[j].Mul(&powers[j-1], &s)
+	}
+	for j, point := range bls12381.BatchScalarMultiplicationG2(&g2Generator, powers) {
+		compressed := point.Bytes()
+		copy(g2Bytes[j*bytesPerG2:], compressed[:])
+	}
+	return g1Bytes, g2Bytes, nil
+}
+
+// randomFieldElement returns a uniformly random field element for the checks
+// in VerifyTrustedSetup.
+func randomFieldElement() (fr.Element, error) {
+	var b Bytes32
+	var out fr.Element
+	if _, err := rand.Read(b[:]); err != nil {
+		return out, err
+	}
+	out.SetBytes(b[:])
+	return out, nil
+}
+
+/*
+VerifyTrustedSetup checks that the loaded trusted setup is well-formed, i.e.
+that for some secret s the G1 points are [L_i(s)]G1 for the Lagrange basis of
+the blob domain and the G2 points are [s^i]G2. It returns
+ErrInvalidTrustedSetup otherwise.
+
+The checks are those of the cgo implementation: writing M_j for the monomial
+points [s^j]G1, that M_0 and the first G2 point are the generators, that
+e(M_{j+1}, G2) == e(M_j, [s]G2) for every j and that
+e(M_1, [s^j]G2) == e(G1, [s^(j+1)]G2) for every j, each batched with a random
+linear combination.
+*/
+func VerifyTrustedSetup() error {
+	setupMu.RLock()
+	defer setupMu.RUnlock()
+	if !loaded {
+		panic("trusted setup isn't loaded")
+	}
+	n := trustedSetupNumG1Points
+	g1Values := settings.g1Values
+	g2Values := settings.g2Values
+	roots := rootsOfUnity(n)
+	config := ecc.MultiExpConfig{NbTasks: runtime.NumCPU()}
+
+	// M_0 is the sum of the Lagrange points and M_1 their combination with the
+	// domain elements.
+	var m0, m1 bls12381.G1Affine
+	if _, err := m1.MultiExp(g1Values, roots, config); err != nil {
+		return err
+	}
+	var m0Jac bls12381.G1Jac
+	for k := range g1Values {
+		m0Jac.AddMixed(&g1Values[k])
+	}
+	m0.FromJacobian(&m0Jac)
+	_, _, g1Generator, g2Generator := bls12381.Generators()
+	if !m0.Equal(&g1Generator) || !g2Values[0].Equal(&g2Generator) {
+		return ErrInvalidTrustedSetup
+	}
+
+	// With R(x) = sum_{j<n-1} (r x)^j, sum_j r^j M_j is the combination of the
+	// Lagrange points with c_k = R(w_k) = ((r w_k)^(n-1) - 1) / (r w_k - 1),
+	// and sum_j r^j M_{j+1} the combination with w_k c_k. Since w_k^n = 1,
+	// (r w_k)^(n-1) = r^(n-1) / w_k.
+	r, err := randomFieldElement()
+	if err != nil {
+		return err
+	}
+	var one, rPow fr.Element
+	one.SetOne()
+	rPow.Exp(r, big.NewInt(int64(n-1)))
+	numerators := fr.BatchInvert(roots)
+	denominators := make([]fr.Element, n)
+	for k := range roots {
+		numerators[k].Mul(&numerators[k], &rPow)
+		numerators[k].Sub(&numerators[k], &one)
+		denominators[k].Mul(&r, &roots[k])
+		denominators[k].Sub(&denominators[k], &one)
+	}
+	lower := fr.BatchInvert(denominators)
+	upper := make([]fr.Element, n)
+	for k := range roots {
+		lower[k].Mul(&lower[k], &numerators[k])
+		upper[k].Mul(&lower[k], &roots[k])
+	}
+	var lowerSum, upperSum bls12381.G1Affine
+	if _, err := lowerSum.MultiExp(g1Values, lower, config); err != nil {
+		return err
+	}
+	if _, err := upperSum.MultiExp(g1Values, upper, config); err != nil {
+		return err
+	}
+	if !pairingsVerify(&upperSum, &g2Values[0], &lowerSum, &g2Values[1]) {
+		return ErrInvalidTrustedSetup
+	}
+
+	// The G2 points are checked the same way against M_1 = [s]G1.
+	t, err := randomFieldElement()
+	if err != nil {
+		return err
+	}
+	powers := make([]fr.Element, len(g2Values)-1)
+	powers[0].SetOne()
+	for j := 1; j < len(powers); j++ {
+		powers[j].Mul(&powers[j-1], &t)
+	}
+	var lowerG2, upperG2 bls12381.G2Affine
+	if _, err := lowerG2.MultiExp(g2Values[:len(g2Values)-1], powers, config); err != nil {
+		return err
+	}
+	if _, err := upperG2.MultiExp(g2Values[1:], powers, config); err != nil {
+		return err
+	}
+	if !pairingsVerify(&m1, &lowerG2, &g1Generator, &upperG2) {
+		return ErrInvalidTrustedSetup
+	}
+	return nil
+}
+
+// BlobToKZGCommitment returns the commitment to the blob, like
+// blob_to_kzg_commitment of the C library.
+func BlobToKZGCommitment(blob *Blob) (_ KZGCommitment, err error) {
+	defer callHooks("blob_to_kzg_commitment", 1, BytesPerBlob)(nil, &err)
+	defer recoverPanic(&err)
+	setupMu.RLock()
+	defer setupMu.RUnlock()
+	if !loaded {
+		panic("trusted setup isn't loaded")
+	}
+	if blob == nil {
+		return KZGCommitment{}, ErrBadArgs
+	}
+	if err := checkInputs("blob_to_kzg_commitment",
+		func() error { return checkBlob("blob", blob) }); err != nil {
+		return KZGCommitment{}, err
+	}
+
+	commitment, err := settings.context.BlobToKZGCommitment(gokzg4844.Blob(*blob), 0)
+	if err != nil {
+		return KZGCommitment{}, explainError("blob_to_kzg_commitment", ErrError)
+	}
+	return KZGCommitment(commitment), nil
+}
+
+// ComputeKZGProof returns the proof of the evaluation of the blob's
+// polynomial at z and the value of the evaluation, like compute_kzg_proof of
+// the C library.
+func ComputeKZGProof(blob *Blob, zBytes Bytes32) (_ KZGProof, _ Bytes32, err error) {
+	defer callHooks("compute_kzg_proof", 1, BytesPerBlob+BytesPerFieldElement)(nil, &err)
+	defer recoverPanic(&err)
+	setupMu.RLock()
+	defer setupMu.RUnlock()
+	if !loaded {
+		panic("trusted setup isn't loaded")
+	}
+	if blob == nil {
+		return KZGProof{}, Bytes32{}, ErrBadArgs
+	}
+	if err := checkInputs("compute_kzg_proof",
+		func() error { return checkBlob("blob", blob) },
+		func() error { return checkFieldElement("z", zBytes) }); err != nil {
+		return KZGProof{}, Bytes32{}, err
+	}
+
+	proof, y, err := settings.context.ComputeKZGProof(gokzg4844.Blob(*blob), gokzg4844.Scalar(zBytes), 0)
+	if err != nil {
+		return KZGProof{}, Bytes32{}, explainError("compute_kzg_proof", ErrError)
+	}
+	return KZGProof(proof), Bytes32(y), nil
+}
+
+// ComputeBlobKZGProof returns the proof of the blob against its commitment,
+// like compute_blob_kzg_proof of the C library.
+func ComputeBlobKZGProof(blob *Blob, commitmentBytes Bytes48) (_ KZGProof, err error) {
+	defer callHooks("compute_blob_kzg_proof", 1, BytesPerBlob+BytesPerCommitment)(nil, &err)
+	defer recoverPanic(&err)
+	setupMu.RLock()
+	defer setupMu.RUnlock()
+	if !loaded {
+		panic("trusted setup isn't loaded")
+	}
+	if blob == nil {
+		return KZGProof{}, ErrBadArgs
+	}
+	if err := checkInputs("compute_blob_kzg_proof",
+		func() error { return checkBlob("blob", blob) },
+		func() error { return checkG1("commitment", commitmentBytes) }); err != nil {
+		return KZGProof{}, err
+	}
+
+	proof, err := settings.context.ComputeBlobKZGProof(gokzg4844.Blob(*blob), gokzg4844.KZGCommitment(commitmentBytes), 0)
+	if err != nil {
+		return KZGProof{}, explainError("compute_blob_kzg_proof", ErrError)
+	}
+	return KZGProof(proof), nil
+}
+
+// VerifyKZGProof reports whether the proof shows that the polynomial of the
+// commitment evaluates to y at z, like verify_kzg_proof of the C library.
+func VerifyKZGProof(commitmentBytes Bytes48, zBytes, yBytes Bytes32, proofBytes Bytes48) (valid bool, err error) {
+	defer callHooks("verify_kzg_proof", 1, BytesPerCommitment+2*BytesPerFieldElement+BytesPerProof)(&valid, &err)
+	defer recoverPanic(&err)
+	setupMu.RLock()
+	defer setupMu.RUnlock()
+	if !loaded {
+		panic("trusted setup isn't loaded")
+	}
+	if err := checkInputs("verify_kzg_proof",
+		func() error { return checkG1("commitment", commitmentBytes) },
+		func() error { return checkFieldElement("z", zBytes) },
+		func() error { return checkFieldElement("y", yBytes) },
+		func() error { return checkG1("proof", proofBytes) }); err != nil {
+		return false, err
+	}
+
+	// The inputs are valid, so any error is an invalid proof.
+	err = settings.context.VerifyKZGProof(gokzg4844.KZGCommitment(commitmentBytes), gokzg4844.Scalar(zBytes),
+		gokzg4844.Scalar(yBytes), gokzg4844.KZGProof(proofBytes))
+	return err == nil, nil
+}
+
+// VerifyBlobKZGProof reports whether the proof is valid for the blob and its
+// commitment, like verify_blob_kzg_proof of the C library.
+func VerifyBlobKZGProof(blob *Blob, commitmentBytes, proofBytes Bytes48) (valid bool, err error) {
+	defer callHooks("verify_blob_kzg_proof", 1, BytesPerBlob+BytesPerCommitment+BytesPerProof)(&valid, &err)
+	defer recoverPanic(&err)
+	setupMu.RLock()
+	defer setupMu.RUnlock()
+	if !loaded {
+		panic("trusted setup isn't loaded")
+	}
+	if blob == nil {
+		return false, ErrBadArgs
+	}
+	if err := checkInputs("verify_blob_kzg_proof",
+		func() error { return checkBlob("blob", blob) },
+		func() error { return checkG1("commitment", commitmentBytes) },
+		func() error { return checkG1("proof", proofBytes) }); err != nil {
+		return false, err
+	}
+
+	err = settings.context.VerifyBlobKZGProof(gokzg4844.Blob(*blob), gokzg4844.KZGCommitment(commitmentBytes),
+		gokzg4844.KZGProof(proofBytes))
+	return err == nil, nil
+}
+
+// VerifyBlobKZGProofBatch reports whether every proof is valid for its blob
+// and commitment, like verify_blob_kzg_proof_batch of the C library.
+func VerifyBlobKZGProofBatch(blobs []Blob, commitmentsBytes, proofsBytes []Bytes48) (valid bool, err error) {
+	defer callHooks("verify_blob_kzg_proof_batch", len(blobs),
+		int64(len(blobs))*BytesPerBlob+int64(len(commitmentsBytes))*BytesPerCommitment+int64(len(proofsBytes))*BytesPerProof)(&valid, &err)
+	defer recoverPanic(&err)
+	setupMu.RLock()
+	defer setupMu.RUnlock()
+	if !loaded {
+		panic("trusted setup isn't loaded")
+	}
+	if len(blobs) != len(commitmentsBytes) || len(blobs) != len(proofsBytes) {
+		return false, ErrBadArgs
+	}
+	if err := checkInputs("verify_blob_kzg_proof_batch", func() error {
+		for i := range blobs {
+			if err := checkBlob(fmt.Sprintf("blob %v", i), &blobs[i]); err != nil {
+				return err
+			}
+			if err := checkG1(fmt.Sprintf("commitment %v", i), commitmentsBytes[i]); err != nil {
+				return err
+			}
+			if err := checkG1(fmt.Sprintf("proof %v", i), proofsBytes[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return false, err
+	}
+	if len(blobs) == 0 {
+		return true, nil
+	}
+
+	goBlobs := make([]gokzg4844.Blob, len(blobs))
+	goCommitments := make([]gokzg4844.KZGCommitment, len(blobs))
+	goProofs := make([]gokzg4844.KZGProof, len(blobs))
+	for i := range blobs {
+		goBlobs[i] = gokzg4844.Blob(blobs[i])
+		goCommitments[i] = gokzg4844.KZGCommitment(commitmentsBytes[i])
+		goProofs[i] = gokzg4844.KZGProof(proofsBytes[i])
+	}
+	err = settings.context.VerifyBlobKZGProofBatch(goBlobs, goCommitments, goProofs)
+	return err == nil, nil
+}
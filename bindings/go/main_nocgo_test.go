@@ -0,0 +1,17 @@
+//go:build !cgo || ckzg_nocgo
+
+package ckzg4844
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateSetupMemory(t *testing.T) {
+	// 4096 G1 points, in affine coordinates, kept here and by go-kzg-4844, 65
+	// G2 points and a domain of 4096 roots of unity and their inverses.
+	expected := uint64(2*4096*96 + 65*192 + 2*4096*32)
+	require.Equal(t, expected, EstimateSetupMemory(FieldElementsPerBlob, 65, SetupOptions{}))
+	require.Less(t, EstimateSetupMemory(16, 2, SetupOptions{}), expected)
+}
@@ -1,10 +1,16 @@
 package ckzg4844
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"math/rand"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -47,6 +53,364 @@ func fillBlobRandom(blob *Blob, seed int64) {
 	}
 }
 
+///////////////////////////////////////////////////////////////////////////////
+// Marshal Tests
+///////////////////////////////////////////////////////////////////////////////
+
+func TestMarshalText(t *testing.T) {
+	var blob Blob
+	fillBlobRandom(&blob, 0)
+	commitment, err := BlobToKZGCommitment(&blob)
+	require.NoError(t, err)
+	proof, err := ComputeBlobKZGProof(&blob, Bytes48(commitment))
+	require.NoError(t, err)
+	field := getRandFieldElement(0)
+
+	text, err := field.MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, "0x"+hex.EncodeToString(field[:]), string(text))
+	var decodedField Bytes32
+	require.NoError(t, decodedField.UnmarshalText(text))
+	require.Equal(t, field, decodedField)
+
+	text, err = blob.MarshalText()
+	require.NoError(t, err)
+	decodedBlob := new(Blob)
+	require.NoError(t, decodedBlob.UnmarshalText(text))
+	require.Equal(t, blob, *decodedBlob)
+
+	text, err = commitment.MarshalText()
+	require.NoError(t, err)
+	var decodedCommitment KZGCommitment
+	require.NoError(t, decodedCommitment.UnmarshalText(text))
+	require.Equal(t, commitment, decodedCommitment)
+
+	text, err = proof.MarshalText()
+	require.NoError(t, err)
+	var decodedProof KZGProof
+	require.NoError(t, decodedProof.UnmarshalText(text))
+	require.Equal(t, proof, decodedProof)
+}
+
+func TestMarshalJSON(t *testing.T) {
+	type Sidecar struct {
+		Blob       Blob          `json:"blob"`
+		Commitment KZGCommitment `json:"commitment"`
+		Proof      KZGProof      `json:"proof"`
+		Z          Bytes32       `json:"z"`
+		Raw        Bytes48       `json:"raw"`
+	}
+
+	var sidecar Sidecar
+	fillBlobRandom(&sidecar.Blob, 1)
+	commitment, err := BlobToKZGCommitment(&sidecar.Blob)
+	require.NoError(t, err)
+	proof, err := ComputeBlobKZGProof(&sidecar.Blob, Bytes48(commitment))
+	require.NoError(t, err)
+	sidecar.Commitment = commitment
+	sidecar.Proof = proof
+	sidecar.Z = getRandFieldElement(1)
+	sidecar.Raw = Bytes48(commitment)
+
+	data, err := json.Marshal(&sidecar)
+	require.NoError(t, err)
+	var decoded Sidecar
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, sidecar, decoded)
+
+	data, err = json.Marshal(sidecar.Commitment)
+	require.NoError(t, err)
+	require.Equal(t, `"0x`+hex.EncodeToString(commitment[:])+`"`, string(data))
+}
+
+func TestMarshalBinary(t *testing.T) {
+	type Entry struct {
+		Blob       Blob
+		Commitment KZGCommitment
+		Proof      KZGProof
+		Z          Bytes32
+		Raw        Bytes48
+	}
+
+	var entry Entry
+	fillBlobRandom(&entry.Blob, 2)
+	commitment, err := BlobToKZGCommitment(&entry.Blob)
+	require.NoError(t, err)
+	proof, err := ComputeBlobKZGProof(&entry.Blob, Bytes48(commitment))
+	require.NoError(t, err)
+	entry.Commitment = commitment
+	entry.Proof = proof
+	entry.Z = getRandFieldElement(2)
+	entry.Raw = Bytes48(proof)
+
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(&entry))
+	var decoded Entry
+	require.NoError(t, gob.NewDecoder(&buf).Decode(&decoded))
+	require.Equal(t, entry, decoded)
+
+	data, err := commitment.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, commitment[:], data)
+	var z Bytes32
+	require.ErrorIs(t, z.UnmarshalBinary(data), ErrBadArgs)
+}
+
+func TestString(t *testing.T) {
+	var blob Blob
+	blob[0], blob[1], blob[BytesPerBlob-1] = 0xab, 0xcd, 0xef
+	require.Equal(t, "0xabcd…ef(131072 bytes)", blob.String())
+	require.Equal(t, "0xabcd…ef(131072 bytes)", fmt.Sprint(&blob))
+
+	var commitment KZGCommitment
+	commitment[0] = 0xc0
+	expected := "0xc0" + strings.Repeat("00", 47)
+	require.Equal(t, expected, commitment.String())
+	require.Equal(t, expected, KZGProof(commitment).String())
+	require.Equal(t, expected, fmt.Sprintf("%v", Bytes48(commitment)))
+
+	z := Bytes32{31: 1}
+	require.Equal(t, "0x"+strings.Repeat("00", 31)+"01", z.String())
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Comparison Tests
+///////////////////////////////////////////////////////////////////////////////
+
+func TestBlobComparison(t *testing.T) {
+	blob := new(Blob)
+	require.True(t, blob.IsZero())
+	fillBlobRandom(blob, 0)
+	require.False(t, blob.IsZero())
+
+	clone := blob.Clone()
+	require.True(t, blob.Equal(clone))
+	clone[0] ^= 1
+	require.False(t, blob.Equal(clone))
+	require.NotEqual(t, blob[0], clone[0])
+
+	var nilBlob *Blob
+	require.True(t, nilBlob.Equal(nil))
+	require.False(t, nilBlob.Equal(blob))
+	require.False(t, blob.Equal(nil))
+}
+
+func TestCommitmentAndProofComparison(t *testing.T) {
+	// The commitment and proof of an empty blob are the point at infinity.
+	commitment, err := BlobToKZGCommitment(new(Blob))
+	require.NoError(t, err)
+	proof, err := ComputeBlobKZGProof(new(Blob), Bytes48(commitment))
+	require.NoError(t, err)
+	require.True(t, commitment.IsInfinity())
+	require.False(t, commitment.IsZero())
+	require.True(t, proof.IsInfinity())
+	require.False(t, proof.IsZero())
+	require.True(t, KZGCommitment{}.IsZero())
+	require.True(t, KZGProof{}.IsZero())
+
+	var blob Blob
+	fillBlobRandom(&blob, 0)
+	otherCommitment, err := BlobToKZGCommitment(&blob)
+	require.NoError(t, err)
+	otherProof, err := ComputeBlobKZGProof(&blob, Bytes48(otherCommitment))
+	require.NoError(t, err)
+	require.False(t, otherCommitment.IsInfinity())
+
+	require.True(t, commitment.Equal(commitment))
+	require.False(t, commitment.Equal(otherCommitment))
+	require.True(t, otherProof.Equal(otherProof))
+	require.False(t, proof.Equal(otherProof))
+	require.True(t, otherProof.ConstantTimeEqual(otherProof))
+	require.False(t, proof.ConstantTimeEqual(otherProof))
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Constructor Tests
+///////////////////////////////////////////////////////////////////////////////
+
+const (
+	blsModulusHex         = "0x73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001"
+	g1GeneratorHex        = "0x97f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb"
+	g1InfinityHex         = "0xc00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"
+	invalidG1Hex          = "0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"
+	blsModulusMinusOneHex = "0x73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000000"
+)
+
+func TestNewFromHex(t *testing.T) {
+	b32, err := NewBytes32FromHex(blsModulusHex)
+	require.NoError(t, err)
+	require.Equal(t, blsModulusHex, b32.String())
+	_, err = NewBytes32FromHex("0x00")
+	require.ErrorIs(t, err, ErrBadArgs)
+
+	_, err = NewFieldElementFromHex(blsModulusHex)
+	require.ErrorIs(t, err, ErrBadArgs)
+	fieldElement, err := NewFieldElementFromHex(blsModulusMinusOneHex)
+	require.NoError(t, err)
+	require.Equal(t, blsModulusMinusOneHex, fieldElement.String())
+
+	b48, err := NewBytes48FromHex(invalidG1Hex)
+	require.NoError(t, err)
+	require.Equal(t, invalidG1Hex, b48.String())
+	_, err = NewKZGCommitmentFromHex(invalidG1Hex)
+	require.ErrorIs(t, err, ErrBadArgs)
+	_, err = NewKZGProofFromHex(invalidG1Hex)
+	require.ErrorIs(t, err, ErrBadArgs)
+	commitment, err := NewKZGCommitmentFromHex(g1GeneratorHex)
+	require.NoError(t, err)
+	require.Equal(t, g1GeneratorHex, commitment.String())
+	proof, err := NewKZGProofFromHex(g1InfinityHex)
+	require.NoError(t, err)
+	require.Equal(t, g1InfinityHex, proof.String())
+}
+
+func TestNewBlobFromHex(t *testing.T) {
+	var blob Blob
+	fillBlobRandom(&blob, 0)
+	text, err := blob.MarshalText()
+	require.NoError(t, err)
+
+	parsed, err := NewValidBlobFromHex(string(text))
+	require.NoError(t, err)
+	require.Equal(t, blob, *parsed)
+
+	// Make the last field element equal to the modulus.
+	modulus, err := NewBytes32FromHex(blsModulusHex)
+	require.NoError(t, err)
+	copy(blob[BytesPerBlob-BytesPerFieldElement:], modulus[:])
+	text, err = blob.MarshalText()
+	require.NoError(t, err)
+
+	parsed, err = NewBlobFromHex(string(text))
+	require.NoError(t, err)
+	require.Equal(t, blob, *parsed)
+	_, err = NewValidBlobFromHex(string(text))
+	require.ErrorIs(t, err, ErrBadArgs)
+	require.ErrorIs(t, ValidateBlob(parsed), ErrBadArgs)
+	require.ErrorIs(t, ValidateBlob(nil), ErrBadArgs)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Trusted Setup Tests
+///////////////////////////////////////////////////////////////////////////////
+
+func TestSaveTrustedSetup(t *testing.T) {
+	expected, err := os.ReadFile("../../src/trusted_setup.txt")
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, SaveTrustedSetup(&buf))
+	require.Equal(t, string(expected), buf.String())
+}
+
+func TestTrustedSetupBytesReload(t *testing.T) {
+	blob := Blob{}
+	fillBlobRandom(&blob, 0)
+	commitment, err := BlobToKZGCommitment(&blob)
+	require.NoError(t, err)
+
+	g1Bytes, g2Bytes := TrustedSetupBytes()
+	FreeTrustedSetup()
+	require.NoError(t, LoadTrustedSetup(g1Bytes, g2Bytes))
+
+	reloaded, err := BlobToKZGCommitment(&blob)
+	require.NoError(t, err)
+	require.Equal(t, commitment, reloaded)
+}
+
+func TestLoadTrustedSetupJSON(t *testing.T) {
+	blob := Blob{}
+	fillBlobRandom(&blob, 1)
+	commitment, err := BlobToKZGCommitment(&blob)
+	require.NoError(t, err)
+
+	g1Bytes, g2Bytes := TrustedSetupBytes()
+	toHex := func(b []byte, size int) []string {
+		var points []string
+		for i := 0; i < len(b); i += size {
+			points = append(points, "0x"+hex.EncodeToString(b[i:i+size]))
+		}
+		return points
+	}
+	trustedSetup := map[string][]string{
+		"g1_lagrange": toHex(g1Bytes, 48),
+		"g2_monomial": toHex(g2Bytes, 96),
+	}
+	data, err := json.Marshal(trustedSetup)
+	require.NoError(t, err)
+
+	FreeTrustedSetup()
+	require.NoError(t, LoadTrustedSetupJSON(data))
+	reloaded, err := BlobToKZGCommitment(&blob)
+	require.NoError(t, err)
+	require.Equal(t, commitment, reloaded)
+
+	// Failures leave the setup unloaded, so these run before reloading.
+	FreeTrustedSetup()
+	defer func() {
+		require.NoError(t, LoadTrustedSetup(g1Bytes, g2Bytes))
+	}()
+	require.Error(t, LoadTrustedSetupJSON([]byte("{")))
+	g2Monomial := trustedSetup["g2_monomial"]
+	trustedSetup["g2_monomial"] = g2Monomial[:64]
+	data, err = json.Marshal(trustedSetup)
+	require.NoError(t, err)
+	require.ErrorIs(t, LoadTrustedSetupJSON(data), ErrBadArgs)
+	trustedSetup["g2_monomial"] = g2Monomial
+	trustedSetup["g1_lagrange"][0] = "0x00"
+	data, err = json.Marshal(trustedSetup)
+	require.NoError(t, err)
+	require.ErrorIs(t, LoadTrustedSetupJSON(data), ErrBadArgs)
+}
+
+func TestLoadTrustedSetupMonomial(t *testing.T) {
+	blob := Blob{}
+	fillBlobRandom(&blob, 2)
+	commitment, err := BlobToKZGCommitment(&blob)
+	require.NoError(t, err)
+
+	g1Bytes, g2Bytes := TrustedSetupBytes()
+	g1Monomial, err := convertG1(g1Bytes, false)
+	require.NoError(t, err)
+	// The first monomial point is [s^0]G1, the generator.
+	require.Equal(t, g1GeneratorHex, "0x"+hex.EncodeToString(g1Monomial[:48]))
+
+	FreeTrustedSetup()
+	defer func() {
+		require.NoError(t, LoadTrustedSetup(g1Bytes, g2Bytes))
+	}()
+	setCacheDir(t)
+	require.ErrorIs(t, LoadTrustedSetupMonomial(g1Bytes, g2Bytes), ErrBadArgs)
+	require.NoError(t, LoadTrustedSetupMonomial(g1Monomial, g2Bytes))
+	reloaded, err := BlobToKZGCommitment(&blob)
+	require.NoError(t, err)
+	require.Equal(t, commitment, reloaded)
+	converted, _ := TrustedSetupBytes()
+	require.Equal(t, g1Bytes, converted)
+	FreeTrustedSetup()
+
+	// The second load uses the cached conversion.
+	require.NoError(t, LoadTrustedSetupMonomial(g1Monomial, g2Bytes))
+	converted, _ = TrustedSetupBytes()
+	require.Equal(t, g1Bytes, converted)
+	FreeTrustedSetup()
+
+	// An intact entry that is not the conversion of the input is recomputed
+	// and replaced. Swapping two Lagrange points keeps every point valid.
+	digest := sha256.Sum256(append(append([]byte(nil), g1Monomial...), g2Bytes...))
+	cacheName := hex.EncodeToString(digest[:]) + ".lagrange"
+	planted := append([]byte(nil), g1Bytes...)
+	copy(planted[:48], g1Bytes[48:96])
+	copy(planted[48:96], g1Bytes[:48])
+	writeCache(cacheName, planted)
+	require.NoError(t, LoadTrustedSetupMonomial(g1Monomial, g2Bytes))
+	converted, _ = TrustedSetupBytes()
+	require.Equal(t, g1Bytes, converted)
+	FreeTrustedSetup()
+	cached, ok := readCache(cacheName)
+	require.True(t, ok)
+	require.Equal(t, g1Bytes, cached)
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 // Reference Tests
 ///////////////////////////////////////////////////////////////////////////////
@@ -386,6 +750,54 @@ func TestVerifyBlobKZGProofBatch(t *testing.T) {
 	}
 }
 
+///////////////////////////////////////////////////////////////////////////////
+// Error Tests
+///////////////////////////////////////////////////////////////////////////////
+
+func TestBadArgsErrorMessages(t *testing.T) {
+	modulus, err := NewBytes32FromHex(blsModulusHex)
+	require.NoError(t, err)
+
+	var blob Blob
+	fillBlobRandom(&blob, 0)
+	commitment, err := BlobToKZGCommitment(&blob)
+	require.NoError(t, err)
+	proof, err := ComputeBlobKZGProof(&blob, Bytes48(commitment))
+	require.NoError(t, err)
+
+	badBlob := blob
+	copy(badBlob[17*BytesPerFieldElement:], modulus[:])
+	_, err = BlobToKZGCommitment(&badBlob)
+	require.ErrorIs(t, err, ErrBadArgs)
+	require.EqualError(t, err, "bad arguments: blob field element 17 is not canonical")
+
+	_, _, err = ComputeKZGProof(&blob, modulus)
+	require.ErrorIs(t, err, ErrBadArgs)
+	require.EqualError(t, err, "bad arguments: z is not canonical")
+
+	badPoint := Bytes48{0xff}
+	_, err = VerifyBlobKZGProof(&blob, Bytes48(commitment), badPoint)
+	require.ErrorIs(t, err, ErrBadArgs)
+	require.EqualError(t, err, "bad arguments: proof is not a valid G1 point")
+
+	blobs := []Blob{blob, blob, blob, badBlob}
+	commitments := []Bytes48{Bytes48(commitment), Bytes48(commitment), Bytes48(commitment), Bytes48(commitment)}
+	proofs := []Bytes48{Bytes48(proof), Bytes48(proof), Bytes48(proof), Bytes48(proof)}
+	_, err = VerifyBlobKZGProofBatch(blobs, commitments, proofs)
+	require.ErrorIs(t, err, ErrBadArgs)
+	require.EqualError(t, err, "bad arguments: blob 3 field element 17 is not canonical")
+
+	blobs[3] = blob
+	commitments[2] = badPoint
+	_, err = VerifyBlobKZGProofBatch(blobs, commitments, proofs)
+	require.ErrorIs(t, err, ErrBadArgs)
+	require.EqualError(t, err, "bad arguments: commitment 2 is not a valid G1 point")
+
+	// Mismatched lengths are not attributed to any input.
+	_, err = VerifyBlobKZGProofBatch(blobs, commitments[:1], proofs)
+	require.Equal(t, ErrBadArgs, err)
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 // Benchmarks
 ///////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,300 @@
+/*
+Package mobile wraps ckzg4844 in an API that gomobile bind can export to Java
+and Objective-C, for light clients on Android and iOS:
+
+	gomobile bind -target=android github.com/ethereum/c-kzg-4844/bindings/go/mobile
+	gomobile bind -target=ios github.com/ethereum/c-kzg-4844/bindings/go/mobile
+
+Values are byte slices, which gomobile maps to byte[] and NSData, and the
+lists of VerifyBlobKZGProofBatch are concatenated. Nothing panics: using the
+trusted setup before it is loaded returns ErrNotLoaded. Loading and freeing
+the setup wait for running calls, so the functions are safe to call from any
+thread.
+
+Applications bundle the trusted setup as an asset, in the text or JSON
+format, and pass its contents to LoadTrustedSetup.
+*/
+package mobile
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+)
+
+const (
+	BytesPerBlob         = ckzg4844.BytesPerBlob
+	BytesPerCommitment   = ckzg4844.BytesPerCommitment
+	BytesPerFieldElement = ckzg4844.BytesPerFieldElement
+	BytesPerProof        = ckzg4844.BytesPerProof
+)
+
+var (
+	ErrNotLoaded     = errors.New("trusted setup is not loaded")
+	ErrAlreadyLoaded = errors.New("trusted setup is already loaded")
+)
+
+var (
+	// mu is held for writing while the trusted setup is loaded or freed, and
+	// for reading by the operations.
+	mu     sync.RWMutex
+	loaded bool
+)
+
+// ProofAndY is the result of ComputeKZGProof.
+type ProofAndY struct {
+	Proof []byte
+	Y     []byte
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Helper Functions
+///////////////////////////////////////////////////////////////////////////////
+
+// checkLength returns ErrBadArgs, with the name of the argument, if value is
+// not size bytes long.
+func checkLength(name string, value []byte, size int) error {
+	if len(value) != size {
+		return fmt.Errorf("%w: %v is %v bytes, not %v", ckzg4844.ErrBadArgs, name, len(value), size)
+	}
+	return nil
+}
+
+func toBlob(blob []byte) (*ckzg4844.Blob, error) {
+	if err := checkLength("blob", blob, BytesPerBlob); err != nil {
+		return nil, err
+	}
+	return (*ckzg4844.Blob)(blob), nil
+}
+
+func toBytes32(name string, value []byte) (ckzg4844.Bytes32, error) {
+	var b ckzg4844.Bytes32
+	if err := checkLength(name, value, len(b)); err != nil {
+		return b, err
+	}
+	copy(b[:], value)
+	return b, nil
+}
+
+func toBytes48(name string, value []byte) (ckzg4844.Bytes48, error) {
+	var b ckzg4844.Bytes48
+	if err := checkLength(name, value, len(b)); err != nil {
+		return b, err
+	}
+	copy(b[:], value)
+	return b, nil
+}
+
+// split cuts a concatenated list into values of size bytes.
+func split(name string, list []byte, size int) ([][]byte, error) {
+	if len(list)%size != 0 {
+		return nil, fmt.Errorf("%w: %v are %v bytes, not a multiple of %v", ckzg4844.ErrBadArgs, name, len(list), size)
+	}
+	values := make([][]byte, len(list)/size)
+	for i := range values {
+		values[i] = list[i*size : (i+1)*size]
+	}
+	return values, nil
+}
+
+// withSetup runs f while holding the trusted setup, or returns ErrNotLoaded.
+func withSetup(f func() error) error {
+	mu.RLock()
+	defer mu.RUnlock()
+	if !loaded {
+		return ErrNotLoaded
+	}
+	return f()
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Interface Functions
+///////////////////////////////////////////////////////////////////////////////
+
+// LoadTrustedSetup loads a trusted setup in the text format of
+// src/trusted_setup.txt or in the JSON format of the KZG ceremony.
+func LoadTrustedSetup(data []byte) error {
+	mu.Lock()
+	defer mu.Unlock()
+	if loaded {
+		return ErrAlreadyLoaded
+	}
+	var err error
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		err = ckzg4844.LoadTrustedSetupJSON(data)
+	} else {
+		err = ckzg4844.LoadTrustedSetupText(data)
+	}
+	if err == nil {
+		loaded = true
+	}
+	return err
+}
+
+// IsTrustedSetupLoaded reports whether a trusted setup is loaded.
+func IsTrustedSetupLoaded() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return loaded
+}
+
+// FreeTrustedSetup frees the trusted setup, after the running calls return.
+// It does nothing if no trusted setup is loaded.
+func FreeTrustedSetup() {
+	mu.Lock()
+	defer mu.Unlock()
+	if loaded {
+		ckzg4844.FreeTrustedSetup()
+		loaded = false
+	}
+}
+
+// BlobToKZGCommitment returns the commitment to a blob.
+func BlobToKZGCommitment(blob []byte) ([]byte, error) {
+	b, err := toBlob(blob)
+	if err != nil {
+		return nil, err
+	}
+	var commitment ckzg4844.KZGCommitment
+	err = withSetup(func() (err error) {
+		commitment, err = ckzg4844.BlobToKZGCommitment(b)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return commitment[:], nil
+}
+
+// ComputeKZGProof returns the proof of the evaluation of a blob at z, and
+// the evaluation.
+func ComputeKZGProof(blob, z []byte) (*ProofAndY, error) {
+	b, err := toBlob(blob)
+	if err != nil {
+		return nil, err
+	}
+	zBytes, err := toBytes32("z", z)
+	if err != nil {
+		return nil, err
+	}
+	var proof ckzg4844.KZGProof
+	var y ckzg4844.Bytes32
+	err = withSetup(func() (err error) {
+		proof, y, err = ckzg4844.ComputeKZGProof(b, zBytes)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ProofAndY{Proof: proof[:], Y: y[:]}, nil
+}
+
+// ComputeBlobKZGProof returns the proof of a blob for its commitment.
+func ComputeBlobKZGProof(blob, commitment []byte) ([]byte, error) {
+	b, err := toBlob(blob)
+	if err != nil {
+		return nil, err
+	}
+	commitmentBytes, err := toBytes48("commitment", commitment)
+	if err != nil {
+		return nil, err
+	}
+	var proof ckzg4844.KZGProof
+	err = withSetup(func() (err error) {
+		proof, err = ckzg4844.ComputeBlobKZGProof(b, commitmentBytes)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return proof[:], nil
+}
+
+// VerifyKZGProof reports whether proof shows that the blob committed to
+// evaluates to y at z.
+func VerifyKZGProof(commitment, z, y, proof []byte) (bool, error) {
+	commitmentBytes, err := toBytes48("commitment", commitment)
+	if err != nil {
+		return false, err
+	}
+	zBytes, err := toBytes32("z", z)
+	if err != nil {
+		return false, err
+	}
+	yBytes, err := toBytes32("y", y)
+	if err != nil {
+		return false, err
+	}
+	proofBytes, err := toBytes48("proof", proof)
+	if err != nil {
+		return false, err
+	}
+	var valid bool
+	err = withSetup(func() (err error) {
+		valid, err = ckzg4844.VerifyKZGProof(commitmentBytes, zBytes, yBytes, proofBytes)
+		return err
+	})
+	return valid, err
+}
+
+// VerifyBlobKZGProof reports whether proof is the proof of a blob for its
+// commitment.
+func VerifyBlobKZGProof(blob, commitment, proof []byte) (bool, error) {
+	b, err := toBlob(blob)
+	if err != nil {
+		return false, err
+	}
+	commitmentBytes, err := toBytes48("commitment", commitment)
+	if err != nil {
+		return false, err
+	}
+	proofBytes, err := toBytes48("proof", proof)
+	if err != nil {
+		return false, err
+	}
+	var valid bool
+	err = withSetup(func() (err error) {
+		valid, err = ckzg4844.VerifyBlobKZGProof(b, commitmentBytes, proofBytes)
+		return err
+	})
+	return valid, err
+}
+
+// VerifyBlobKZGProofBatch is VerifyBlobKZGProof for several blobs at once.
+// The blobs, commitments and proofs are each concatenated, and their counts
+// must match.
+func VerifyBlobKZGProofBatch(blobs, commitments, proofs []byte) (bool, error) {
+	blobList, err := split("blobs", blobs, BytesPerBlob)
+	if err != nil {
+		return false, err
+	}
+	commitmentList, err := split("commitments", commitments, BytesPerCommitment)
+	if err != nil {
+		return false, err
+	}
+	proofList, err := split("proofs", proofs, BytesPerProof)
+	if err != nil {
+		return false, err
+	}
+	if len(commitmentList) != len(blobList) || len(proofList) != len(blobList) {
+		return false, fmt.Errorf("%w: %v blobs, %v commitments and %v proofs",
+			ckzg4844.ErrBadArgs, len(blobList), len(commitmentList), len(proofList))
+	}
+	blobValues := make([]ckzg4844.Blob, len(blobList))
+	commitmentValues := make([]ckzg4844.Bytes48, len(blobList))
+	proofValues := make([]ckzg4844.Bytes48, len(blobList))
+	for i := range blobList {
+		copy(blobValues[i][:], blobList[i])
+		copy(commitmentValues[i][:], commitmentList[i])
+		copy(proofValues[i][:], proofList[i])
+	}
+	var valid bool
+	err = withSetup(func() (err error) {
+		valid, err = ckzg4844.VerifyBlobKZGProofBatch(blobValues, commitmentValues, proofValues)
+		return err
+	})
+	return valid, err
+}
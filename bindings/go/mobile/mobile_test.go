@@ -0,0 +1,86 @@
+package mobile
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"testing"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+	"github.com/ethereum/c-kzg-4844/bindings/go/ckzgtest"
+	"github.com/stretchr/testify/require"
+)
+
+const trustedSetupFile = "../../../src/trusted_setup.txt"
+
+func TestMain(m *testing.M) {
+	data, err := os.ReadFile(trustedSetupFile)
+	if err == nil {
+		err = LoadTrustedSetup(data)
+	}
+	if err != nil {
+		panic(fmt.Sprintf("failed to load trusted setup: %v", err))
+	}
+	defer FreeTrustedSetup()
+	os.Exit(m.Run())
+}
+
+func TestProveAndVerify(t *testing.T) {
+	blobs, _, _ := ckzgtest.RandomBundle(0, 2)
+	var allBlobs, commitments, proofs []byte
+	for i := range blobs {
+		commitment, err := BlobToKZGCommitment(blobs[i][:])
+		require.NoError(t, err)
+		proof, err := ComputeBlobKZGProof(blobs[i][:], commitment)
+		require.NoError(t, err)
+		valid, err := VerifyBlobKZGProof(blobs[i][:], commitment, proof)
+		require.NoError(t, err)
+		require.True(t, valid)
+		allBlobs = append(allBlobs, blobs[i][:]...)
+		commitments = append(commitments, commitment...)
+		proofs = append(proofs, proof...)
+	}
+	valid, err := VerifyBlobKZGProofBatch(allBlobs, commitments, proofs)
+	require.NoError(t, err)
+	require.True(t, valid)
+	valid, err = VerifyBlobKZGProofBatch(allBlobs, commitments, append(proofs[BytesPerProof:], proofs[:BytesPerProof]...))
+	require.NoError(t, err)
+	require.False(t, valid)
+
+	z := ckzgtest.RandomFieldElement(0)
+	result, err := ComputeKZGProof(blobs[0][:], z[:])
+	require.NoError(t, err)
+	valid, err = VerifyKZGProof(commitments[:BytesPerCommitment], z[:], result.Y, result.Proof)
+	require.NoError(t, err)
+	require.True(t, valid)
+}
+
+func TestErrors(t *testing.T) {
+	blob := ckzgtest.RandomBlob(0)
+	_, err := BlobToKZGCommitment(blob[:100])
+	require.ErrorIs(t, err, ckzg4844.ErrBadArgs)
+	_, err = ComputeBlobKZGProof(blob[:], nil)
+	require.ErrorIs(t, err, ckzg4844.ErrBadArgs)
+	_, err = VerifyBlobKZGProofBatch(blob[:], nil, nil)
+	require.ErrorIs(t, err, ckzg4844.ErrBadArgs)
+	_, err = VerifyBlobKZGProofBatch(append(blob[:], 0), nil, nil)
+	require.ErrorIs(t, err, ckzg4844.ErrBadArgs)
+	valid, err := VerifyBlobKZGProofBatch(nil, nil, nil)
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	data, err := os.ReadFile(trustedSetupFile)
+	require.NoError(t, err)
+	require.ErrorIs(t, LoadTrustedSetup(data), ErrAlreadyLoaded)
+
+	// Without a setup, the operations fail rather than panic.
+	FreeTrustedSetup()
+	defer func() {
+		require.NoError(t, LoadTrustedSetup(data))
+	}()
+	require.False(t, IsTrustedSetupLoaded())
+	_, err = BlobToKZGCommitment(blob[:])
+	require.ErrorIs(t, err, ErrNotLoaded)
+	require.ErrorIs(t, LoadTrustedSetup(bytes.Repeat([]byte{'0'}, 100)), ckzg4844.ErrBadArgs)
+	require.False(t, IsTrustedSetupLoaded())
+}
@@ -0,0 +1,141 @@
+// Package pb contains the protobuf messages defined in ckzg.proto and
+// converters between them and the ckzg4844 types.
+package pb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative ckzg.proto
+
+import (
+	"fmt"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// Helper Functions
+///////////////////////////////////////////////////////////////////////////////
+
+func copyExact(dst, src []byte, name string) error {
+	if len(src) != len(dst) {
+		return fmt.Errorf("%w: %v must be %v bytes, got %v", ckzg4844.ErrBadArgs, name, len(dst), len(src))
+	}
+	copy(dst, src)
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// To Protobuf
+///////////////////////////////////////////////////////////////////////////////
+
+func BlobToProto(blob *ckzg4844.Blob) *Blob {
+	return &Blob{Data: append([]byte(nil), blob[:]...)}
+}
+
+func CommitmentToProto(commitment ckzg4844.Bytes48) *Commitment {
+	return &Commitment{Data: append([]byte(nil), commitment[:]...)}
+}
+
+func ProofToProto(proof ckzg4844.Bytes48) *Proof {
+	return &Proof{Data: append([]byte(nil), proof[:]...)}
+}
+
+func FieldElementToProto(fieldElement ckzg4844.Bytes32) *FieldElement {
+	return &FieldElement{Data: append([]byte(nil), fieldElement[:]...)}
+}
+
+func BlobSidecarToProto(blob *ckzg4844.Blob, commitment, proof ckzg4844.Bytes48) *BlobSidecar {
+	return &BlobSidecar{
+		Blob:       BlobToProto(blob),
+		Commitment: CommitmentToProto(commitment),
+		Proof:      ProofToProto(proof),
+	}
+}
+
+// BlobsBundleToProto converts index-aligned blobs, commitments and proofs.
+// The slices must have equal lengths.
+func BlobsBundleToProto(blobs []ckzg4844.Blob, commitments, proofs []ckzg4844.Bytes48) (*BlobsBundle, error) {
+	if len(blobs) != len(commitments) || len(blobs) != len(proofs) {
+		return nil, ckzg4844.ErrBadArgs
+	}
+	bundle := &BlobsBundle{
+		Blobs:       make([]*Blob, len(blobs)),
+		Commitments: make([]*Commitment, len(blobs)),
+		Proofs:      make([]*Proof, len(blobs)),
+	}
+	for i := range blobs {
+		bundle.Blobs[i] = BlobToProto(&blobs[i])
+		bundle.Commitments[i] = CommitmentToProto(commitments[i])
+		bundle.Proofs[i] = ProofToProto(proofs[i])
+	}
+	return bundle, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// From Protobuf
+///////////////////////////////////////////////////////////////////////////////
+
+func BlobFromProto(m *Blob) (*ckzg4844.Blob, error) {
+	blob := new(ckzg4844.Blob)
+	if err := copyExact(blob[:], m.GetData(), "blob"); err != nil {
+		return nil, err
+	}
+	return blob, nil
+}
+
+func CommitmentFromProto(m *Commitment) (ckzg4844.Bytes48, error) {
+	var commitment ckzg4844.Bytes48
+	err := copyExact(commitment[:], m.GetData(), "commitment")
+	return commitment, err
+}
+
+func ProofFromProto(m *Proof) (ckzg4844.Bytes48, error) {
+	var proof ckzg4844.Bytes48
+	err := copyExact(proof[:], m.GetData(), "proof")
+	return proof, err
+}
+
+func FieldElementFromProto(m *FieldElement) (ckzg4844.Bytes32, error) {
+	var fieldElement ckzg4844.Bytes32
+	err := copyExact(fieldElement[:], m.GetData(), "field element")
+	return fieldElement, err
+}
+
+func BlobSidecarFromProto(m *BlobSidecar) (*ckzg4844.Blob, ckzg4844.Bytes48, ckzg4844.Bytes48, error) {
+	blob, err := BlobFromProto(m.GetBlob())
+	if err != nil {
+		return nil, ckzg4844.Bytes48{}, ckzg4844.Bytes48{}, err
+	}
+	commitment, err := CommitmentFromProto(m.GetCommitment())
+	if err != nil {
+		return nil, ckzg4844.Bytes48{}, ckzg4844.Bytes48{}, err
+	}
+	proof, err := ProofFromProto(m.GetProof())
+	if err != nil {
+		return nil, ckzg4844.Bytes48{}, ckzg4844.Bytes48{}, err
+	}
+	return blob, commitment, proof, nil
+}
+
+// BlobsBundleFromProto converts a bundle back into slices suitable for
+// ckzg4844.VerifyBlobKZGProofBatch. The repeated fields must have equal
+// lengths.
+func BlobsBundleFromProto(m *BlobsBundle) ([]ckzg4844.Blob, []ckzg4844.Bytes48, []ckzg4844.Bytes48, error) {
+	n := len(m.GetBlobs())
+	if len(m.GetCommitments()) != n || len(m.GetProofs()) != n {
+		return nil, nil, nil, ckzg4844.ErrBadArgs
+	}
+	blobs := make([]ckzg4844.Blob, n)
+	commitments := make([]ckzg4844.Bytes48, n)
+	proofs := make([]ckzg4844.Bytes48, n)
+	for i := 0; i < n; i++ {
+		if err := copyExact(blobs[i][:], m.Blobs[i].GetData(), fmt.Sprintf("blob %v", i)); err != nil {
+			return nil, nil, nil, err
+		}
+		if err := copyExact(commitments[i][:], m.Commitments[i].GetData(), fmt.Sprintf("commitment %v", i)); err != nil {
+			return nil, nil, nil, err
+		}
+		if err := copyExact(proofs[i][:], m.Proofs[i].GetData(), fmt.Sprintf("proof %v", i)); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	return blobs, commitments, proofs, nil
+}
@@ -0,0 +1,61 @@
+package pb
+
+import (
+	"math/rand"
+	"testing"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestBlobsBundleRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	blobs := make([]ckzg4844.Blob, 2)
+	commitments := make([]ckzg4844.Bytes48, 2)
+	proofs := make([]ckzg4844.Bytes48, 2)
+	for i := range blobs {
+		r.Read(blobs[i][:])
+		r.Read(commitments[i][:])
+		r.Read(proofs[i][:])
+	}
+
+	bundle, err := BlobsBundleToProto(blobs, commitments, proofs)
+	require.NoError(t, err)
+	data, err := proto.Marshal(bundle)
+	require.NoError(t, err)
+
+	decoded := new(BlobsBundle)
+	require.NoError(t, proto.Unmarshal(data, decoded))
+	gotBlobs, gotCommitments, gotProofs, err := BlobsBundleFromProto(decoded)
+	require.NoError(t, err)
+	require.Equal(t, blobs, gotBlobs)
+	require.Equal(t, commitments, gotCommitments)
+	require.Equal(t, proofs, gotProofs)
+}
+
+func TestBlobSidecarRoundTrip(t *testing.T) {
+	blob := new(ckzg4844.Blob)
+	rand.New(rand.NewSource(1)).Read(blob[:])
+	commitment := ckzg4844.Bytes48{1}
+	proof := ckzg4844.Bytes48{2}
+
+	gotBlob, gotCommitment, gotProof, err := BlobSidecarFromProto(BlobSidecarToProto(blob, commitment, proof))
+	require.NoError(t, err)
+	require.Equal(t, blob, gotBlob)
+	require.Equal(t, commitment, gotCommitment)
+	require.Equal(t, proof, gotProof)
+}
+
+func TestFromProtoInvalidLength(t *testing.T) {
+	_, err := CommitmentFromProto(&Commitment{Data: make([]byte, 47)})
+	require.ErrorIs(t, err, ckzg4844.ErrBadArgs)
+	_, err = BlobFromProto(nil)
+	require.ErrorIs(t, err, ckzg4844.ErrBadArgs)
+	_, err = FieldElementFromProto(&FieldElement{Data: make([]byte, 33)})
+	require.ErrorIs(t, err, ckzg4844.ErrBadArgs)
+
+	bundle := &BlobsBundle{Blobs: []*Blob{{}}}
+	_, _, _, err = BlobsBundleFromProto(bundle)
+	require.ErrorIs(t, err, ckzg4844.ErrBadArgs)
+}
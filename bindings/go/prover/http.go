@@ -0,0 +1,101 @@
+package prover
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+)
+
+// maxSubmitSize bounds the size of the body of a submission, a hex blob.
+const maxSubmitSize = 2*ckzg4844.BytesPerBlob + 1024
+
+type submitRequest struct {
+	Blob *ckzg4844.Blob `json:"blob"`
+}
+
+type jobResponse struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	*Result
+	Error string `json:"error,omitempty"`
+}
+
+/*
+Handler serves the jobs of p over HTTP:
+
+	POST /jobs {"blob"} submits a blob
+	GET /jobs/<id> returns the state of a job
+
+Both respond with the id of the job, its status, which is "pending", "done"
+or "failed", and its result or error. The status code is 200 once the job is
+done and 202 while it is pending. Submitting the same blob again is
+idempotent: it returns the same job, and its result if it is done.
+*/
+func Handler(p *Prover) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/jobs", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var request submitRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSubmitSize)).Decode(&request); err != nil || request.Blob == nil {
+			http.Error(w, "malformed request: expected {\"blob\": <hex>}", http.StatusBadRequest)
+			return
+		}
+		id, err := p.Submit(request.Blob)
+		switch {
+		case errors.Is(err, ckzg4844.ErrBadArgs):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		case errors.Is(err, ErrClosed):
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJob(w, p, id)
+	})
+	mux.HandleFunc("/jobs/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		writeJob(w, p, strings.TrimPrefix(r.URL.Path, "/jobs/"))
+	})
+	return mux
+}
+
+// writeJob responds with the state of the job id.
+func writeJob(w http.ResponseWriter, p *Prover, id string) {
+	result, err := p.Result(id)
+	response := jobResponse{ID: id}
+	status := http.StatusOK
+	switch {
+	case err == nil:
+		response.Status = "done"
+		response.Result = &result
+	case errors.Is(err, ErrUnknownJob):
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	case errors.Is(err, ErrPending):
+		response.Status = "pending"
+		status = http.StatusAccepted
+	case errors.Is(err, ErrClosed):
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	default:
+		response.Status = "failed"
+		response.Error = err.Error()
+		status = http.StatusInternalServerError
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(response)
+}
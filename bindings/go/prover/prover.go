@@ -0,0 +1,358 @@
+// Package prover implements a proving service that does not lose work across
+// restarts. Every accepted blob is written to a directory before it is
+// proven, and every result is written there once computed, so a restarted
+// prover resumes the jobs that were in progress and serves the results of
+// completed ones. Jobs are identified by the SHA-256 digest of their blob,
+// which makes submitting the same blob again idempotent.
+//
+// The C library computes commitments and blob proofs on the CPU only; the
+// number of jobs proven at once is the only setting.
+package prover
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+)
+
+var (
+	ErrUnknownJob = errors.New("unknown job")
+	ErrPending    = errors.New("job is not complete")
+	ErrClosed     = errors.New("prover is closed")
+)
+
+const (
+	blobSuffix   = ".blob"
+	resultSuffix = ".json"
+	tempSuffix   = ".tmp"
+)
+
+// Result is the outcome of a completed job.
+type Result struct {
+	Commitment    ckzg4844.KZGCommitment `json:"commitment"`
+	Proof         ckzg4844.KZGProof      `json:"proof"`
+	VersionedHash ckzg4844.Bytes32       `json:"versioned_hash"`
+}
+
+// job is a job submitted since the prover started. done is closed once
+// result or err is set; err is ErrClosed if the prover was closed before the
+// job was proven.
+type job struct {
+	done   chan struct{}
+	result Result
+	err    error
+}
+
+// Prover proves the blobs submitted to it, persisting jobs in a directory.
+type Prover struct {
+	backend   ckzg4844.Backend
+	dir       string
+	semaphore chan struct{}
+	stop      chan struct{}
+	wg        sync.WaitGroup
+
+	mu     sync.Mutex
+	jobs   map[string]*job
+	closed bool
+}
+
+// JobID returns the identifier of the job proving blob: the hex SHA-256
+// digest of the blob.
+func JobID(blob *ckzg4844.Blob) string {
+	digest := sha256.Sum256(blob[:])
+	return hex.EncodeToString(digest[:])
+}
+
+// validID reports whether id is formatted as returned by JobID, so it is safe
+// to use as a file name.
+func validID(id string) bool {
+	if len(id) != 2*sha256.Size || strings.ToLower(id) != id {
+		return false
+	}
+	_, err := hex.DecodeString(id)
+	return err == nil
+}
+
+/*
+Open starts a prover persisting its jobs in dir, which is created if needed,
+and proving at most workers blobs at once, or runtime.NumCPU() if workers is
+zero. The trusted setup of backend must be loaded. The jobs left pending in
+dir by a previous prover are resumed.
+*/
+func Open(backend ckzg4844.Backend, dir string, workers int) (*Prover, error) {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	p := &Prover{
+		backend:   backend,
+		dir:       dir,
+		semaphore: make(chan struct{}, workers),
+		stop:      make(chan struct{}),
+		jobs:      map[string]*job{},
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasSuffix(name, tempSuffix) {
+			_ = os.Remove(filepath.Join(dir, name))
+			continue
+		}
+		id := strings.TrimSuffix(name, blobSuffix)
+		if !strings.HasSuffix(name, blobSuffix) || !validID(id) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		blob := new(ckzg4844.Blob)
+		if err := blob.UnmarshalBinary(data); err != nil || JobID(blob) != id {
+			// The file was not written by a prover; drop it.
+			_ = os.Remove(filepath.Join(dir, name))
+			continue
+		}
+		p.start(id, blob)
+	}
+	return p, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Interface Functions
+///////////////////////////////////////////////////////////////////////////////
+
+/*
+Submit accepts a job proving blob and returns its identifier. The blob is
+written to disk before Submit returns, so the job survives a restart.
+Submitting a blob that is pending or already proven returns the same
+identifier without doing any work. A blob with a non-canonical field
+element is rejected.
+*/
+func (p *Prover) Submit(blob *ckzg4844.Blob) (string, error) {
+	if err := ckzg4844.ValidateBlob(blob); err != nil {
+		return "", err
+	}
+	id := JobID(blob)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return "", ErrClosed
+	}
+	if j, ok := p.jobs[id]; ok && !j.failed() {
+		return id, nil
+	}
+	if _, err := os.Stat(p.path(id, resultSuffix)); err == nil {
+		return id, nil
+	}
+	data, _ := blob.MarshalBinary()
+	if err := p.write(id, blobSuffix, data); err != nil {
+		return "", err
+	}
+	p.startLocked(id, blob.Clone())
+	return id, nil
+}
+
+/*
+Result returns the result of the job id. It returns ErrPending if the job
+is not complete, ErrUnknownJob if no such job was submitted, ErrClosed if the
+prover was closed before the job was proven, and the error of the job if
+proving failed. Failed jobs are retried when submitted again.
+*/
+func (p *Prover) Result(id string) (Result, error) {
+	p.mu.Lock()
+	j, ok := p.jobs[id]
+	p.mu.Unlock()
+	if ok {
+		select {
+		case <-j.done:
+			return j.result, j.err
+		default:
+			return Result{}, ErrPending
+		}
+	}
+	return p.readResult(id)
+}
+
+// Wait waits for the job id to complete and returns its result as Result
+// does, or ctx's error if it is done first.
+func (p *Prover) Wait(ctx context.Context, id string) (Result, error) {
+	p.mu.Lock()
+	j, ok := p.jobs[id]
+	p.mu.Unlock()
+	if !ok {
+		return p.readResult(id)
+	}
+	select {
+	case <-j.done:
+		return j.result, j.err
+	case <-ctx.Done():
+		return Result{}, ctx.Err()
+	}
+}
+
+// Close stops accepting jobs and waits for those being proven. The jobs not
+// started yet complete with ErrClosed, but stay on disk and are resumed by
+// the next prover opened on the same directory.
+func (p *Prover) Close() {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.stop)
+	}
+	p.mu.Unlock()
+	p.wg.Wait()
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Jobs
+///////////////////////////////////////////////////////////////////////////////
+
+// failed reports whether the job completed with an error.
+func (j *job) failed() bool {
+	select {
+	case <-j.done:
+		return j.err != nil
+	default:
+		return false
+	}
+}
+
+func (p *Prover) start(id string, blob *ckzg4844.Blob) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.startLocked(id, blob)
+}
+
+// startLocked proves blob in the background, when a worker is free.
+func (p *Prover) startLocked(id string, blob *ckzg4844.Blob) {
+	j := &job{done: make(chan struct{})}
+	p.jobs[id] = j
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		defer close(j.done)
+		select {
+		case p.semaphore <- struct{}{}:
+		case <-p.stop:
+			j.err = ErrClosed
+			return
+		}
+		defer func() { <-p.semaphore }()
+		select {
+		case <-p.stop:
+			j.err = ErrClosed
+			return
+		default:
+		}
+		j.result, j.err = p.prove(id, blob)
+	}()
+}
+
+// prove computes the result of a job and persists it. Once the result is
+// written the blob is no longer needed.
+func (p *Prover) prove(id string, blob *ckzg4844.Blob) (Result, error) {
+	commitment, err := p.backend.BlobToKZGCommitment(blob)
+	if err != nil {
+		return Result{}, err
+	}
+	proof, err := p.backend.ComputeBlobKZGProof(blob, ckzg4844.Bytes48(commitment))
+	if err != nil {
+		return Result{}, err
+	}
+	result := Result{Commitment: commitment, Proof: proof, VersionedHash: ckzg4844.KZGToVersionedHash(commitment)}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return Result{}, err
+	}
+	if err := p.write(id, resultSuffix, data); err != nil {
+		return Result{}, err
+	}
+	_ = os.Remove(p.path(id, blobSuffix))
+	return result, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Persistence
+///////////////////////////////////////////////////////////////////////////////
+
+func (p *Prover) path(id, suffix string) string {
+	return filepath.Join(p.dir, id+suffix)
+}
+
+// write atomically and durably stores the file of a job.
+func (p *Prover) write(id, suffix string, data []byte) error {
+	f, err := os.CreateTemp(p.dir, id+"-*"+tempSuffix)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(f.Name(), p.path(id, suffix)); err != nil {
+		return err
+	}
+	return syncDir(p.dir)
+}
+
+// syncDir flushes the entries of dir, so that a file renamed into it
+// survives a crash. Windows cannot sync directories, and makes renames
+// durable by itself.
+func syncDir(dir string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	err = d.Sync()
+	if closeErr := d.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// readResult reads the persisted result of the job id.
+func (p *Prover) readResult(id string) (Result, error) {
+	if !validID(id) {
+		return Result{}, ErrUnknownJob
+	}
+	data, err := os.ReadFile(p.path(id, resultSuffix))
+	if errors.Is(err, os.ErrNotExist) {
+		if _, err := os.Stat(p.path(id, blobSuffix)); err == nil {
+			return Result{}, ErrPending
+		}
+		return Result{}, ErrUnknownJob
+	}
+	if err != nil {
+		return Result{}, err
+	}
+	var result Result
+	if err := json.Unmarshal(data, &result); err != nil {
+		return Result{}, fmt.Errorf("corrupted result of job %v: %w", id, err)
+	}
+	return result, nil
+}
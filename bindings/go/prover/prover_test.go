@@ -0,0 +1,203 @@
+package prover
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+	"github.com/ethereum/c-kzg-4844/bindings/go/ckzgtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	if err := ckzg4844.LoadTrustedSetupFile("../../../src/trusted_setup.txt"); err != nil {
+		panic(fmt.Sprintf("failed to load trusted setup: %v", err))
+	}
+	defer ckzg4844.FreeTrustedSetup()
+	os.Exit(m.Run())
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Helper Functions
+///////////////////////////////////////////////////////////////////////////////
+
+// gatedBackend holds every commitment until gate is closed.
+type gatedBackend struct {
+	ckzg4844.Backend
+	started chan struct{}
+	gate    chan struct{}
+}
+
+func (b *gatedBackend) BlobToKZGCommitment(blob *ckzg4844.Blob) (ckzg4844.KZGCommitment, error) {
+	b.started <- struct{}{}
+	<-b.gate
+	return b.Backend.BlobToKZGCommitment(blob)
+}
+
+// expectedResult computes the result of a job proving blob.
+func expectedResult(t *testing.T, blob *ckzg4844.Blob) Result {
+	commitment, err := ckzg4844.BlobToKZGCommitment(blob)
+	require.NoError(t, err)
+	proof, err := ckzg4844.ComputeBlobKZGProof(blob, ckzg4844.Bytes48(commitment))
+	require.NoError(t, err)
+	return Result{Commitment: commitment, Proof: proof, VersionedHash: ckzg4844.KZGToVersionedHash(commitment)}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Tests
+///////////////////////////////////////////////////////////////////////////////
+
+func TestSubmit(t *testing.T) {
+	dir := t.TempDir()
+	p, err := Open(ckzg4844.DefaultBackend, dir, 2)
+	require.NoError(t, err)
+	blob := ckzgtest.RandomBlob(1)
+
+	id, err := p.Submit(blob)
+	require.NoError(t, err)
+	require.Equal(t, JobID(blob), id)
+	result, err := p.Wait(context.Background(), id)
+	require.NoError(t, err)
+	require.Equal(t, expectedResult(t, blob), result)
+	again, err := p.Submit(blob)
+	require.NoError(t, err)
+	require.Equal(t, id, again)
+	p.Close()
+
+	// The result is served after a restart, without proving again.
+	_, err = os.Stat(filepath.Join(dir, id+blobSuffix))
+	require.ErrorIs(t, err, os.ErrNotExist)
+	p, err = Open(ckzg4844.DefaultBackend, dir, 2)
+	require.NoError(t, err)
+	defer p.Close()
+	got, err := p.Result(id)
+	require.NoError(t, err)
+	require.Equal(t, result, got)
+
+	_, err = p.Result(JobID(ckzgtest.RandomBlob(2)))
+	require.ErrorIs(t, err, ErrUnknownJob)
+	_, err = p.Result("../" + id)
+	require.ErrorIs(t, err, ErrUnknownJob)
+	_, err = p.Submit(ckzgtest.CorruptFieldElement(ckzgtest.RandomBlob(4), 0))
+	require.ErrorIs(t, err, ckzg4844.ErrBadArgs)
+}
+
+func TestResume(t *testing.T) {
+	dir := t.TempDir()
+	backend := &gatedBackend{Backend: ckzg4844.DefaultBackend, started: make(chan struct{}, 2), gate: make(chan struct{})}
+	p, err := Open(backend, dir, 1)
+	require.NoError(t, err)
+	first, second := ckzgtest.RandomBlob(1), ckzgtest.RandomBlob(2)
+	firstID, err := p.Submit(first)
+	require.NoError(t, err)
+	<-backend.started
+	secondID, err := p.Submit(second)
+	require.NoError(t, err)
+	_, err = p.Result(secondID)
+	require.ErrorIs(t, err, ErrPending)
+
+	// Close waits for the first job, while the second is never started.
+	closed := make(chan struct{})
+	go func() { p.Close(); close(closed) }()
+	require.Eventually(t, func() bool {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		return p.closed
+	}, time.Minute, time.Millisecond)
+	_, err = p.Submit(second)
+	require.ErrorIs(t, err, ErrClosed)
+	close(backend.gate)
+	<-closed
+	_, err = os.Stat(filepath.Join(dir, secondID+blobSuffix))
+	require.NoError(t, err)
+	_, err = p.Wait(context.Background(), secondID)
+	require.ErrorIs(t, err, ErrClosed)
+	_, err = p.Result(secondID)
+	require.ErrorIs(t, err, ErrClosed)
+
+	// A leftover temporary file and a blob that does not match its name.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, firstID+"-1"+tempSuffix), nil, 0o644))
+	mismatched := filepath.Join(dir, JobID(ckzgtest.RandomBlob(3))+blobSuffix)
+	require.NoError(t, os.WriteFile(mismatched, first[:], 0o644))
+
+	p, err = Open(ckzg4844.DefaultBackend, dir, 1)
+	require.NoError(t, err)
+	defer p.Close()
+	result, err := p.Result(firstID)
+	require.NoError(t, err)
+	require.Equal(t, expectedResult(t, first), result)
+	result, err = p.Wait(context.Background(), secondID)
+	require.NoError(t, err)
+	require.Equal(t, expectedResult(t, second), result)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+}
+
+func TestHandler(t *testing.T) {
+	p, err := Open(ckzg4844.DefaultBackend, t.TempDir(), 1)
+	require.NoError(t, err)
+	defer p.Close()
+	server := httptest.NewServer(Handler(p))
+	defer server.Close()
+	blob := ckzgtest.RandomBlob(1)
+	hexBlob, err := blob.MarshalText()
+	require.NoError(t, err)
+
+	submit := func(body string) (int, jobResponse) {
+		resp, err := http.Post(server.URL+"/jobs", "application/json", strings.NewReader(body))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var job jobResponse
+		if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusAccepted {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&job))
+		}
+		return resp.StatusCode, job
+	}
+	get := func(id string) (int, jobResponse) {
+		resp, err := http.Get(server.URL + "/jobs/" + id)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var job jobResponse
+		if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusAccepted {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&job))
+		}
+		return resp.StatusCode, job
+	}
+
+	body := `{"blob":"` + string(hexBlob) + `"}`
+	_, job := submit(body)
+	require.Equal(t, JobID(blob), job.ID)
+	_, err = p.Wait(context.Background(), job.ID)
+	require.NoError(t, err)
+	status, job := get(job.ID)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "done", job.Status)
+	require.Equal(t, expectedResult(t, blob), *job.Result)
+	status, again := submit(body)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, job, again)
+
+	status, _ = get(JobID(ckzgtest.RandomBlob(2)))
+	require.Equal(t, http.StatusNotFound, status)
+	invalid, err := ckzgtest.CorruptFieldElement(ckzgtest.RandomBlob(4), 0).MarshalText()
+	require.NoError(t, err)
+	status, _ = submit(`{"blob":"` + string(invalid) + `"}`)
+	require.Equal(t, http.StatusBadRequest, status)
+	status, _ = submit(`{}`)
+	require.Equal(t, http.StatusBadRequest, status)
+
+	resp, err := http.Post(server.URL+"/jobs/"+job.ID, "application/json", bytes.NewReader(nil))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
@@ -0,0 +1,215 @@
+// Package remote implements a client that offloads proof generation to a
+// remote proving service. Every proof returned by the service is verified
+// locally before it is handed back to the caller, so a faulty or malicious
+// service cannot make the caller publish an invalid proof.
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+)
+
+// maxResultBytes bounds the size of each result in a response, to which the
+// response body is limited. A result holds a hex-encoded proof or a short
+// error message.
+const maxResultBytes = 1024
+
+var (
+	ErrInvalidRemoteProof = errors.New("remote service returned an invalid proof")
+	ErrBadResponse        = errors.New("remote service returned a malformed response")
+)
+
+// Client forwards proving requests to a remote service over HTTP. Returned
+// proofs are always verified, so the trusted setup of Verifier must be loaded.
+type Client struct {
+	// Endpoint is the base URL of the proving service.
+	Endpoint string
+	// HTTPClient is used to issue requests. If nil, http.DefaultClient is
+	// used.
+	HTTPClient *http.Client
+	// Verifier verifies the returned proofs. If nil,
+	// ckzg4844.DefaultBackend is used.
+	Verifier ckzg4844.Backend
+}
+
+// NewClient returns a client for the proving service at endpoint.
+func NewClient(endpoint string) *Client {
+	return &Client{Endpoint: strings.TrimSuffix(endpoint, "/")}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Wire Format
+///////////////////////////////////////////////////////////////////////////////
+
+type proofRequest struct {
+	Blob       string `json:"blob"`
+	Commitment string `json:"commitment"`
+}
+
+type proofsRequest struct {
+	Items []proofRequest `json:"items"`
+}
+
+type proofResult struct {
+	Proof string `json:"proof,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+type proofsResponse struct {
+	Results []proofResult `json:"results"`
+}
+
+func encodeHex(b []byte) string {
+	return "0x" + hex.EncodeToString(b)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Interface Functions
+///////////////////////////////////////////////////////////////////////////////
+
+/*
+ComputeBlobKZGProof asks the remote service to compute the proof for a single
+blob and commitment. The returned proof has been checked with the Verifier.
+*/
+func (c *Client) ComputeBlobKZGProof(ctx context.Context, blob *ckzg4844.Blob, commitmentBytes ckzg4844.Bytes48) (ckzg4844.KZGProof, error) {
+	if blob == nil {
+		return ckzg4844.KZGProof{}, ckzg4844.ErrBadArgs
+	}
+	proofs, err := c.ComputeBlobKZGProofs(ctx, []ckzg4844.Blob{*blob}, []ckzg4844.Bytes48{commitmentBytes})
+	if err != nil {
+		return ckzg4844.KZGProof{}, err
+	}
+	return proofs[0], nil
+}
+
+/*
+ComputeBlobKZGProofs asks the remote service to compute proofs for many blobs
+in one round trip. If any item fails remotely, or any returned proof does not
+verify locally, an error naming the offending index is returned.
+*/
+func (c *Client) ComputeBlobKZGProofs(ctx context.Context, blobs []ckzg4844.Blob, commitmentsBytes []ckzg4844.Bytes48) ([]ckzg4844.KZGProof, error) {
+	if len(blobs) != len(commitmentsBytes) {
+		return nil, ckzg4844.ErrBadArgs
+	}
+
+	request := proofsRequest{Items: make([]proofRequest, len(blobs))}
+	for i := range blobs {
+		request.Items[i] = proofRequest{
+			Blob:       encodeHex(blobs[i][:]),
+			Commitment: encodeHex(commitmentsBytes[i][:]),
+		}
+	}
+	var response proofsResponse
+	limit := int64(len(blobs)+1) * maxResultBytes
+	if err := c.post(ctx, "/proofs", &request, &response, limit); err != nil {
+		return nil, err
+	}
+	if len(response.Results) != len(blobs) {
+		return nil, fmt.Errorf("%w: expected %v results, got %v", ErrBadResponse, len(blobs), len(response.Results))
+	}
+
+	proofs := make([]ckzg4844.KZGProof, len(blobs))
+	proofsBytes := make([]ckzg4844.Bytes48, len(blobs))
+	for i, result := range response.Results {
+		if result.Error != "" {
+			return nil, fmt.Errorf("remote proof %v failed: %v", i, result.Error)
+		}
+		if err := proofsBytes[i].UnmarshalText([]byte(result.Proof)); err != nil {
+			return nil, fmt.Errorf("%w: proof %v: %v", ErrBadResponse, i, err)
+		}
+		proofs[i] = ckzg4844.KZGProof(proofsBytes[i])
+	}
+
+	// Verify the proofs at once, and only look for the offending one if the
+	// batch does not verify.
+	verifier := c.Verifier
+	if verifier == nil {
+		verifier = ckzg4844.DefaultBackend
+	}
+	if ok, err := verifier.VerifyBlobKZGProofBatch(blobs, commitmentsBytes, proofsBytes); err == nil && ok {
+		return proofs, nil
+	}
+	for i := range blobs {
+		ok, err := verifier.VerifyBlobKZGProof(&blobs[i], commitmentsBytes[i], proofsBytes[i])
+		if err != nil {
+			return nil, fmt.Errorf("%w: proof %v: %v", ErrInvalidRemoteProof, i, err)
+		}
+		if !ok {
+			return nil, fmt.Errorf("%w: proof %v", ErrInvalidRemoteProof, i)
+		}
+	}
+	return nil, ErrInvalidRemoteProof
+}
+
+// post sends in as JSON to path and decodes the response into out, reading at
+// most limit bytes of the response.
+func (c *Client) post(ctx context.Context, path string, in, out interface{}, limit int64) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.Endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: status %v", ErrBadResponse, resp.Status)
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, limit)).Decode(out); err != nil {
+		return fmt.Errorf("%w: %v", ErrBadResponse, err)
+	}
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Backend
+///////////////////////////////////////////////////////////////////////////////
+
+/*
+Backend is a ckzg4844.Backend that computes blob proofs with a Client and runs
+every other operation on a local backend, which also verifies the returned
+proofs, so that proving can be offloaded by code written against
+ckzg4844.Backend, such as the limit and prover packages.
+Requests are made without a deadline; set a timeout on the HTTPClient of the
+Client to bound them.
+*/
+type Backend struct {
+	ckzg4844.Backend
+	client *Client
+}
+
+var _ ckzg4844.Backend = (*Backend)(nil)
+
+// NewBackend returns a backend that computes blob proofs with a copy of
+// client verifying them with local, and runs the other operations on local.
+func NewBackend(client *Client, local ckzg4844.Backend) *Backend {
+	c := *client
+	c.Verifier = local
+	return &Backend{Backend: local, client: &c}
+}
+
+// ComputeBlobKZGProof asks the remote service to compute the proof, like
+// Client.ComputeBlobKZGProof.
+func (b *Backend) ComputeBlobKZGProof(blob *ckzg4844.Blob, commitmentBytes ckzg4844.Bytes48) (ckzg4844.KZGProof, error) {
+	return b.client.ComputeBlobKZGProof(context.Background(), blob, commitmentBytes)
+}
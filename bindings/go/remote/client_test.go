@@ -0,0 +1,153 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	if err := ckzg4844.LoadTrustedSetupFile("../../../src/trusted_setup.txt"); err != nil {
+		panic(fmt.Sprintf("failed to load trusted setup: %v", err))
+	}
+	defer ckzg4844.FreeTrustedSetup()
+	code := m.Run()
+	os.Exit(code)
+}
+
+func getBlob(seed byte) ckzg4844.Blob {
+	var blob ckzg4844.Blob
+	for i := 0; i < ckzg4844.FieldElementsPerBlob; i++ {
+		blob[i*ckzg4844.BytesPerFieldElement+31] = byte(i) ^ seed
+	}
+	return blob
+}
+
+// newProver returns a test server that computes proofs locally. If tamper is
+// set, the server replaces every proof with the proof of a different blob.
+func newProver(tamper bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request proofsRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		response := proofsResponse{Results: make([]proofResult, len(request.Items))}
+		for i, item := range request.Items {
+			var blob ckzg4844.Blob
+			var commitment ckzg4844.Bytes48
+			if err := blob.UnmarshalText([]byte(item.Blob)); err != nil {
+				response.Results[i].Error = err.Error()
+				continue
+			}
+			if err := commitment.UnmarshalText([]byte(item.Commitment)); err != nil {
+				response.Results[i].Error = err.Error()
+				continue
+			}
+			if tamper {
+				blob = getBlob(0xff)
+			}
+			proof, err := ckzg4844.ComputeBlobKZGProof(&blob, commitment)
+			if err != nil {
+				response.Results[i].Error = err.Error()
+				continue
+			}
+			response.Results[i].Proof = encodeHex(proof[:])
+		}
+		json.NewEncoder(w).Encode(&response)
+	}))
+}
+
+func TestComputeBlobKZGProof(t *testing.T) {
+	server := newProver(false)
+	defer server.Close()
+
+	blob := getBlob(1)
+	commitment, err := ckzg4844.BlobToKZGCommitment(&blob)
+	require.NoError(t, err)
+	expected, err := ckzg4844.ComputeBlobKZGProof(&blob, ckzg4844.Bytes48(commitment))
+	require.NoError(t, err)
+
+	proof, err := NewClient(server.URL).ComputeBlobKZGProof(context.Background(), &blob, ckzg4844.Bytes48(commitment))
+	require.NoError(t, err)
+	require.Equal(t, expected, proof)
+}
+
+func TestComputeBlobKZGProofInvalidRemoteProof(t *testing.T) {
+	server := newProver(true)
+	defer server.Close()
+
+	blob := getBlob(1)
+	commitment, err := ckzg4844.BlobToKZGCommitment(&blob)
+	require.NoError(t, err)
+
+	_, err = NewClient(server.URL).ComputeBlobKZGProof(context.Background(), &blob, ckzg4844.Bytes48(commitment))
+	require.ErrorIs(t, err, ErrInvalidRemoteProof)
+}
+
+func TestComputeBlobKZGProofsLengthMismatch(t *testing.T) {
+	client := NewClient("http://127.0.0.1:0")
+	_, err := client.ComputeBlobKZGProofs(context.Background(), make([]ckzg4844.Blob, 1), nil)
+	require.ErrorIs(t, err, ckzg4844.ErrBadArgs)
+}
+
+func TestComputeBlobKZGProofsOversizedResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response := proofsResponse{Results: []proofResult{{Error: strings.Repeat("x", 4*maxResultBytes)}}}
+		json.NewEncoder(w).Encode(&response)
+	}))
+	defer server.Close()
+
+	_, err := NewClient(server.URL).ComputeBlobKZGProofs(context.Background(), make([]ckzg4844.Blob, 1), make([]ckzg4844.Bytes48, 1))
+	require.ErrorIs(t, err, ErrBadResponse)
+}
+
+func TestBackend(t *testing.T) {
+	server := newProver(false)
+	defer server.Close()
+
+	var backend ckzg4844.Backend = NewBackend(NewClient(server.URL), ckzg4844.DefaultBackend)
+	blob := getBlob(1)
+	commitment, err := backend.BlobToKZGCommitment(&blob)
+	require.NoError(t, err)
+	proof, err := backend.ComputeBlobKZGProof(&blob, ckzg4844.Bytes48(commitment))
+	require.NoError(t, err)
+	ok, err := backend.VerifyBlobKZGProof(&blob, ckzg4844.Bytes48(commitment), ckzg4844.Bytes48(proof))
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+// countingBackend counts the batch verifications made with it.
+type countingBackend struct {
+	ckzg4844.Backend
+	batches int
+}
+
+func (b *countingBackend) VerifyBlobKZGProofBatch(blobs []ckzg4844.Blob, commitmentsBytes, proofsBytes []ckzg4844.Bytes48) (bool, error) {
+	b.batches++
+	return b.Backend.VerifyBlobKZGProofBatch(blobs, commitmentsBytes, proofsBytes)
+}
+
+func TestBackendVerifiesWithLocal(t *testing.T) {
+	server := newProver(false)
+	defer server.Close()
+
+	local := &countingBackend{Backend: ckzg4844.DefaultBackend}
+	client := NewClient(server.URL)
+	backend := NewBackend(client, local)
+	blob := getBlob(1)
+	commitment, err := backend.BlobToKZGCommitment(&blob)
+	require.NoError(t, err)
+	_, err = backend.ComputeBlobKZGProof(&blob, ckzg4844.Bytes48(commitment))
+	require.NoError(t, err)
+	require.Equal(t, 1, local.batches)
+	require.Nil(t, client.Verifier)
+}
@@ -0,0 +1,291 @@
+// Package rest implements an HTTP service running the KZG operations on
+// batches of items, for use as a sidecar by infrastructure that does not link
+// the library itself.
+//
+// Every endpoint takes a POST of a JSON object with an "items" array and
+// returns an object with a "results" array, holding the result of each item
+// in order, or its "error". Byte strings are 0x-prefixed hex. The endpoints
+// are:
+//
+//	/commitments   {"blob"} -> {"commitment"}
+//	/proofs        {"blob", "commitment"} -> {"proof"}
+//	/verify-batch  {"blob", "commitment", "proof"} -> {"valid"}
+//	/recover       {"indices", "evaluations"} -> {"codeword"}
+//
+// /proofs is the protocol of the remote package's Client. /recover runs
+// rs.Recover, returning the full extended blob.
+package rest
+
+import (
+	"context"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+	"github.com/ethereum/c-kzg-4844/bindings/go/limit"
+	"github.com/ethereum/c-kzg-4844/bindings/go/rs"
+)
+
+const (
+	// DefaultMaxItems is the default limit on the number of items of a
+	// request.
+	DefaultMaxItems = 64
+	// maxItemSize bounds the size of the JSON encoding of an item. The
+	// largest are those of /recover, with up to a codeword of evaluations
+	// and their indices.
+	maxItemSize = 2*rs.CodewordLength*(2*ckzg4844.BytesPerFieldElement+8) + 1024
+)
+
+// Server serves the endpoints for a backend. It implements http.Handler.
+type Server struct {
+	// Backend runs the operations. Its trusted setup must be loaded.
+	Backend ckzg4844.Backend
+	// Limiter bounds the operations run at once, across all requests, and
+	// may be shared with other servers. If nil, a limiter running
+	// MaxConcurrency operations at once is used.
+	Limiter *limit.Limiter
+	// MaxConcurrency is the number of operations run at once when Limiter
+	// is nil. If zero, runtime.NumCPU() is used.
+	MaxConcurrency int
+	// MaxItems is the limit on the number of items of a request. If zero,
+	// DefaultMaxItems is used.
+	MaxItems int
+
+	once    sync.Once
+	limiter *limit.Limiter
+	mux     *http.ServeMux
+}
+
+// NewServer returns a server for backend.
+func NewServer(backend ckzg4844.Backend) *Server {
+	return &Server{Backend: backend}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Wire Format
+///////////////////////////////////////////////////////////////////////////////
+
+type item struct {
+	Blob        string   `json:"blob"`
+	Commitment  string   `json:"commitment"`
+	Proof       string   `json:"proof"`
+	Indices     []int    `json:"indices"`
+	Evaluations []string `json:"evaluations"`
+}
+
+type itemsRequest struct {
+	Items []item `json:"items"`
+}
+
+type result struct {
+	Commitment *ckzg4844.KZGCommitment `json:"commitment,omitempty"`
+	Proof      *ckzg4844.KZGProof      `json:"proof,omitempty"`
+	Valid      *bool                   `json:"valid,omitempty"`
+	Codeword   []ckzg4844.Bytes32      `json:"codeword,omitempty"`
+	Error      string                  `json:"error,omitempty"`
+}
+
+type resultsResponse struct {
+	Results []result `json:"results"`
+}
+
+// decode parses the hex string s of the field name of an item into v.
+func decode(name, s string, v encoding.TextUnmarshaler) error {
+	if err := v.UnmarshalText([]byte(s)); err != nil {
+		return fmt.Errorf("invalid %v: %w", name, err)
+	}
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Server
+///////////////////////////////////////////////////////////////////////////////
+
+func (s *Server) init() {
+	s.limiter = s.Limiter
+	if s.limiter == nil {
+		s.limiter = limit.New(s.Backend, limit.Config{MaxConcurrent: s.MaxConcurrency})
+	}
+	s.mux = http.NewServeMux()
+	s.mux.HandleFunc("/commitments", s.handler(s.commitments))
+	s.mux.HandleFunc("/proofs", s.handler(s.proofs))
+	s.mux.HandleFunc("/verify-batch", s.handler(s.verifyBatch))
+	s.mux.HandleFunc("/recover", s.handler(s.recover))
+}
+
+// ServeHTTP serves the endpoints.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.once.Do(s.init)
+	s.mux.ServeHTTP(w, r)
+}
+
+// handler returns the handler of an endpoint, which computes the results of
+// the items of a request.
+func (s *Server) handler(endpoint func(ctx context.Context, items []item) []result) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		maxItems := s.MaxItems
+		if maxItems == 0 {
+			maxItems = DefaultMaxItems
+		}
+		var request itemsRequest
+		decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, int64(maxItems)*maxItemSize))
+		if err := decoder.Decode(&request); err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				http.Error(w, "request too large", http.StatusRequestEntityTooLarge)
+				return
+			}
+			http.Error(w, "malformed request: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		if len(request.Items) > maxItems {
+			http.Error(w, fmt.Sprintf("too many items, the limit is %v", maxItems), http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		results := endpoint(r.Context(), request.Items)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resultsResponse{Results: results})
+	}
+}
+
+// each computes n results with f concurrently, each in a slot of the
+// limiter. The results not started, because ctx is done or the limiter's
+// queue is full, are the error that prevented them.
+func (s *Server) each(ctx context.Context, n int, f func(i int) (result, error)) []result {
+	results := make([]result, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		if err := s.limiter.Acquire(ctx); err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer func() { s.limiter.Release(); wg.Done() }()
+			var err error
+			if results[i], err = f(i); err != nil {
+				results[i] = result{Error: err.Error()}
+			}
+		}(i)
+	}
+	wg.Wait()
+	return results
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Endpoints
+///////////////////////////////////////////////////////////////////////////////
+
+func (s *Server) commitments(ctx context.Context, items []item) []result {
+	return s.each(ctx, len(items), func(i int) (result, error) {
+		blob := new(ckzg4844.Blob)
+		if err := decode("blob", items[i].Blob, blob); err != nil {
+			return result{}, err
+		}
+		commitment, err := s.Backend.BlobToKZGCommitment(blob)
+		if err != nil {
+			return result{}, err
+		}
+		return result{Commitment: &commitment}, nil
+	})
+}
+
+func (s *Server) proofs(ctx context.Context, items []item) []result {
+	return s.each(ctx, len(items), func(i int) (result, error) {
+		blob := new(ckzg4844.Blob)
+		var commitment ckzg4844.Bytes48
+		if err := decode("blob", items[i].Blob, blob); err != nil {
+			return result{}, err
+		}
+		if err := decode("commitment", items[i].Commitment, &commitment); err != nil {
+			return result{}, err
+		}
+		proof, err := s.Backend.ComputeBlobKZGProof(blob, commitment)
+		if err != nil {
+			return result{}, err
+		}
+		return result{Proof: &proof}, nil
+	})
+}
+
+// verifyBatch verifies the well-formed items with a single batch
+// verification. Only if that fails are they verified one by one, to find
+// those that are invalid.
+func (s *Server) verifyBatch(ctx context.Context, items []item) []result {
+	results := make([]result, len(items))
+	var blobs []ckzg4844.Blob
+	var commitments, proofs []ckzg4844.Bytes48
+	var indices []int
+	for i, item := range items {
+		blob := new(ckzg4844.Blob)
+		var commitment, proof ckzg4844.Bytes48
+		err := decode("blob", item.Blob, blob)
+		if err == nil {
+			err = decode("commitment", item.Commitment, &commitment)
+		}
+		if err == nil {
+			err = decode("proof", item.Proof, &proof)
+		}
+		if err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+		blobs = append(blobs, *blob)
+		commitments = append(commitments, commitment)
+		proofs = append(proofs, proof)
+		indices = append(indices, i)
+	}
+	if len(indices) == 0 {
+		return results
+	}
+
+	batch := s.each(ctx, 1, func(int) (result, error) {
+		valid, err := s.Backend.VerifyBlobKZGProofBatch(blobs, commitments, proofs)
+		return result{Valid: &valid}, err
+	})[0]
+	individual := make([]result, len(indices))
+	if batch.Error == "" && *batch.Valid {
+		for j := range individual {
+			individual[j] = batch
+		}
+	} else if ctx.Err() == nil {
+		individual = s.each(ctx, len(indices), func(j int) (result, error) {
+			valid, err := s.Backend.VerifyBlobKZGProof(&blobs[j], commitments[j], proofs[j])
+			return result{Valid: &valid}, err
+		})
+	} else {
+		for j := range individual {
+			individual[j].Error = ctx.Err().Error()
+		}
+	}
+	for j, i := range indices {
+		results[i] = individual[j]
+	}
+	return results
+}
+
+func (s *Server) recover(ctx context.Context, items []item) []result {
+	return s.each(ctx, len(items), func(i int) (result, error) {
+		evaluations := make([]ckzg4844.Bytes32, len(items[i].Evaluations))
+		for j, evaluation := range items[i].Evaluations {
+			if err := decode(fmt.Sprintf("evaluation %v", j), evaluation, &evaluations[j]); err != nil {
+				return result{}, err
+			}
+		}
+		codeword, err := rs.Recover(items[i].Indices, evaluations)
+		if err != nil {
+			return result{}, err
+		}
+		return result{Codeword: codeword}, nil
+	})
+}
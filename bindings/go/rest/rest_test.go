@@ -0,0 +1,183 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+	"github.com/ethereum/c-kzg-4844/bindings/go/ckzgtest"
+	"github.com/ethereum/c-kzg-4844/bindings/go/remote"
+	"github.com/ethereum/c-kzg-4844/bindings/go/rs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	if err := ckzg4844.LoadTrustedSetupFile("../../../src/trusted_setup.txt"); err != nil {
+		panic(fmt.Sprintf("failed to load trusted setup: %v", err))
+	}
+	defer ckzg4844.FreeTrustedSetup()
+	os.Exit(m.Run())
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Helper Functions
+///////////////////////////////////////////////////////////////////////////////
+
+type testResult struct {
+	Commitment string   `json:"commitment"`
+	Proof      string   `json:"proof"`
+	Valid      *bool    `json:"valid"`
+	Codeword   []string `json:"codeword"`
+	Error      string   `json:"error"`
+}
+
+// post sends items to an endpoint of server, requires the response to have
+// status, and returns its results.
+func post(t *testing.T, server *httptest.Server, endpoint string, status int, items ...map[string]interface{}) []testResult {
+	t.Helper()
+	body, err := json.Marshal(map[string]interface{}{"items": items})
+	require.NoError(t, err)
+	resp, err := http.Post(server.URL+endpoint, "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, status, resp.StatusCode)
+	if status != http.StatusOK {
+		return nil
+	}
+	var response struct {
+		Results []testResult `json:"results"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	require.Len(t, response.Results, len(items))
+	return response.Results
+}
+
+func hexString(v interface{ MarshalText() ([]byte, error) }) string {
+	text, _ := v.MarshalText()
+	return string(text)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Tests
+///////////////////////////////////////////////////////////////////////////////
+
+func TestCommitmentsAndProofs(t *testing.T) {
+	server := httptest.NewServer(NewServer(ckzg4844.DefaultBackend))
+	defer server.Close()
+	blobs, commitments, proofs := ckzgtest.RandomBundle(1, 2)
+
+	results := post(t, server, "/commitments", http.StatusOK,
+		map[string]interface{}{"blob": hexString(&blobs[0])},
+		map[string]interface{}{"blob": "0x00"},
+		map[string]interface{}{"blob": hexString(&blobs[1])},
+	)
+	require.Equal(t, hexString(commitments[0]), results[0].Commitment)
+	require.Contains(t, results[1].Error, "invalid blob")
+	require.Equal(t, hexString(commitments[1]), results[2].Commitment)
+
+	results = post(t, server, "/proofs", http.StatusOK,
+		map[string]interface{}{"blob": hexString(&blobs[0]), "commitment": hexString(commitments[0])},
+		map[string]interface{}{"blob": hexString(&blobs[1]), "commitment": hexString(ckzgtest.InvalidPoint())},
+	)
+	require.Equal(t, hexString(proofs[0]), results[0].Proof)
+	require.Empty(t, results[0].Error)
+	require.NotEmpty(t, results[1].Error)
+	require.Empty(t, results[1].Proof)
+}
+
+func TestRemoteClient(t *testing.T) {
+	server := httptest.NewServer(NewServer(ckzg4844.DefaultBackend))
+	defer server.Close()
+	blobs, commitments, proofs := ckzgtest.RandomBundle(2, 3)
+
+	client := remote.NewClient(server.URL)
+	got, err := client.ComputeBlobKZGProofs(context.Background(), blobs, commitments)
+	require.NoError(t, err)
+	for i := range proofs {
+		require.Equal(t, ckzg4844.KZGProof(proofs[i]), got[i])
+	}
+}
+
+func TestVerifyBatch(t *testing.T) {
+	server := httptest.NewServer(NewServer(ckzg4844.DefaultBackend))
+	defer server.Close()
+	blobs, commitments, proofs := ckzgtest.RandomBundle(3, 3)
+	item := func(i int, proof ckzg4844.Bytes48) map[string]interface{} {
+		return map[string]interface{}{"blob": hexString(&blobs[i]), "commitment": hexString(commitments[i]), "proof": hexString(proof)}
+	}
+
+	// All valid, in a single batch verification.
+	results := post(t, server, "/verify-batch", http.StatusOK, item(0, proofs[0]), item(1, proofs[1]), item(2, proofs[2]))
+	for _, result := range results {
+		require.Empty(t, result.Error)
+		require.True(t, *result.Valid)
+	}
+
+	// One invalid, one malformed and one that is not a point.
+	results = post(t, server, "/verify-batch", http.StatusOK,
+		item(0, proofs[0]), item(1, proofs[0]), map[string]interface{}{"blob": "0x"}, item(2, ckzgtest.InvalidPoint()))
+	require.True(t, *results[0].Valid)
+	require.False(t, *results[1].Valid)
+	require.Contains(t, results[2].Error, "invalid blob")
+	require.Nil(t, results[2].Valid)
+	require.NotEmpty(t, results[3].Error)
+	require.Nil(t, results[3].Valid)
+
+	// Nothing to verify.
+	require.Empty(t, post(t, server, "/verify-batch", http.StatusOK))
+}
+
+func TestRecover(t *testing.T) {
+	server := httptest.NewServer(NewServer(ckzg4844.DefaultBackend))
+	defer server.Close()
+	blob := ckzgtest.RandomBlob(4)
+	codeword, err := rs.Encode(blob)
+	require.NoError(t, err)
+
+	indices := make([]int, ckzg4844.FieldElementsPerBlob)
+	evaluations := make([]string, ckzg4844.FieldElementsPerBlob)
+	for i := range indices {
+		indices[i] = 2*i + 1
+		evaluations[i] = hexString(codeword[indices[i]])
+	}
+	results := post(t, server, "/recover", http.StatusOK,
+		map[string]interface{}{"indices": indices, "evaluations": evaluations},
+		map[string]interface{}{"indices": indices[1:], "evaluations": evaluations[1:]},
+	)
+	require.Empty(t, results[0].Error)
+	require.Len(t, results[0].Codeword, rs.CodewordLength)
+	for i, evaluation := range results[0].Codeword {
+		require.Equal(t, hexString(codeword[i]), evaluation)
+	}
+	require.Equal(t, rs.ErrNotEnoughEvaluations.Error(), results[1].Error)
+}
+
+func TestRequestErrors(t *testing.T) {
+	server := httptest.NewServer(&Server{Backend: ckzg4844.DefaultBackend, MaxConcurrency: 1, MaxItems: 2})
+	defer server.Close()
+	item := map[string]interface{}{"blob": "0x"}
+	post(t, server, "/commitments", http.StatusRequestEntityTooLarge, item, item, item)
+	post(t, server, "/commitments", http.StatusOK, item, item)
+
+	resp, err := http.Post(server.URL+"/proofs", "application/json", strings.NewReader("{"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, err = http.Get(server.URL + "/proofs")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+
+	resp, err = http.Post(server.URL+"/unknown", "application/json", strings.NewReader("{}"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,251 @@
+// Package rs implements the systematic Reed-Solomon extension of a blob used
+// for data availability sampling, without KZG commitments or a trusted setup.
+//
+// A blob is the evaluation form of a polynomial of degree less than
+// FieldElementsPerBlob over the roots of unity of that order, in bit-reversed
+// order. Encode evaluates the same polynomial over the roots of unity of
+// order CodewordLength, also in bit-reversed order, so the first half of the
+// codeword is the blob itself. Any FieldElementsPerBlob distinct evaluations
+// of a codeword are enough to recover all of it.
+package rs
+
+import (
+	"errors"
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+)
+
+const (
+	// CodewordLength is the number of field elements in an extended blob.
+	CodewordLength = 2 * ckzg4844.FieldElementsPerBlob
+	// primitiveRoot generates the multiplicative group of the scalar field.
+	// It is also used as the coset shift during recovery.
+	primitiveRoot = 7
+)
+
+var (
+	ErrInvalidFieldElement  = errors.New("field element is not canonical")
+	ErrInvalidIndex         = errors.New("evaluation index out of range")
+	ErrDuplicateIndex       = errors.New("duplicate evaluation index")
+	ErrNotEnoughEvaluations = errors.New("not enough evaluations to recover")
+	ErrInconsistentCodeword = errors.New("evaluations are not a valid codeword")
+)
+
+// rootsOfUnity holds the powers of a primitive CodewordLength-th root of
+// unity, in natural order.
+var rootsOfUnity [CodewordLength]fr.Element
+
+func init() {
+	exponent := fr.Modulus()
+	exponent.Sub(exponent, big.NewInt(1))
+	exponent.Div(exponent, big.NewInt(CodewordLength))
+	var generator, root fr.Element
+	generator.SetUint64(primitiveRoot)
+	root.Exp(generator, exponent)
+
+	rootsOfUnity[0].SetOne()
+	for i := 1; i < CodewordLength; i++ {
+		rootsOfUnity[i].Mul(&rootsOfUnity[i-1], &root)
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Helper Functions
+///////////////////////////////////////////////////////////////////////////////
+
+// reverseBits reverses the low log2(n) bits of i.
+func reverseBits(i, n int) int {
+	return int(bits.Reverse32(uint32(i)) >> (32 - bits.TrailingZeros32(uint32(n))))
+}
+
+// bitReverse permutes a into bit-reversed order. The length of a must be a
+// power of two.
+func bitReverse(a []fr.Element) {
+	for i := range a {
+		if j := reverseBits(i, len(a)); i < j {
+			a[i], a[j] = a[j], a[i]
+		}
+	}
+}
+
+// fft evaluates the polynomial with coefficients a over the roots of unity of
+// order len(a), in place and in natural order. With inverse set it
+// interpolates instead. The length of a must be a power of two no larger
+// than CodewordLength.
+func fft(a []fr.Element, inverse bool) {
+	n := len(a)
+	bitReverse(a)
+	for size := 2; size <= n; size <<= 1 {
+		half, stride := size/2, CodewordLength/size
+		for start := 0; start < n; start += size {
+			for j := 0; j < half; j++ {
+				k := j * stride
+				if inverse && k != 0 {
+					k = CodewordLength - k
+				}
+				var t fr.Element
+				t.Mul(&a[start+j+half], &rootsOfUnity[k])
+				a[start+j+half].Sub(&a[start+j], &t)
+				a[start+j].Add(&a[start+j], &t)
+			}
+		}
+	}
+	if inverse {
+		var scale fr.Element
+		scale.SetUint64(uint64(n))
+		scale.Inverse(&scale)
+		for i := range a {
+			a[i].Mul(&a[i], &scale)
+		}
+	}
+}
+
+// scaleCoefficients multiplies the i-th coefficient of a by factor^i, which
+// moves evaluation from the domain to the coset factor*domain.
+func scaleCoefficients(a []fr.Element, factor *fr.Element) {
+	var power fr.Element
+	power.SetOne()
+	for i := range a {
+		a[i].Mul(&a[i], &power)
+		power.Mul(&power, factor)
+	}
+}
+
+func toBytes32(a []fr.Element) []ckzg4844.Bytes32 {
+	out := make([]ckzg4844.Bytes32, len(a))
+	for i := range a {
+		out[i] = a[i].Bytes()
+	}
+	return out
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Reed-Solomon Functions
+///////////////////////////////////////////////////////////////////////////////
+
+// Encode extends blob to a codeword of CodewordLength field elements. The
+// first FieldElementsPerBlob elements are the blob's own field elements.
+func Encode(blob *ckzg4844.Blob) ([]ckzg4844.Bytes32, error) {
+	codeword := make([]fr.Element, CodewordLength)
+	for i := 0; i < ckzg4844.FieldElementsPerBlob; i++ {
+		fe := blob.FieldElement(i)
+		if err := codeword[i].SetBytesCanonical(fe[:]); err != nil {
+			return nil, ErrInvalidFieldElement
+		}
+	}
+
+	coefficients := codeword[:ckzg4844.FieldElementsPerBlob]
+	bitReverse(coefficients)
+	fft(coefficients, true)
+	fft(codeword, false)
+	bitReverse(codeword)
+	return toBytes32(codeword), nil
+}
+
+// Recover reconstructs a full codeword from a subset of its evaluations.
+// indices[i] is the position of evaluations[i] in the codeword; at least
+// FieldElementsPerBlob distinct positions are required. Recover returns
+// ErrInconsistentCodeword if the evaluations do not all lie on one codeword.
+func Recover(indices []int, evaluations []ckzg4844.Bytes32) ([]ckzg4844.Bytes32, error) {
+	if len(indices) != len(evaluations) {
+		return nil, ckzg4844.ErrBadArgs
+	}
+
+	// Place the known evaluations in natural order.
+	var known [CodewordLength]bool
+	values := make([]fr.Element, CodewordLength)
+	for i, index := range indices {
+		if index < 0 || index >= CodewordLength {
+			return nil, ErrInvalidIndex
+		}
+		j := reverseBits(index, CodewordLength)
+		if known[j] {
+			return nil, ErrDuplicateIndex
+		}
+		if err := values[j].SetBytesCanonical(evaluations[i][:]); err != nil {
+			return nil, ErrInvalidFieldElement
+		}
+		known[j] = true
+	}
+	if len(indices) < ckzg4844.FieldElementsPerBlob {
+		return nil, ErrNotEnoughEvaluations
+	}
+
+	// Z is the polynomial vanishing on the missing positions. Its degree is at
+	// most CodewordLength - FieldElementsPerBlob, so it fits in the domain.
+	zero := make([]fr.Element, CodewordLength)
+	zero[0].SetOne()
+	degree := 0
+	for j := range known {
+		if known[j] {
+			continue
+		}
+		// Multiply by (x - w^j).
+		for k := degree + 1; k > 0; k-- {
+			var t fr.Element
+			t.Mul(&zero[k], &rootsOfUnity[j])
+			zero[k].Sub(&zero[k-1], &t)
+		}
+		zero[0].Mul(&zero[0], &rootsOfUnity[j])
+		zero[0].Neg(&zero[0])
+		degree++
+	}
+
+	// Evaluations of P*Z are known everywhere: E*Z where P is known, and zero
+	// where it is missing.
+	zeroEvals := append([]fr.Element(nil), zero...)
+	fft(zeroEvals, false)
+	product := make([]fr.Element, CodewordLength)
+	for j := range product {
+		product[j].Mul(&values[j], &zeroEvals[j])
+	}
+	fft(product, true)
+
+	// Divide P*Z by Z on a coset, where Z has no roots.
+	var shift, shiftInverse fr.Element
+	shift.SetUint64(primitiveRoot)
+	shiftInverse.Inverse(&shift)
+	scaleCoefficients(product, &shift)
+	scaleCoefficients(zero, &shift)
+	fft(product, false)
+	fft(zero, false)
+	zero = fr.BatchInvert(zero)
+	for j := range product {
+		product[j].Mul(&product[j], &zero[j])
+	}
+	fft(product, true)
+	scaleCoefficients(product, &shiftInverse)
+
+	// The quotient is P, which must have the degree of a blob polynomial and
+	// agree with every given evaluation.
+	for _, c := range product[ckzg4844.FieldElementsPerBlob:] {
+		if !c.IsZero() {
+			return nil, ErrInconsistentCodeword
+		}
+	}
+	fft(product, false)
+	for j := range product {
+		if known[j] && !product[j].Equal(&values[j]) {
+			return nil, ErrInconsistentCodeword
+		}
+	}
+	bitReverse(product)
+	return toBytes32(product), nil
+}
+
+// Decode recovers the blob from a subset of the evaluations of its codeword.
+// The arguments are as for Recover.
+func Decode(indices []int, evaluations []ckzg4844.Bytes32) (*ckzg4844.Blob, error) {
+	codeword, err := Recover(indices, evaluations)
+	if err != nil {
+		return nil, err
+	}
+	blob := new(ckzg4844.Blob)
+	for i := 0; i < ckzg4844.FieldElementsPerBlob; i++ {
+		copy(blob[i*ckzg4844.BytesPerFieldElement:], codeword[i][:])
+	}
+	return blob, nil
+}
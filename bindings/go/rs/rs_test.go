@@ -0,0 +1,218 @@
+package rs
+
+import (
+	"math/rand"
+	"testing"
+	"testing/quick"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+	"github.com/ethereum/c-kzg-4844/bindings/go/ckzgtest"
+	"github.com/stretchr/testify/require"
+)
+
+// sample returns the positions in perm[:n] with their codeword values.
+func sample(codeword []ckzg4844.Bytes32, perm []int, n int) ([]int, []ckzg4844.Bytes32) {
+	indices := append([]int(nil), perm[:n]...)
+	evaluations := make([]ckzg4844.Bytes32, n)
+	for i, index := range indices {
+		evaluations[i] = codeword[index]
+	}
+	return indices, evaluations
+}
+
+func TestEncodeIsSystematic(t *testing.T) {
+	blob := ckzgtest.RandomBlob(0)
+	codeword, err := Encode(blob)
+	require.NoError(t, err)
+	require.Len(t, codeword, CodewordLength)
+	for i := 0; i < ckzg4844.FieldElementsPerBlob; i++ {
+		require.Equal(t, blob.FieldElement(i), codeword[i])
+	}
+}
+
+func TestEncodeIdentityPolynomial(t *testing.T) {
+	// The blob of p(x) = x holds the blob domain itself, so its codeword holds
+	// the extended domain, both in bit-reversed order.
+	blob := new(ckzg4844.Blob)
+	for i := 0; i < ckzg4844.FieldElementsPerBlob; i++ {
+		root := rootsOfUnity[2*reverseBits(i, ckzg4844.FieldElementsPerBlob)]
+		require.NoError(t, blob.SetFieldElement(i, root.Bytes()))
+	}
+	codeword, err := Encode(blob)
+	require.NoError(t, err)
+	for i := range codeword {
+		require.Equal(t, ckzg4844.Bytes32(rootsOfUnity[reverseBits(i, CodewordLength)].Bytes()), codeword[i])
+	}
+}
+
+func TestRecover(t *testing.T) {
+	blob := ckzgtest.RandomBlob(1)
+	codeword, err := Encode(blob)
+	require.NoError(t, err)
+	r := rand.New(rand.NewSource(1))
+
+	for _, n := range []int{ckzg4844.FieldElementsPerBlob, ckzg4844.FieldElementsPerBlob + 1, CodewordLength} {
+		indices, evaluations := sample(codeword, r.Perm(CodewordLength), n)
+		recovered, err := Recover(indices, evaluations)
+		require.NoError(t, err)
+		require.Equal(t, codeword, recovered)
+	}
+
+	// Only the extension half.
+	extension := make([]int, ckzg4844.FieldElementsPerBlob)
+	for i := range extension {
+		extension[i] = ckzg4844.FieldElementsPerBlob + i
+	}
+	indices, evaluations := sample(codeword, extension, len(extension))
+	decoded, err := Decode(indices, evaluations)
+	require.NoError(t, err)
+	require.Equal(t, blob, decoded)
+}
+
+func TestRecoverInconsistent(t *testing.T) {
+	codeword, err := Encode(ckzgtest.RandomBlob(2))
+	require.NoError(t, err)
+	indices, evaluations := sample(codeword, rand.New(rand.NewSource(2)).Perm(CodewordLength), ckzg4844.FieldElementsPerBlob+1)
+	var one fr.Element
+	one.SetOne()
+	var e fr.Element
+	require.NoError(t, e.SetBytesCanonical(evaluations[0][:]))
+	e.Add(&e, &one)
+	evaluations[0] = e.Bytes()
+
+	_, err = Recover(indices, evaluations)
+	require.ErrorIs(t, err, ErrInconsistentCodeword)
+}
+
+func TestRecoverInvalidArgs(t *testing.T) {
+	codeword, err := Encode(ckzgtest.RandomBlob(3))
+	require.NoError(t, err)
+	perm := rand.New(rand.NewSource(3)).Perm(CodewordLength)
+
+	indices, evaluations := sample(codeword, perm, ckzg4844.FieldElementsPerBlob-1)
+	_, err = Recover(indices, evaluations)
+	require.ErrorIs(t, err, ErrNotEnoughEvaluations)
+
+	indices, evaluations = sample(codeword, perm, ckzg4844.FieldElementsPerBlob)
+	_, err = Recover(indices, evaluations[1:])
+	require.ErrorIs(t, err, ckzg4844.ErrBadArgs)
+
+	indices[1] = indices[0]
+	_, err = Recover(indices, evaluations)
+	require.ErrorIs(t, err, ErrDuplicateIndex)
+
+	indices[1] = CodewordLength
+	_, err = Recover(indices, evaluations)
+	require.ErrorIs(t, err, ErrInvalidIndex)
+
+	indices, evaluations = sample(codeword, perm, ckzg4844.FieldElementsPerBlob)
+	evaluations[0] = ckzg4844.Bytes32{0xff}
+	_, err = Recover(indices, evaluations)
+	require.ErrorIs(t, err, ErrInvalidFieldElement)
+}
+
+func TestEncodeInvalidBlob(t *testing.T) {
+	blob := ckzgtest.RandomBlob(4)
+	blob[0] = 0xff
+	_, err := Encode(blob)
+	require.ErrorIs(t, err, ErrInvalidFieldElement)
+}
+
+func FuzzRecover(f *testing.F) {
+	f.Add(int64(0), []byte{}, false)
+	f.Add(int64(1), []byte{0x00, 0x01, 0x1f, 0xff}, true)
+	f.Add(int64(2), make([]byte, 2*ckzg4844.FieldElementsPerBlob), true)
+
+	f.Fuzz(func(t *testing.T, seed int64, dropped []byte, tamper bool) {
+		codeword, err := Encode(ckzgtest.RandomBlob(seed))
+		require.NoError(t, err)
+
+		// Each pair of bytes names a position to leave out.
+		missing := make(map[int]bool)
+		for i := 0; i+1 < len(dropped); i += 2 {
+			missing[(int(dropped[i])<<8|int(dropped[i+1]))%CodewordLength] = true
+		}
+		var indices []int
+		var evaluations []ckzg4844.Bytes32
+		for i := range codeword {
+			if !missing[i] {
+				indices = append(indices, i)
+				evaluations = append(evaluations, codeword[i])
+			}
+		}
+		if tamper && len(evaluations) > 0 {
+			var one, e fr.Element
+			one.SetOne()
+			require.NoError(t, e.SetBytesCanonical(evaluations[0][:]))
+			e.Add(&e, &one)
+			evaluations[0] = e.Bytes()
+		}
+
+		recovered, err := Recover(indices, evaluations)
+		switch {
+		case len(indices) < ckzg4844.FieldElementsPerBlob:
+			require.ErrorIs(t, err, ErrNotEnoughEvaluations)
+		case !tamper:
+			require.NoError(t, err)
+			require.Equal(t, codeword, recovered)
+		case len(indices) == ckzg4844.FieldElementsPerBlob:
+			// Any FieldElementsPerBlob evaluations lie on some codeword.
+			require.NoError(t, err)
+			require.NotEqual(t, codeword, recovered)
+		default:
+			require.ErrorIs(t, err, ErrInconsistentCodeword)
+		}
+	})
+}
+
+func TestRecoverProperty(t *testing.T) {
+	// Any half of the extended blob recovers the blob.
+	property := func(blob ckzgtest.CanonicalBlob, seed int64) bool {
+		codeword, err := Encode((*ckzg4844.Blob)(&blob))
+		if err != nil {
+			return false
+		}
+		perm := rand.New(rand.NewSource(seed)).Perm(CodewordLength)
+		decoded, err := Decode(sample(codeword, perm, ckzg4844.FieldElementsPerBlob))
+		return err == nil && *decoded == ckzg4844.Blob(blob)
+	}
+	require.NoError(t, quick.Check(property, &quick.Config{MaxCount: 4}))
+}
+
+func TestRecoverAdversarial(t *testing.T) {
+	codeword, err := Encode(ckzgtest.RandomBlob(5))
+	require.NoError(t, err)
+	other, err := Encode(ckzgtest.RandomBlob(6))
+	require.NoError(t, err)
+	perm := rand.New(rand.NewSource(5)).Perm(CodewordLength)
+
+	// With one evaluation more than the minimum, every corruption is caught.
+	indices, evaluations := sample(codeword, perm, ckzg4844.FieldElementsPerBlob+1)
+	_, err = Recover(indices, ckzgtest.MixEvaluations(indices, evaluations, other, 0))
+	require.ErrorIs(t, err, ErrInconsistentCodeword)
+	_, err = Recover(indices, ckzgtest.MixEvaluations(indices, evaluations, other, 3, 17, 200))
+	require.ErrorIs(t, err, ErrInconsistentCodeword)
+	_, err = Recover(ckzgtest.SwapIndices(indices, 1, 2), evaluations)
+	require.ErrorIs(t, err, ErrInconsistentCodeword)
+	_, err = Recover(indices, ckzgtest.FlipBit(evaluations, 4, 0))
+	require.ErrorIs(t, err, ErrInconsistentCodeword)
+	_, err = Recover(indices, ckzgtest.FlipBit(evaluations, 4, 255))
+	require.ErrorIs(t, err, ErrInvalidFieldElement)
+
+	// Mixing in a whole other sample is consistent: it recovers the other
+	// codeword.
+	all := make([]int, len(indices))
+	for i := range all {
+		all[i] = i
+	}
+	recovered, err := Recover(indices, ckzgtest.MixEvaluations(indices, evaluations, other, all...))
+	require.NoError(t, err)
+	require.Equal(t, other, recovered)
+
+	// With the minimum number of evaluations, corruption can't be detected.
+	indices, evaluations = sample(codeword, perm, ckzg4844.FieldElementsPerBlob)
+	recovered, err = Recover(indices, ckzgtest.FlipBit(evaluations, 0, 0))
+	require.NoError(t, err)
+	require.NotEqual(t, codeword, recovered)
+}
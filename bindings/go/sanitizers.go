@@ -0,0 +1,12 @@
+//go:build cgo && !ckzg_nocgo && (asan || msan)
+
+package ckzg4844
+
+// The go command sets the asan and msan tags when building with -asan and
+// -msan, and then compiles all C code with AddressSanitizer or
+// MemorySanitizer. The C library is then compiled with the optimization level
+// the sanitizers recommend and keeps its frame pointers and debug
+// information, so that their reports name its functions and lines.
+
+// #cgo CFLAGS: -O1 -g -fno-omit-frame-pointer
+import "C"
@@ -0,0 +1,109 @@
+// Package setups maps network names to trusted setup loaders, so that
+// applications supporting several networks can select a setup by name.
+//
+// The ckzg4844 package holds a single loaded trusted setup, so a loader loads
+// into it rather than returning a settings value; call
+// ckzg4844.FreeTrustedSetup before loading another one.
+package setups
+
+import (
+	"context"
+	"crypto/sha256"
+	_ "embed"
+	"errors"
+	"sort"
+	"sync"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+)
+
+// mainnetSetup is a copy of src/trusted_setup.txt, which go:embed cannot reach
+// from here. It is shipped with the package rather than downloaded because the
+// file upstream changes format between releases, so no mutable URL can be
+// checked against ckzg4844.MainnetTrustedSetupDigest for long.
+//
+//go:embed trusted_setup.txt
+var mainnetSetup []byte
+
+var (
+	ErrUnknownSetup   = errors.New("unknown trusted setup")
+	ErrDuplicateSetup = errors.New("trusted setup name already registered")
+)
+
+// Loader loads a trusted setup into the ckzg4844 package.
+type Loader func(ctx context.Context) error
+
+var (
+	mu       sync.RWMutex
+	registry = map[string]Loader{
+		"mainnet": Mainnet(),
+		"minimal": Minimal(),
+	}
+)
+
+// Mainnet returns a loader for the canonical mainnet setup. It loads the copy
+// shipped with the package, without using the network, after checking it
+// against ckzg4844.MainnetTrustedSetupDigest.
+func Mainnet() Loader {
+	return func(context.Context) error {
+		if sha256.Sum256(mainnetSetup) != ckzg4844.MainnetTrustedSetupDigest {
+			return ckzg4844.ErrTrustedSetupDigestMismatch
+		}
+		return ckzg4844.LoadTrustedSetupText(mainnetSetup)
+	}
+}
+
+// Minimal returns a loader for the minimal preset. The minimal preset has as
+// many field elements per blob as mainnet and uses the same setup.
+func Minimal() Loader {
+	return Mainnet()
+}
+
+// File returns a loader for a setup file in the text format whose SHA-256
+// digest must be digest.
+func File(path string, digest ckzg4844.Bytes32) Loader {
+	return func(context.Context) error {
+		return ckzg4844.LoadTrustedSetupFileWithDigest(path, digest)
+	}
+}
+
+// Register adds a loader under name. It returns ErrDuplicateSetup if the name
+// is already taken.
+func Register(name string, loader Loader) error {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := registry[name]; ok {
+		return ErrDuplicateSetup
+	}
+	registry[name] = loader
+	return nil
+}
+
+// Lookup returns the loader registered under name.
+func Lookup(name string) (Loader, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	loader, ok := registry[name]
+	return loader, ok
+}
+
+// Load loads the setup registered under name.
+func Load(ctx context.Context, name string) error {
+	loader, ok := Lookup(name)
+	if !ok {
+		return ErrUnknownSetup
+	}
+	return loader(ctx)
+}
+
+// Names returns the registered names in sorted order.
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,53 @@
+package setups
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"os"
+	"testing"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+	"github.com/stretchr/testify/require"
+)
+
+const trustedSetupFile = "../../../src/trusted_setup.txt"
+
+func TestRegistry(t *testing.T) {
+	require.Equal(t, []string{"mainnet", "minimal"}, Names())
+	require.ErrorIs(t, Register("mainnet", Mainnet()), ErrDuplicateSetup)
+	require.ErrorIs(t, Load(context.Background(), "devnet"), ErrUnknownSetup)
+
+	require.NoError(t, Register("devnet", File(trustedSetupFile, ckzg4844.MainnetTrustedSetupDigest)))
+	require.Equal(t, []string{"devnet", "mainnet", "minimal"}, Names())
+	require.NoError(t, Load(context.Background(), "devnet"))
+	require.Equal(t, ckzg4844.MainnetTrustedSetupDigest, fingerprintOfFile(t))
+	ckzg4844.FreeTrustedSetup()
+}
+
+func TestMainnet(t *testing.T) {
+	// The shipped copy must be the file the digest was taken from.
+	data, err := os.ReadFile(trustedSetupFile)
+	require.NoError(t, err)
+	require.Equal(t, data, mainnetSetup)
+	require.Equal(t, ckzg4844.MainnetTrustedSetupDigest, ckzg4844.Bytes32(sha256.Sum256(mainnetSetup)))
+
+	// Loading does not touch the cache or the network.
+	dir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", dir)
+	t.Setenv("HOME", dir)
+	require.NoError(t, Load(context.Background(), "minimal"))
+	require.Equal(t, ckzg4844.MainnetTrustedSetupDigest, fingerprintOfFile(t))
+	ckzg4844.FreeTrustedSetup()
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+// fingerprintOfFile returns the digest of the loaded setup saved in the text
+// format, which for the mainnet setup is the digest of its file.
+func fingerprintOfFile(t *testing.T) ckzg4844.Bytes32 {
+	var buf bytes.Buffer
+	require.NoError(t, ckzg4844.SaveTrustedSetup(&buf))
+	return sha256.Sum256(buf.Bytes())
+}
@@ -0,0 +1,72 @@
+package ckzg4844
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// The Scan and Value methods below implement sql.Scanner and driver.Valuer.
+// Values are stored as raw bytes (e.g. bytea or BLOB columns). When scanning,
+// 0x-prefixed hex text is accepted as well, for text columns.
+
+///////////////////////////////////////////////////////////////////////////////
+// Helper Functions
+///////////////////////////////////////////////////////////////////////////////
+
+func scanBytes(dst []byte, unmarshalText func([]byte) error, src interface{}) error {
+	switch src := src.(type) {
+	case []byte:
+		if len(src) == len(dst) {
+			copy(dst, src)
+			return nil
+		}
+		return unmarshalText(src)
+	case string:
+		return unmarshalText([]byte(src))
+	}
+	return fmt.Errorf("%w: cannot scan %T into a %v-byte value", ErrBadArgs, src, len(dst))
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Scanner Functions
+///////////////////////////////////////////////////////////////////////////////
+
+func (b *Bytes32) Scan(src interface{}) error {
+	return scanBytes(b[:], b.UnmarshalText, src)
+}
+
+func (b *Bytes48) Scan(src interface{}) error {
+	return scanBytes(b[:], b.UnmarshalText, src)
+}
+
+func (c *KZGCommitment) Scan(src interface{}) error {
+	return scanBytes(c[:], c.UnmarshalText, src)
+}
+
+func (p *KZGProof) Scan(src interface{}) error {
+	return scanBytes(p[:], p.UnmarshalText, src)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Valuer Functions
+///////////////////////////////////////////////////////////////////////////////
+
+// Value returns the raw bytes.
+func (b Bytes32) Value() (driver.Value, error) {
+	return b[:], nil
+}
+
+// Value returns the raw bytes.
+func (b Bytes48) Value() (driver.Value, error) {
+	return b[:], nil
+}
+
+// Value returns the raw bytes.
+func (c KZGCommitment) Value() (driver.Value, error) {
+	return c[:], nil
+}
+
+// Value returns the raw bytes.
+func (p KZGProof) Value() (driver.Value, error) {
+	return p[:], nil
+}
@@ -0,0 +1,71 @@
+package ckzg4844
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ sql.Scanner   = (*KZGCommitment)(nil)
+	_ driver.Valuer = KZGCommitment{}
+	_ sql.Scanner   = (*KZGProof)(nil)
+	_ driver.Valuer = KZGProof{}
+	_ sql.Scanner   = (*Bytes48)(nil)
+	_ driver.Valuer = Bytes48{}
+	_ sql.Scanner   = (*Bytes32)(nil)
+	_ driver.Valuer = Bytes32{}
+)
+
+func TestSQLRoundTrip(t *testing.T) {
+	var blob Blob
+	fillBlobRandom(&blob, 0)
+	commitment, err := BlobToKZGCommitment(&blob)
+	require.NoError(t, err)
+	proof, err := ComputeBlobKZGProof(&blob, Bytes48(commitment))
+	require.NoError(t, err)
+	z := getRandFieldElement(0)
+
+	value, err := commitment.Value()
+	require.NoError(t, err)
+	require.Equal(t, commitment[:], value)
+	var scannedCommitment KZGCommitment
+	require.NoError(t, scannedCommitment.Scan(value))
+	require.Equal(t, commitment, scannedCommitment)
+
+	value, err = proof.Value()
+	require.NoError(t, err)
+	var scannedProof KZGProof
+	require.NoError(t, scannedProof.Scan(value))
+	require.Equal(t, proof, scannedProof)
+
+	value, err = z.Value()
+	require.NoError(t, err)
+	var scannedZ Bytes32
+	require.NoError(t, scannedZ.Scan(value))
+	require.Equal(t, z, scannedZ)
+}
+
+func TestSQLScanText(t *testing.T) {
+	var b Bytes48
+	b[0] = 0xc0
+	text := "0x" + hex.EncodeToString(b[:])
+
+	var fromString, fromBytes Bytes48
+	require.NoError(t, fromString.Scan(text))
+	require.Equal(t, b, fromString)
+	require.NoError(t, fromBytes.Scan([]byte(text)))
+	require.Equal(t, b, fromBytes)
+}
+
+func TestSQLScanInvalid(t *testing.T) {
+	var b Bytes48
+	require.ErrorIs(t, b.Scan(nil), ErrBadArgs)
+	require.ErrorIs(t, b.Scan(int64(1)), ErrBadArgs)
+	require.ErrorIs(t, b.Scan(make([]byte, 47)), ErrBadArgs)
+	var z Bytes32
+	require.ErrorIs(t, z.Scan("0x00"), ErrBadArgs)
+}
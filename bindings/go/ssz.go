@@ -0,0 +1,133 @@
+package ckzg4844
+
+import (
+	"crypto/sha256"
+)
+
+// The SSZ methods below have the same signatures as the ones generated by
+// fastssz, so these types can be embedded in SSZ containers directly. All of
+// them are fixed-size byte vectors, which serialize as their raw bytes.
+
+///////////////////////////////////////////////////////////////////////////////
+// Helper Functions
+///////////////////////////////////////////////////////////////////////////////
+
+// merkleize returns the SSZ hash tree root of a fixed-size byte vector. The
+// bytes are split into 32-byte chunks (the last one zero-padded) and the
+// number of chunks is padded with zero chunks to the next power of two.
+func merkleize(b []byte) [32]byte {
+	numChunks := (len(b) + 31) / 32
+	width := 1
+	for width < numChunks {
+		width *= 2
+	}
+	layer := make([]byte, width*32)
+	copy(layer, b)
+	for width > 1 {
+		for i := 0; i < width/2; i++ {
+			sum := sha256.Sum256(layer[64*i : 64*i+64])
+			copy(layer[32*i:], sum[:])
+		}
+		width /= 2
+	}
+	var root [32]byte
+	copy(root[:], layer[:32])
+	return root
+}
+
+func unmarshalSSZ(dst, buf []byte) error {
+	if len(buf) != len(dst) {
+		return ErrBadArgs
+	}
+	copy(dst, buf)
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Blob
+///////////////////////////////////////////////////////////////////////////////
+
+// SizeSSZ returns the size of the SSZ encoding.
+func (b *Blob) SizeSSZ() int {
+	return BytesPerBlob
+}
+
+// MarshalSSZ returns the SSZ encoding.
+func (b *Blob) MarshalSSZ() ([]byte, error) {
+	return b.MarshalSSZTo(nil)
+}
+
+// MarshalSSZTo appends the SSZ encoding to dst.
+func (b *Blob) MarshalSSZTo(dst []byte) ([]byte, error) {
+	return append(dst, b[:]...), nil
+}
+
+// UnmarshalSSZ decodes an SSZ encoding, which must be exactly BytesPerBlob long.
+func (b *Blob) UnmarshalSSZ(buf []byte) error {
+	return unmarshalSSZ(b[:], buf)
+}
+
+// HashTreeRoot returns the SSZ hash tree root.
+func (b *Blob) HashTreeRoot() ([32]byte, error) {
+	return merkleize(b[:]), nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// KZGCommitment
+///////////////////////////////////////////////////////////////////////////////
+
+// SizeSSZ returns the size of the SSZ encoding.
+func (c *KZGCommitment) SizeSSZ() int {
+	return BytesPerCommitment
+}
+
+// MarshalSSZ returns the SSZ encoding.
+func (c *KZGCommitment) MarshalSSZ() ([]byte, error) {
+	return c.MarshalSSZTo(nil)
+}
+
+// MarshalSSZTo appends the SSZ encoding to dst.
+func (c *KZGCommitment) MarshalSSZTo(dst []byte) ([]byte, error) {
+	return append(dst, c[:]...), nil
+}
+
+// UnmarshalSSZ decodes an SSZ encoding, which must be exactly
+// BytesPerCommitment long.
+func (c *KZGCommitment) UnmarshalSSZ(buf []byte) error {
+	return unmarshalSSZ(c[:], buf)
+}
+
+// HashTreeRoot returns the SSZ hash tree root.
+func (c *KZGCommitment) HashTreeRoot() ([32]byte, error) {
+	return merkleize(c[:]), nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// KZGProof
+///////////////////////////////////////////////////////////////////////////////
+
+// SizeSSZ returns the size of the SSZ encoding.
+func (p *KZGProof) SizeSSZ() int {
+	return BytesPerProof
+}
+
+// MarshalSSZ returns the SSZ encoding.
+func (p *KZGProof) MarshalSSZ() ([]byte, error) {
+	return p.MarshalSSZTo(nil)
+}
+
+// MarshalSSZTo appends the SSZ encoding to dst.
+func (p *KZGProof) MarshalSSZTo(dst []byte) ([]byte, error) {
+	return append(dst, p[:]...), nil
+}
+
+// UnmarshalSSZ decodes an SSZ encoding, which must be exactly BytesPerProof
+// long.
+func (p *KZGProof) UnmarshalSSZ(buf []byte) error {
+	return unmarshalSSZ(p[:], buf)
+}
+
+// HashTreeRoot returns the SSZ hash tree root.
+func (p *KZGProof) HashTreeRoot() ([32]byte, error) {
+	return merkleize(p[:]), nil
+}
@@ -0,0 +1,69 @@
+package ckzg4844
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSSZRoundTrip(t *testing.T) {
+	blob := new(Blob)
+	fillBlobRandom(blob, 0)
+	commitment, err := BlobToKZGCommitment(blob)
+	require.NoError(t, err)
+	proof, err := ComputeBlobKZGProof(blob, Bytes48(commitment))
+	require.NoError(t, err)
+
+	encoded, err := blob.MarshalSSZ()
+	require.NoError(t, err)
+	require.Equal(t, blob.SizeSSZ(), len(encoded))
+	decodedBlob := new(Blob)
+	require.NoError(t, decodedBlob.UnmarshalSSZ(encoded))
+	require.Equal(t, blob, decodedBlob)
+
+	encoded, err = commitment.MarshalSSZTo([]byte{0xff})
+	require.NoError(t, err)
+	require.Equal(t, 1+commitment.SizeSSZ(), len(encoded))
+	var decodedCommitment KZGCommitment
+	require.NoError(t, decodedCommitment.UnmarshalSSZ(encoded[1:]))
+	require.Equal(t, commitment, decodedCommitment)
+
+	encoded, err = proof.MarshalSSZ()
+	require.NoError(t, err)
+	var decodedProof KZGProof
+	require.NoError(t, decodedProof.UnmarshalSSZ(encoded))
+	require.Equal(t, proof, decodedProof)
+
+	require.ErrorIs(t, decodedProof.UnmarshalSSZ(encoded[1:]), ErrBadArgs)
+	require.ErrorIs(t, decodedBlob.UnmarshalSSZ(encoded), ErrBadArgs)
+}
+
+func TestSSZHashTreeRoot(t *testing.T) {
+	// A 48-byte vector is two chunks, the second one zero-padded.
+	var commitment KZGCommitment
+	for i := range commitment {
+		commitment[i] = byte(i)
+	}
+	var chunks [64]byte
+	copy(chunks[:], commitment[:])
+	root, err := commitment.HashTreeRoot()
+	require.NoError(t, err)
+	require.Equal(t, sha256.Sum256(chunks[:]), root)
+
+	// An empty blob hashes to the zero hash at depth log2(4096) = 12.
+	var zeroHash [32]byte
+	for i := 0; i < 12; i++ {
+		zeroHash = sha256.Sum256(append(zeroHash[:], zeroHash[:]...))
+	}
+	root, err = new(Blob).HashTreeRoot()
+	require.NoError(t, err)
+	require.Equal(t, zeroHash, root)
+
+	// Changing a single byte changes the root.
+	blob := new(Blob)
+	blob[BytesPerBlob-1] = 1
+	root, err = blob.HashTreeRoot()
+	require.NoError(t, err)
+	require.NotEqual(t, zeroHash, root)
+}
@@ -0,0 +1,295 @@
+package ckzg4844
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// MainnetTrustedSetupDigest is the SHA-256 digest of the canonical mainnet
+// trusted setup, src/trusted_setup.txt, in the text format.
+var MainnetTrustedSetupDigest = Bytes32{
+	0x19, 0xd2, 0xf6, 0x02, 0x9b, 0x7f, 0x04, 0x52,
+	0xc2, 0x74, 0x73, 0xdf, 0xe2, 0x76, 0x1a, 0x99,
+	0xb8, 0xdd, 0x36, 0x8a, 0x13, 0x4c, 0xf2, 0xba,
+	0xc0, 0x64, 0xf8, 0xc5, 0xb5, 0x69, 0x91, 0x9c,
+}
+
+// ParseTrustedSetup parses the text format read by LoadTrustedSetupFile into
+// the arguments of LoadTrustedSetup, for loading it into another backend. It
+// returns ErrBadArgs if the data is malformed.
+func ParseTrustedSetup(data []byte) (g1Bytes, g2Bytes []byte, err error) {
+	fields := bytes.Fields(data)
+	if len(fields) < 2 {
+		return nil, nil, ErrBadArgs
+	}
+	numG1Points, err1 := strconv.Atoi(string(fields[0]))
+	numG2Points, err2 := strconv.Atoi(string(fields[1]))
+	if err1 != nil || err2 != nil || numG1Points < 0 || numG2Points < 0 || len(fields) != 2+numG1Points+numG2Points {
+		return nil, nil, ErrBadArgs
+	}
+	decode := func(points [][]byte, size int) ([]byte, error) {
+		out := make([]byte, len(points)*size)
+		for i, point := range points {
+			if len(point) != 2*size {
+				return nil, ErrBadArgs
+			}
+			if _, err := hex.Decode(out[i*size:], point); err != nil {
+				return nil, ErrBadArgs
+			}
+		}
+		return out, nil
+	}
+	if g1Bytes, err = decode(fields[2:2+numG1Points], bytesPerG1); err != nil {
+		return nil, nil, err
+	}
+	if g2Bytes, err = decode(fields[2+numG1Points:], bytesPerG2); err != nil {
+		return nil, nil, err
+	}
+	return g1Bytes, g2Bytes, nil
+}
+
+// LoadTrustedSetupVerified loads the trusted setup file only if its SHA-256
+// digest is MainnetTrustedSetupDigest, and returns
+// ErrTrustedSetupDigestMismatch otherwise. The bytes that are hashed are the
+// bytes that are loaded, so the file cannot change in between.
+func LoadTrustedSetupVerified(trustedSetupFile string) error {
+	return LoadTrustedSetupFileWithDigest(trustedSetupFile, MainnetTrustedSetupDigest)
+}
+
+// LoadTrustedSetupFileWithDigest is like LoadTrustedSetupVerified, but checks
+// against the given digest, for custom setups.
+func LoadTrustedSetupFileWithDigest(trustedSetupFile string, digest Bytes32) error {
+	if isLoaded() {
+		panic("trusted setup is already loaded")
+	}
+	data, err := os.ReadFile(trustedSetupFile)
+	if err != nil {
+		return err
+	}
+	return loadTrustedSetupBytesWithDigest(data, digest)
+}
+
+// LoadTrustedSetupText loads a trusted setup in the text format of
+// LoadTrustedSetupFile from memory, such as a setup embedded in a binary or
+// bundled with a mobile application. It returns ErrBadArgs if the data is
+// malformed.
+func LoadTrustedSetupText(data []byte) error {
+	if isLoaded() {
+		panic("trusted setup is already loaded")
+	}
+	g1Bytes, g2Bytes, err := ParseTrustedSetup(data)
+	if err != nil {
+		return err
+	}
+	return LoadTrustedSetup(g1Bytes, g2Bytes)
+}
+
+// loadTrustedSetupBytesWithDigest loads a trusted setup in the text format
+// after checking its digest.
+func loadTrustedSetupBytesWithDigest(data []byte, digest Bytes32) error {
+	if sha256.Sum256(data) != digest {
+		return ErrTrustedSetupDigestMismatch
+	}
+	g1Bytes, g2Bytes, err := ParseTrustedSetup(data)
+	if err != nil {
+		return err
+	}
+	return LoadTrustedSetup(g1Bytes, g2Bytes)
+}
+
+// FingerprintTrustedSetup returns a digest identifying the trusted setup with
+// the given LoadTrustedSetup arguments: the SHA-256 of the number of G1 and
+// G2 points, as big-endian uint64s, followed by the points.
+func FingerprintTrustedSetup(g1Bytes, g2Bytes []byte) Bytes32 {
+	h := sha256.New()
+	var lengths [16]byte
+	binary.BigEndian.PutUint64(lengths[:8], uint64(len(g1Bytes)/bytesPerG1))
+	binary.BigEndian.PutUint64(lengths[8:], uint64(len(g2Bytes)/bytesPerG2))
+	h.Write(lengths[:])
+	h.Write(g1Bytes)
+	h.Write(g2Bytes)
+	var fingerprint Bytes32
+	h.Sum(fingerprint[:0])
+	return fingerprint
+}
+
+// TrustedSetupFingerprint returns the fingerprint of the loaded trusted setup.
+// Two processes use the same setup exactly when their fingerprints are equal,
+// regardless of the format it was loaded from.
+func TrustedSetupFingerprint() Bytes32 {
+	return FingerprintTrustedSetup(TrustedSetupBytes())
+}
+
+// maxTrustedSetupSize bounds the size of a downloaded trusted setup. The
+// mainnet setup in the text format is about 800KB.
+const maxTrustedSetupSize = 4 << 20
+
+// FetchTrustedSetup downloads the trusted setup in the text format from url,
+// verifies that its SHA-256 digest is expectedDigest and loads it. The file is
+// kept in CacheDir under its digest, so later calls load it without a
+// download.
+func FetchTrustedSetup(ctx context.Context, url string, expectedDigest Bytes32) error {
+	return fetchTrustedSetup(ctx, http.DefaultClient, url, expectedDigest)
+}
+
+// fetchTrustedSetup implements FetchTrustedSetup with the given client.
+func fetchTrustedSetup(ctx context.Context, client *http.Client, url string, expectedDigest Bytes32) error {
+	if isLoaded() {
+		panic("trusted setup is already loaded")
+	}
+	cacheName := hex.EncodeToString(expectedDigest[:]) + ".txt"
+	if data, ok := readCache(cacheName); ok {
+		if err := loadTrustedSetupBytesWithDigest(data, expectedDigest); err == nil {
+			return nil
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetching trusted setup: unexpected status %v", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxTrustedSetupSize+1))
+	if err != nil {
+		return err
+	}
+	if len(data) > maxTrustedSetupSize {
+		return fmt.Errorf("fetching trusted setup: response exceeds %v bytes", maxTrustedSetupSize)
+	}
+	if err := loadTrustedSetupBytesWithDigest(data, expectedDigest); err != nil {
+		return err
+	}
+	writeCache(cacheName, data)
+	return nil
+}
+
+// decodePoints concatenates hex-encoded points of size bytes each.
+func decodePoints(points []string, size int) ([]byte, error) {
+	out := make([]byte, 0, len(points)*size)
+	for _, point := range points {
+		b, err := hex.DecodeString(strings.TrimPrefix(point, "0x"))
+		if err != nil {
+			return nil, err
+		}
+		if len(b) != size {
+			return nil, ErrBadArgs
+		}
+		out = append(out, b...)
+	}
+	return out, nil
+}
+
+/*
+LoadTrustedSetupMonomial is like LoadTrustedSetup, but takes the G1 points in
+monomial form and converts them to Lagrange form first. It returns ErrBadArgs
+if the points are not in monomial form. The conversion takes a few seconds, so
+its result is kept in CacheDir, and checked with VerifyTrustedSetup when it is
+loaded from there.
+*/
+func LoadTrustedSetupMonomial(g1MonomialBytes, g2Bytes []byte) error {
+	if isLoaded() {
+		panic("trusted setup is already loaded")
+	}
+	if !isMonomialForm(g1MonomialBytes, g2Bytes) {
+		return ErrBadArgs
+	}
+	digest := sha256.Sum256(append(append([]byte(nil), g1MonomialBytes...), g2Bytes...))
+	cacheName := hex.EncodeToString(digest[:]) + ".lagrange"
+	if g1Bytes, ok := readCache(cacheName); ok && len(g1Bytes) == len(g1MonomialBytes) {
+		// Nothing ties the entry to the input but its name, so it is only used
+		// if it is the Lagrange form of a setup for the secret of the G2
+		// points, which are part of the input. Otherwise it is recomputed.
+		if err := LoadTrustedSetup(g1Bytes, g2Bytes); err == nil {
+			if VerifyTrustedSetup() == nil {
+				return nil
+			}
+			FreeTrustedSetup()
+		}
+	}
+	g1Bytes, err := convertG1(g1MonomialBytes, true)
+	if err != nil {
+		return err
+	}
+	if err := LoadTrustedSetup(g1Bytes, g2Bytes); err != nil {
+		return err
+	}
+	writeCache(cacheName, g1Bytes)
+	return nil
+}
+
+/*
+LoadTrustedSetupJSON loads a trusted setup in the JSON format published by the
+KZG ceremony, using its g1_lagrange and g2_monomial arrays of 0x-prefixed hex
+points. If g1_lagrange is absent, the G1 points are taken from g1_monomial and
+converted to Lagrange form.
+*/
+func LoadTrustedSetupJSON(data []byte) error {
+	if isLoaded() {
+		panic("trusted setup is already loaded")
+	}
+	var trustedSetup struct {
+		G1Lagrange []string `json:"g1_lagrange"`
+		G1Monomial []string `json:"g1_monomial"`
+		G2Monomial []string `json:"g2_monomial"`
+	}
+	if err := json.Unmarshal(data, &trustedSetup); err != nil {
+		return err
+	}
+	g2Bytes, err := decodePoints(trustedSetup.G2Monomial, bytesPerG2)
+	if err != nil {
+		return err
+	}
+	if len(trustedSetup.G1Lagrange) == 0 && len(trustedSetup.G1Monomial) != 0 {
+		g1Bytes, err := decodePoints(trustedSetup.G1Monomial, bytesPerG1)
+		if err != nil {
+			return err
+		}
+		return LoadTrustedSetupMonomial(g1Bytes, g2Bytes)
+	}
+	g1Bytes, err := decodePoints(trustedSetup.G1Lagrange, bytesPerG1)
+	if err != nil {
+		return err
+	}
+	return LoadTrustedSetup(g1Bytes, g2Bytes)
+}
+
+// SetupOptions configures how a trusted setup is loaded. The C library has no
+// load-time options yet, such as precomputation tables, so the zero value
+// describes the only mode.
+type SetupOptions struct{}
+
+/*
+SaveTrustedSetup writes the loaded trusted setup to w in the text format read
+by LoadTrustedSetupFile: the number of G1 and G2 points, followed by each point
+as a hex string on its own line.
+*/
+func SaveTrustedSetup(w io.Writer) error {
+	g1Bytes, g2Bytes := TrustedSetupBytes()
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%v\n%v\n", trustedSetupNumG1Points, trustedSetupNumG2Points)
+	for i := 0; i < len(g1Bytes); i += bytesPerG1 {
+		fmt.Fprintf(bw, "%x\n", g1Bytes[i:i+bytesPerG1])
+	}
+	for i := 0; i < len(g2Bytes); i += bytesPerG2 {
+		fmt.Fprintf(bw, "%x\n", g2Bytes[i:i+bytesPerG2])
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,206 @@
+package ckzg4844
+
+import (
+	"context"
+	"crypto/sha256"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadTrustedSetupVerified(t *testing.T) {
+	g1Bytes, g2Bytes := TrustedSetupBytes()
+	FreeTrustedSetup()
+	defer func() {
+		require.NoError(t, LoadTrustedSetup(g1Bytes, g2Bytes))
+	}()
+
+	require.NoError(t, LoadTrustedSetupVerified("../../src/trusted_setup.txt"))
+	reloaded, _ := TrustedSetupBytes()
+	require.Equal(t, g1Bytes, reloaded)
+	FreeTrustedSetup()
+
+	// A modified copy is only accepted with its own digest.
+	data, err := os.ReadFile("../../src/trusted_setup.txt")
+	require.NoError(t, err)
+	data = append(data, '\n')
+	path := filepath.Join(t.TempDir(), "trusted_setup.txt")
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+	require.ErrorIs(t, LoadTrustedSetupVerified(path), ErrTrustedSetupDigestMismatch)
+	require.NoError(t, LoadTrustedSetupFileWithDigest(path, sha256.Sum256(data)))
+	FreeTrustedSetup()
+
+	// A digest match does not skip parsing.
+	data = []byte("4096 65 00")
+	require.ErrorIs(t, loadTrustedSetupBytesWithDigest(data, sha256.Sum256(data)), ErrBadArgs)
+}
+
+func TestLoadTrustedSetupText(t *testing.T) {
+	g1Bytes, g2Bytes := TrustedSetupBytes()
+	FreeTrustedSetup()
+	defer func() {
+		require.NoError(t, LoadTrustedSetup(g1Bytes, g2Bytes))
+	}()
+
+	data, err := os.ReadFile("../../src/trusted_setup.txt")
+	require.NoError(t, err)
+	require.ErrorIs(t, LoadTrustedSetupText(data[:len(data)/2]), ErrBadArgs)
+	require.NoError(t, LoadTrustedSetupText(data))
+	reloaded, _ := TrustedSetupBytes()
+	require.Equal(t, g1Bytes, reloaded)
+	FreeTrustedSetup()
+}
+
+func TestParseTrustedSetup(t *testing.T) {
+	data, err := os.ReadFile("../../src/trusted_setup.txt")
+	require.NoError(t, err)
+	g1Bytes, g2Bytes, err := ParseTrustedSetup(data)
+	require.NoError(t, err)
+	expectedG1, expectedG2 := TrustedSetupBytes()
+	require.Equal(t, expectedG1, g1Bytes)
+	require.Equal(t, expectedG2, g2Bytes)
+
+	for _, bad := range []string{"", "4096\n", "x\n65\n", "1\n1\n00\n", strings.Join(strings.Split(string(data), "\n")[:100], "\n")} {
+		_, _, err := ParseTrustedSetup([]byte(bad))
+		require.ErrorIs(t, err, ErrBadArgs, "%q", bad)
+	}
+}
+
+func TestLoadTrustedSetupFileUnicodePath(t *testing.T) {
+	g1Bytes, g2Bytes := TrustedSetupBytes()
+	FreeTrustedSetup()
+	defer func() {
+		require.NoError(t, LoadTrustedSetup(g1Bytes, g2Bytes))
+	}()
+
+	data, err := os.ReadFile("../../src/trusted_setup.txt")
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "configuración", "セットアップ.txt")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+	require.NoError(t, LoadTrustedSetupFile(path))
+	FreeTrustedSetup()
+}
+
+func TestFetchTrustedSetup(t *testing.T) {
+	data, err := os.ReadFile("../../src/trusted_setup.txt")
+	require.NoError(t, err)
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/trusted_setup.txt":
+			w.Write(data)
+		case "/corrupt.txt":
+			w.Write(append(data[:len(data):len(data)], '\n'))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	g1Bytes, g2Bytes := TrustedSetupBytes()
+	FreeTrustedSetup()
+	defer func() {
+		require.NoError(t, LoadTrustedSetup(g1Bytes, g2Bytes))
+	}()
+	ctx := context.Background()
+	setCacheDir(t)
+
+	err = fetchTrustedSetup(ctx, server.Client(), server.URL+"/corrupt.txt", MainnetTrustedSetupDigest)
+	require.ErrorIs(t, err, ErrTrustedSetupDigestMismatch)
+	err = fetchTrustedSetup(ctx, server.Client(), server.URL+"/missing.txt", MainnetTrustedSetupDigest)
+	require.Error(t, err)
+
+	require.NoError(t, fetchTrustedSetup(ctx, server.Client(), server.URL+"/trusted_setup.txt", MainnetTrustedSetupDigest))
+	reloaded, _ := TrustedSetupBytes()
+	require.Equal(t, g1Bytes, reloaded)
+	FreeTrustedSetup()
+	require.Equal(t, 3, requests)
+
+	// The second fetch is served from the cache.
+	require.NoError(t, fetchTrustedSetup(ctx, server.Client(), server.URL+"/trusted_setup.txt", MainnetTrustedSetupDigest))
+	FreeTrustedSetup()
+	require.Equal(t, 3, requests)
+}
+
+func TestVerifyTrustedSetup(t *testing.T) {
+	require.NoError(t, VerifyTrustedSetup())
+
+	g1Bytes, g2Bytes := TrustedSetupBytes()
+	FreeTrustedSetup()
+	defer func() {
+		require.NoError(t, LoadTrustedSetup(g1Bytes, g2Bytes))
+	}()
+	swap := func(b []byte, size, i, j int) []byte {
+		b = append([]byte(nil), b...)
+		tmp := append([]byte(nil), b[i*size:(i+1)*size]...)
+		copy(b[i*size:], b[j*size:(j+1)*size])
+		copy(b[j*size:], tmp)
+		return b
+	}
+
+	// Swapped Lagrange points still load, but are not a Lagrange basis.
+	require.NoError(t, LoadTrustedSetup(swap(g1Bytes, bytesPerG1, 10, 20), g2Bytes))
+	require.ErrorIs(t, VerifyTrustedSetup(), ErrInvalidTrustedSetup)
+	FreeTrustedSetup()
+
+	require.NoError(t, LoadTrustedSetup(g1Bytes, swap(g2Bytes, bytesPerG2, 5, 6)))
+	require.ErrorIs(t, VerifyTrustedSetup(), ErrInvalidTrustedSetup)
+	FreeTrustedSetup()
+}
+
+func TestGenerateInsecureSetup(t *testing.T) {
+	_, _, err := GenerateInsecureSetup(Bytes32{31: 7}, 16)
+	require.ErrorIs(t, err, ErrBadArgs)
+	_, _, err = GenerateInsecureSetup(Bytes32{}, FieldElementsPerBlob)
+	require.ErrorIs(t, err, ErrBadArgs)
+	_, _, err = GenerateInsecureSetup(Bytes32{31: 1}, FieldElementsPerBlob)
+	require.ErrorIs(t, err, ErrBadArgs)
+
+	insecureG1, insecureG2, err := GenerateInsecureSetup(Bytes32{31: 7}, FieldElementsPerBlob)
+	require.NoError(t, err)
+
+	g1Bytes, g2Bytes := TrustedSetupBytes()
+	FreeTrustedSetup()
+	defer func() {
+		require.NoError(t, LoadTrustedSetup(g1Bytes, g2Bytes))
+	}()
+	require.NoError(t, LoadTrustedSetup(insecureG1, insecureG2))
+	defer FreeTrustedSetup()
+	require.NoError(t, VerifyTrustedSetup())
+
+	blob := Blob{}
+	fillBlobRandom(&blob, 0)
+	commitment, err := BlobToKZGCommitment(&blob)
+	require.NoError(t, err)
+	proof, err := ComputeBlobKZGProof(&blob, Bytes48(commitment))
+	require.NoError(t, err)
+	ok, err := VerifyBlobKZGProof(&blob, Bytes48(commitment), Bytes48(proof))
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestTrustedSetupFingerprint(t *testing.T) {
+	g1Bytes, g2Bytes := TrustedSetupBytes()
+	fingerprint := TrustedSetupFingerprint()
+	require.Equal(t, fingerprint, FingerprintTrustedSetup(g1Bytes, g2Bytes))
+
+	insecureG1, insecureG2, err := GenerateInsecureSetup(Bytes32{31: 7}, FieldElementsPerBlob)
+	require.NoError(t, err)
+	require.NotEqual(t, fingerprint, FingerprintTrustedSetup(insecureG1, insecureG2))
+
+	// The same setup loaded from another format has the same fingerprint.
+	FreeTrustedSetup()
+	defer func() {
+		require.NoError(t, LoadTrustedSetup(g1Bytes, g2Bytes))
+	}()
+	require.NoError(t, LoadTrustedSetupVerified("../../src/trusted_setup.txt"))
+	require.Equal(t, fingerprint, TrustedSetupFingerprint())
+	FreeTrustedSetup()
+}
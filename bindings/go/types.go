@@ -0,0 +1,406 @@
+package ckzg4844
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+)
+
+const (
+	BytesPerBlob         = 131072
+	BytesPerCommitment   = 48
+	BytesPerFieldElement = 32
+	BytesPerProof        = 48
+	FieldElementsPerBlob = 4096
+
+	bytesPerG1 = 48
+	bytesPerG2 = 96
+
+	trustedSetupNumG1Points = FieldElementsPerBlob
+	trustedSetupNumG2Points = 65
+)
+
+type (
+	Bytes32       [32]byte
+	Bytes48       [48]byte
+	KZGCommitment Bytes48
+	KZGProof      Bytes48
+	Blob          [BytesPerBlob]byte
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// Marshal Functions
+///////////////////////////////////////////////////////////////////////////////
+
+// MarshalText encodes the bytes as a 0x-prefixed hex string.
+func (b Bytes32) MarshalText() ([]byte, error) {
+	return []byte("0x" + hex.EncodeToString(b[:])), nil
+}
+
+// MarshalText encodes the bytes as a 0x-prefixed hex string.
+func (b Bytes48) MarshalText() ([]byte, error) {
+	return []byte("0x" + hex.EncodeToString(b[:])), nil
+}
+
+// MarshalText encodes the blob as a 0x-prefixed hex string. It has a pointer
+// receiver to avoid copying the blob, so marshal a *Blob (or an addressable
+// Blob field) to get the hex encoding from encoding/json.
+func (b *Blob) MarshalText() ([]byte, error) {
+	return []byte("0x" + hex.EncodeToString(b[:])), nil
+}
+
+// MarshalText encodes the commitment as a 0x-prefixed hex string.
+func (c KZGCommitment) MarshalText() ([]byte, error) {
+	return Bytes48(c).MarshalText()
+}
+
+// MarshalText encodes the proof as a 0x-prefixed hex string.
+func (p KZGProof) MarshalText() ([]byte, error) {
+	return Bytes48(p).MarshalText()
+}
+
+// MarshalBinary returns a copy of the raw bytes.
+func (b Bytes32) MarshalBinary() ([]byte, error) {
+	return append([]byte(nil), b[:]...), nil
+}
+
+// MarshalBinary returns a copy of the raw bytes.
+func (b Bytes48) MarshalBinary() ([]byte, error) {
+	return append([]byte(nil), b[:]...), nil
+}
+
+// MarshalBinary returns a copy of the raw bytes. Like MarshalText, it has a
+// pointer receiver to avoid copying the blob.
+func (b *Blob) MarshalBinary() ([]byte, error) {
+	return append([]byte(nil), b[:]...), nil
+}
+
+// MarshalBinary returns a copy of the raw bytes.
+func (c KZGCommitment) MarshalBinary() ([]byte, error) {
+	return Bytes48(c).MarshalBinary()
+}
+
+// MarshalBinary returns a copy of the raw bytes.
+func (p KZGProof) MarshalBinary() ([]byte, error) {
+	return Bytes48(p).MarshalBinary()
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// String Functions
+///////////////////////////////////////////////////////////////////////////////
+
+// String returns the bytes as a 0x-prefixed hex string.
+func (b Bytes32) String() string {
+	return "0x" + hex.EncodeToString(b[:])
+}
+
+// String returns the bytes as a 0x-prefixed hex string.
+func (b Bytes48) String() string {
+	return "0x" + hex.EncodeToString(b[:])
+}
+
+// String returns a truncated hex representation of the blob, showing its
+// first two and last byte, e.g. 0xabcd…ef(131072 bytes). Use MarshalText for
+// the full encoding. It has a pointer receiver to avoid copying the blob, so
+// pass a *Blob to the fmt functions to use it.
+func (b *Blob) String() string {
+	return fmt.Sprintf("0x%x…%x(%v bytes)", b[:2], b[len(b)-1:], len(b))
+}
+
+// String returns the commitment as a 0x-prefixed hex string.
+func (c KZGCommitment) String() string {
+	return Bytes48(c).String()
+}
+
+// String returns the proof as a 0x-prefixed hex string.
+func (p KZGProof) String() string {
+	return Bytes48(p).String()
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Comparison Functions
+///////////////////////////////////////////////////////////////////////////////
+
+// g1Infinity is the compressed encoding of the G1 point at infinity.
+var g1Infinity = Bytes48{0xc0}
+
+// Equal reports whether both blobs hold the same bytes. A nil blob is only
+// equal to another nil blob.
+func (b *Blob) Equal(other *Blob) bool {
+	if b == nil || other == nil {
+		return b == other
+	}
+	return *b == *other
+}
+
+// IsZero reports whether every byte in the blob is zero.
+func (b *Blob) IsZero() bool {
+	return *b == Blob{}
+}
+
+// Clone returns a copy of the blob that does not share memory with it.
+func (b *Blob) Clone() *Blob {
+	clone := *b
+	return &clone
+}
+
+// Equal reports whether both commitments hold the same bytes.
+func (c KZGCommitment) Equal(other KZGCommitment) bool {
+	return c == other
+}
+
+// IsZero reports whether every byte is zero, i.e. the commitment was never
+// set. This is not a valid encoding of any point.
+func (c KZGCommitment) IsZero() bool {
+	return c == KZGCommitment{}
+}
+
+// IsInfinity reports whether the commitment is the point at infinity, which
+// is the commitment to a blob of all zeros.
+func (c KZGCommitment) IsInfinity() bool {
+	return Bytes48(c) == g1Infinity
+}
+
+// Equal reports whether both proofs hold the same bytes. Use
+// ConstantTimeEqual when the comparison must not leak timing information.
+func (p KZGProof) Equal(other KZGProof) bool {
+	return p == other
+}
+
+// ConstantTimeEqual reports whether both proofs hold the same bytes, taking
+// the same amount of time regardless of their contents.
+func (p KZGProof) ConstantTimeEqual(other KZGProof) bool {
+	return subtle.ConstantTimeCompare(p[:], other[:]) == 1
+}
+
+// IsZero reports whether every byte is zero, i.e. the proof was never set.
+// This is not a valid encoding of any point.
+func (p KZGProof) IsZero() bool {
+	return p == KZGProof{}
+}
+
+// IsInfinity reports whether the proof is the point at infinity.
+func (p KZGProof) IsInfinity() bool {
+	return Bytes48(p) == g1Infinity
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Unmarshal Functions
+///////////////////////////////////////////////////////////////////////////////
+
+func (b *Bytes32) UnmarshalText(input []byte) error {
+	if bytes.HasPrefix(input, []byte("0x")) {
+		input = input[2:]
+	}
+	if len(input) != 2*len(b) {
+		return ErrBadArgs
+	}
+	l, err := hex.Decode(b[:], input)
+	if err != nil {
+		return err
+	}
+	if l != len(b) {
+		return ErrBadArgs
+	}
+	return nil
+}
+
+func (b *Bytes48) UnmarshalText(input []byte) error {
+	if bytes.HasPrefix(input, []byte("0x")) {
+		input = input[2:]
+	}
+	if len(input) != 2*len(b) {
+		return ErrBadArgs
+	}
+	l, err := hex.Decode(b[:], input)
+	if err != nil {
+		return err
+	}
+	if l != len(b) {
+		return ErrBadArgs
+	}
+	return nil
+}
+
+func (b *Blob) UnmarshalText(input []byte) error {
+	if bytes.HasPrefix(input, []byte("0x")) {
+		input = input[2:]
+	}
+	if len(input) != 2*len(b) {
+		return ErrBadArgs
+	}
+	l, err := hex.Decode(b[:], input)
+	if err != nil {
+		return err
+	}
+	if l != len(b) {
+		return ErrBadArgs
+	}
+	return nil
+}
+
+func (c *KZGCommitment) UnmarshalText(input []byte) error {
+	return (*Bytes48)(c).UnmarshalText(input)
+}
+
+func (p *KZGProof) UnmarshalText(input []byte) error {
+	return (*Bytes48)(p).UnmarshalText(input)
+}
+
+func (b *Bytes32) UnmarshalBinary(data []byte) error {
+	if len(data) != len(b) {
+		return ErrBadArgs
+	}
+	copy(b[:], data)
+	return nil
+}
+
+func (b *Bytes48) UnmarshalBinary(data []byte) error {
+	if len(data) != len(b) {
+		return ErrBadArgs
+	}
+	copy(b[:], data)
+	return nil
+}
+
+func (b *Blob) UnmarshalBinary(data []byte) error {
+	if len(data) != len(b) {
+		return ErrBadArgs
+	}
+	copy(b[:], data)
+	return nil
+}
+
+func (c *KZGCommitment) UnmarshalBinary(data []byte) error {
+	return (*Bytes48)(c).UnmarshalBinary(data)
+}
+
+func (p *KZGProof) UnmarshalBinary(data []byte) error {
+	return (*Bytes48)(p).UnmarshalBinary(data)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Validation Functions
+///////////////////////////////////////////////////////////////////////////////
+
+// ValidateFieldElement returns ErrBadArgs if the bytes are not a canonical
+// (big-endian, less than the modulus) BLS scalar field element.
+func ValidateFieldElement(fieldElementBytes Bytes32) error {
+	return checkFieldElement("field element", fieldElementBytes)
+}
+
+// ValidateBlob returns ErrBadArgs if any field element in the blob is not
+// canonical.
+func ValidateBlob(blob *Blob) error {
+	if blob == nil {
+		return ErrBadArgs
+	}
+	return checkBlob("blob", blob)
+}
+
+// ValidateG1 returns ErrBadArgs if the bytes are not a valid compressed G1
+// point in the correct subgroup, as required for commitments and proofs.
+// The point at infinity is accepted.
+func ValidateG1(g1Bytes Bytes48) error {
+	return checkG1("point", g1Bytes)
+}
+
+// checkBlob returns an error wrapping ErrBadArgs that names the first field
+// element of the named blob that is not canonical.
+func checkBlob(name string, blob *Blob) error {
+	for i := 0; i < FieldElementsPerBlob; i++ {
+		offset := i * BytesPerFieldElement
+		if err := checkFieldElement(fmt.Sprintf("%v field element %v", name, i), *(*Bytes32)(blob[offset : offset+BytesPerFieldElement])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Constructor Functions
+///////////////////////////////////////////////////////////////////////////////
+
+// NewBytes32FromHex parses a (optionally 0x-prefixed) hex string of exactly
+// 32 bytes. The value is not validated as a field element.
+func NewBytes32FromHex(s string) (Bytes32, error) {
+	var b Bytes32
+	if err := b.UnmarshalText([]byte(s)); err != nil {
+		return Bytes32{}, err
+	}
+	return b, nil
+}
+
+// NewBytes48FromHex parses a (optionally 0x-prefixed) hex string of exactly
+// 48 bytes. The value is not validated as a G1 point.
+func NewBytes48FromHex(s string) (Bytes48, error) {
+	var b Bytes48
+	if err := b.UnmarshalText([]byte(s)); err != nil {
+		return Bytes48{}, err
+	}
+	return b, nil
+}
+
+// NewBlobFromHex parses a (optionally 0x-prefixed) hex string of exactly
+// BytesPerBlob bytes. The field elements are not validated; use
+// NewValidBlobFromHex for that.
+func NewBlobFromHex(s string) (*Blob, error) {
+	blob := new(Blob)
+	if err := blob.UnmarshalText([]byte(s)); err != nil {
+		return nil, err
+	}
+	return blob, nil
+}
+
+// NewValidBlobFromHex is like NewBlobFromHex but also checks that every field
+// element is canonical.
+func NewValidBlobFromHex(s string) (*Blob, error) {
+	blob, err := NewBlobFromHex(s)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateBlob(blob); err != nil {
+		return nil, err
+	}
+	return blob, nil
+}
+
+// NewFieldElementFromHex parses a 32-byte hex string and checks that it is a
+// canonical field element.
+func NewFieldElementFromHex(s string) (Bytes32, error) {
+	b, err := NewBytes32FromHex(s)
+	if err != nil {
+		return Bytes32{}, err
+	}
+	if err := ValidateFieldElement(b); err != nil {
+		return Bytes32{}, err
+	}
+	return b, nil
+}
+
+// NewKZGCommitmentFromHex parses a 48-byte hex string and checks that it is a
+// valid G1 point in the correct subgroup.
+func NewKZGCommitmentFromHex(s string) (KZGCommitment, error) {
+	b, err := NewBytes48FromHex(s)
+	if err != nil {
+		return KZGCommitment{}, err
+	}
+	if err := ValidateG1(b); err != nil {
+		return KZGCommitment{}, err
+	}
+	return KZGCommitment(b), nil
+}
+
+// NewKZGProofFromHex parses a 48-byte hex string and checks that it is a
+// valid G1 point in the correct subgroup.
+func NewKZGProofFromHex(s string) (KZGProof, error) {
+	b, err := NewBytes48FromHex(s)
+	if err != nil {
+		return KZGProof{}, err
+	}
+	if err := ValidateG1(b); err != nil {
+		return KZGProof{}, err
+	}
+	return KZGProof(b), nil
+}
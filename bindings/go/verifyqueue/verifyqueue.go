@@ -0,0 +1,233 @@
+// Package verifyqueue verifies blob proofs on a pool of workers fed by two
+// queues, one for verifications on the block proposal path and one for bulk
+// work such as gossip and backfill. Workers always take critical jobs first,
+// so bulk work never delays a critical verification by more than the time
+// of the batch in progress on a worker.
+//
+// Jobs queued together are verified as a batch, with a single
+// VerifyBlobKZGProofBatch, and only verified one by one if the batch fails.
+package verifyqueue
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+)
+
+// Priority is the class of a job.
+type Priority int
+
+const (
+	// Critical is for verifications that block the processing of a block.
+	Critical Priority = iota
+	// Bulk is for verifications that can wait, such as those of gossip and
+	// backfill.
+	Bulk
+
+	numPriorities = 2
+)
+
+var (
+	ErrQueueFull = errors.New("verification queue is full")
+	ErrClosed    = errors.New("verification queue is closed")
+)
+
+// Config configures a Queue. Zero fields take their default.
+type Config struct {
+	// Workers is the number of verifications run at once. The default is
+	// runtime.NumCPU().
+	Workers int
+	// MaxBatch is the largest number of jobs verified as a batch. The default
+	// is 16.
+	MaxBatch int
+	// MaxQueued is the largest number of jobs waiting in each queue. The
+	// default is 1024.
+	MaxQueued int
+}
+
+// Job is a blob proof to verify.
+type Job struct {
+	Blob       *ckzg4844.Blob
+	Commitment ckzg4844.Bytes48
+	Proof      ckzg4844.Bytes48
+}
+
+// Result is the outcome of a job. Err is set if the job could not be
+// verified, because its inputs are malformed or it was canceled.
+type Result struct {
+	Valid bool
+	Err   error
+}
+
+type queuedJob struct {
+	Job
+	ctx    context.Context
+	result chan Result
+}
+
+// Queue is a pool of workers verifying jobs by priority.
+type Queue struct {
+	backend ckzg4844.Backend
+	config  Config
+
+	mu     sync.Mutex
+	cond   *sync.Cond
+	queues [numPriorities][]*queuedJob
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// New starts the workers of a queue verifying jobs with backend. Its trusted
+// setup must be loaded.
+func New(backend ckzg4844.Backend, config Config) *Queue {
+	if config.Workers <= 0 {
+		config.Workers = runtime.NumCPU()
+	}
+	if config.MaxBatch <= 0 {
+		config.MaxBatch = 16
+	}
+	if config.MaxQueued <= 0 {
+		config.MaxQueued = 1024
+	}
+	q := &Queue{backend: backend, config: config}
+	q.cond = sync.NewCond(&q.mu)
+	q.wg.Add(config.Workers)
+	for i := 0; i < config.Workers; i++ {
+		go q.work()
+	}
+	return q
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Interface Functions
+///////////////////////////////////////////////////////////////////////////////
+
+/*
+Submit queues job with priority and returns the channel its result will be
+sent on. It does not block: if the queue of that priority is full it returns
+ErrQueueFull, so that callers can shed load. If ctx is done before a worker
+takes the job, the job is not verified and its result holds ctx's error.
+*/
+func (q *Queue) Submit(ctx context.Context, priority Priority, job Job) (<-chan Result, error) {
+	if priority < Critical || priority > Bulk {
+		return nil, ckzg4844.ErrBadArgs
+	}
+	if job.Blob == nil {
+		return nil, ckzg4844.ErrBadArgs
+	}
+	queued := &queuedJob{Job: job, ctx: ctx, result: make(chan Result, 1)}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return nil, ErrClosed
+	}
+	if len(q.queues[priority]) >= q.config.MaxQueued {
+		return nil, ErrQueueFull
+	}
+	q.queues[priority] = append(q.queues[priority], queued)
+	q.cond.Signal()
+	return queued.result, nil
+}
+
+// Verify submits job with priority and waits for its result, or for ctx to
+// be done.
+func (q *Queue) Verify(ctx context.Context, priority Priority, job Job) (bool, error) {
+	result, err := q.Submit(ctx, priority, job)
+	if err != nil {
+		return false, err
+	}
+	select {
+	case r := <-result:
+		return r.Valid, r.Err
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+}
+
+// Len returns the number of jobs waiting in the queue of priority.
+func (q *Queue) Len(priority Priority) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.queues[priority])
+}
+
+// Close stops accepting jobs and waits for the queued ones to be verified.
+func (q *Queue) Close() {
+	q.mu.Lock()
+	q.closed = true
+	q.cond.Broadcast()
+	q.mu.Unlock()
+	q.wg.Wait()
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Workers
+///////////////////////////////////////////////////////////////////////////////
+
+func (q *Queue) work() {
+	defer q.wg.Done()
+	for {
+		jobs, ok := q.next()
+		if !ok {
+			return
+		}
+		q.verify(jobs)
+	}
+}
+
+// next waits for jobs and takes up to MaxBatch of them from the queue with
+// the highest priority. Jobs whose context is done are answered with its
+// error instead. It returns false once the queue is closed and empty.
+func (q *Queue) next() ([]*queuedJob, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for {
+		for priority := range q.queues {
+			var jobs []*queuedJob
+			queue := q.queues[priority]
+			for len(queue) > 0 && len(jobs) < q.config.MaxBatch {
+				job := queue[0]
+				queue[0] = nil
+				queue = queue[1:]
+				if err := job.ctx.Err(); err != nil {
+					job.result <- Result{Err: err}
+					continue
+				}
+				jobs = append(jobs, job)
+			}
+			q.queues[priority] = queue
+			if len(jobs) > 0 {
+				return jobs, true
+			}
+		}
+		if q.closed {
+			return nil, false
+		}
+		q.cond.Wait()
+	}
+}
+
+// verify verifies jobs as a batch, and one by one if the batch fails.
+func (q *Queue) verify(jobs []*queuedJob) {
+	if len(jobs) > 1 {
+		blobs := make([]ckzg4844.Blob, len(jobs))
+		commitments := make([]ckzg4844.Bytes48, len(jobs))
+		proofs := make([]ckzg4844.Bytes48, len(jobs))
+		for i, job := range jobs {
+			blobs[i], commitments[i], proofs[i] = *job.Blob, job.Commitment, job.Proof
+		}
+		if ok, err := q.backend.VerifyBlobKZGProofBatch(blobs, commitments, proofs); err == nil && ok {
+			for _, job := range jobs {
+				job.result <- Result{Valid: true}
+			}
+			return
+		}
+	}
+	for _, job := range jobs {
+		valid, err := q.backend.VerifyBlobKZGProof(job.Blob, job.Commitment, job.Proof)
+		job.result <- Result{Valid: valid, Err: err}
+	}
+}
@@ -0,0 +1,175 @@
+package verifyqueue
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+	"github.com/ethereum/c-kzg-4844/bindings/go/ckzgtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	if err := ckzg4844.LoadTrustedSetupFile("../../../src/trusted_setup.txt"); err != nil {
+		panic(fmt.Sprintf("failed to load trusted setup: %v", err))
+	}
+	defer ckzg4844.FreeTrustedSetup()
+	os.Exit(m.Run())
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Helper Functions
+///////////////////////////////////////////////////////////////////////////////
+
+// gatedBackend records the first byte of the commitment of every job it
+// verifies, and holds every call until gate is closed. Every job is valid.
+type gatedBackend struct {
+	ckzg4844.Backend
+	started chan struct{}
+	gate    chan struct{}
+
+	mu    sync.Mutex
+	order []byte
+}
+
+func newGatedBackend() *gatedBackend {
+	return &gatedBackend{started: make(chan struct{}, 64), gate: make(chan struct{})}
+}
+
+func (b *gatedBackend) record(commitments ...ckzg4844.Bytes48) {
+	b.started <- struct{}{}
+	<-b.gate
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, commitment := range commitments {
+		b.order = append(b.order, commitment[0])
+	}
+}
+
+func (b *gatedBackend) VerifyBlobKZGProof(blob *ckzg4844.Blob, commitmentBytes, proofBytes ckzg4844.Bytes48) (bool, error) {
+	b.record(commitmentBytes)
+	return true, nil
+}
+
+func (b *gatedBackend) VerifyBlobKZGProofBatch(blobs []ckzg4844.Blob, commitmentsBytes, proofsBytes []ckzg4844.Bytes48) (bool, error) {
+	b.record(commitmentsBytes...)
+	return true, nil
+}
+
+// markedJob returns a job whose commitment starts with marker.
+func markedJob(marker byte) Job {
+	return Job{Blob: new(ckzg4844.Blob), Commitment: ckzg4844.Bytes48{marker}}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Tests
+///////////////////////////////////////////////////////////////////////////////
+
+func TestCriticalFirst(t *testing.T) {
+	backend := newGatedBackend()
+	q := New(backend, Config{Workers: 1, MaxBatch: 2})
+	ctx := context.Background()
+
+	// The worker is busy with a, while the others are queued.
+	first, err := q.Submit(ctx, Bulk, markedJob('a'))
+	require.NoError(t, err)
+	<-backend.started
+	var results []<-chan Result
+	for _, job := range []struct {
+		priority Priority
+		marker   byte
+	}{{Bulk, 'b'}, {Bulk, 'c'}, {Bulk, 'd'}, {Critical, 'e'}, {Critical, 'f'}, {Critical, 'g'}} {
+		result, err := q.Submit(ctx, job.priority, markedJob(job.marker))
+		require.NoError(t, err)
+		results = append(results, result)
+	}
+	require.Equal(t, 3, q.Len(Bulk))
+	require.Equal(t, 3, q.Len(Critical))
+	close(backend.gate)
+
+	require.Equal(t, Result{Valid: true}, <-first)
+	for _, result := range results {
+		require.Equal(t, Result{Valid: true}, <-result)
+	}
+	q.Close()
+	require.Equal(t, "aefgbcd", string(backend.order))
+}
+
+func TestResults(t *testing.T) {
+	q := New(ckzg4844.DefaultBackend, Config{Workers: 2, MaxBatch: 4})
+	defer q.Close()
+	blobs, commitments, proofs := ckzgtest.RandomBundle(1, 8)
+
+	type outcome struct {
+		valid bool
+		err   error
+	}
+	outcomes := make([]outcome, len(blobs))
+	var wg sync.WaitGroup
+	for i := range blobs {
+		job := Job{Blob: &blobs[i], Commitment: commitments[i], Proof: proofs[i]}
+		switch i {
+		case 3:
+			job.Proof = proofs[0]
+		case 5:
+			job.Proof = ckzgtest.InvalidPoint()
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			outcomes[i].valid, outcomes[i].err = q.Verify(context.Background(), Priority(i%2), job)
+		}(i)
+	}
+	wg.Wait()
+	for i, outcome := range outcomes {
+		switch i {
+		case 3:
+			require.NoError(t, outcome.err)
+			require.False(t, outcome.valid)
+		case 5:
+			require.ErrorIs(t, outcome.err, ckzg4844.ErrBadArgs)
+		default:
+			require.NoError(t, outcome.err)
+			require.True(t, outcome.valid)
+		}
+	}
+}
+
+func TestBackpressure(t *testing.T) {
+	backend := newGatedBackend()
+	q := New(backend, Config{Workers: 1, MaxQueued: 1})
+	ctx := context.Background()
+
+	first, err := q.Submit(ctx, Bulk, markedJob('a'))
+	require.NoError(t, err)
+	<-backend.started
+	_, err = q.Submit(ctx, Bulk, markedJob('b'))
+	require.NoError(t, err)
+	_, err = q.Submit(ctx, Bulk, markedJob('c'))
+	require.ErrorIs(t, err, ErrQueueFull)
+
+	// The critical queue has room, but the job is canceled before a worker
+	// takes it.
+	canceled, cancel := context.WithCancel(ctx)
+	result, err := q.Submit(canceled, Critical, markedJob('d'))
+	require.NoError(t, err)
+	cancel()
+	_, err = q.Verify(canceled, Critical, markedJob('e'))
+	require.ErrorIs(t, err, ErrQueueFull)
+
+	close(backend.gate)
+	require.Equal(t, Result{Valid: true}, <-first)
+	require.ErrorIs(t, (<-result).Err, context.Canceled)
+	q.Close()
+	require.Equal(t, "ab", string(backend.order))
+
+	_, err = q.Submit(ctx, Critical, markedJob('f'))
+	require.ErrorIs(t, err, ErrClosed)
+	_, err = q.Submit(ctx, Priority(2), markedJob('g'))
+	require.ErrorIs(t, err, ckzg4844.ErrBadArgs)
+	_, err = q.Submit(ctx, Critical, Job{})
+	require.ErrorIs(t, err, ckzg4844.ErrBadArgs)
+}
@@ -0,0 +1,666 @@
+package ethereum.ckzg4844.ffm;
+
+import static ethereum.ckzg4844.CKZGException.CKZGError.C_KZG_BADARGS;
+import static java.lang.foreign.ValueLayout.ADDRESS;
+import static java.lang.foreign.ValueLayout.JAVA_BOOLEAN;
+import static java.lang.foreign.ValueLayout.JAVA_BYTE;
+import static java.lang.foreign.ValueLayout.JAVA_INT;
+import static java.lang.foreign.ValueLayout.JAVA_LONG;
+
+import ethereum.ckzg4844.CKZGException;
+import ethereum.ckzg4844.ProofAndY;
+import java.io.IOException;
+import java.io.InputStream;
+import java.io.UncheckedIOException;
+import java.lang.foreign.Arena;
+import java.lang.foreign.FunctionDescriptor;
+import java.lang.foreign.Linker;
+import java.lang.foreign.MemoryLayout;
+import java.lang.foreign.MemorySegment;
+import java.lang.foreign.SymbolLookup;
+import java.lang.invoke.MethodHandle;
+import java.math.BigInteger;
+import java.nio.charset.StandardCharsets;
+import java.nio.file.Files;
+import java.nio.file.Path;
+import java.util.HexFormat;
+
+/**
+ * Bindings to the shared library of c-kzg-4844 (built with {@code make shared} in the library
+ * source directory) using the Foreign Function &amp; Memory API, so there is no native glue to
+ * build. The methods have the same names and behavior as those of {@code CKZG4844JNI}, and share
+ * its trusted setup semantics: one setup is loaded at a time and used for all the calls.
+ *
+ * <p>The methods taking byte arrays copy them to native memory for each call. The overloads taking
+ * {@link MemorySegment}s pass the segments to the library as they are, so blobs can be allocated
+ * once in an {@link Arena} of the caller and freed when it is closed. These segments must be native
+ * and have the exact size of their values.
+ *
+ * <p>The library is called with 64-bit {@code size_t}, so only 64-bit platforms are supported.
+ * Running the bindings needs {@code --enable-native-access} for the module using them.
+ */
+public class CKZG4844FFM {
+
+  private static final String LIBRARY_NAME = "ckzg";
+  private static final String PLATFORM_NATIVE_LIBRARY_NAME = System.mapLibraryName(LIBRARY_NAME);
+
+  private static final String TRUSTED_SETUP_NOT_LOADED = "Trusted Setup is not loaded.";
+
+  /** The value of C_KZG_RET on success. */
+  private static final int C_KZG_OK = 0;
+
+  /** The layout of KZGSettings. */
+  private static final MemoryLayout KZG_SETTINGS =
+      MemoryLayout.structLayout(
+          JAVA_LONG.withName("max_width"),
+          ADDRESS.withName("roots_of_unity"),
+          ADDRESS.withName("g1_values"),
+          ADDRESS.withName("g2_values"));
+
+  /** Scalar field modulus of BLS12-381 */
+  public static final BigInteger BLS_MODULUS =
+      new BigInteger(
+          "52435875175126190479447740508185965837690552500527637822603658699938581184513");
+  /** The number of bytes in a g1 point. */
+  public static final int BYTES_PER_G1 = 48;
+  /** The number of bytes in a g2 point. */
+  public static final int BYTES_PER_G2 = 96;
+  /** The number of bytes in a KZG commitment */
+  public static final int BYTES_PER_COMMITMENT = 48;
+  /** The number of bytes in a KZG proof */
+  public static final int BYTES_PER_PROOF = 48;
+  /** Bytes used to encode a BLS scalar field element */
+  public static final int BYTES_PER_FIELD_ELEMENT = 32;
+  /** Number of field elements in a blob */
+  public static final int FIELD_ELEMENTS_PER_BLOB = 4096;
+  /** Number of bytes in a blob */
+  public static final int BYTES_PER_BLOB = FIELD_ELEMENTS_PER_BLOB * BYTES_PER_FIELD_ELEMENT;
+
+  private static Functions functions;
+  private static Arena settingsArena;
+  private static MemorySegment settings;
+
+  private CKZG4844FFM() {}
+
+  /**
+   * Loads the shared library by its platform name (libckzg.so, libckzg.dylib or ckzg.dll) from the
+   * paths searched by the dynamic linker of the system, such as {@code LD_LIBRARY_PATH}.
+   */
+  public static void loadNativeLibrary() {
+    try {
+      load(SymbolLookup.libraryLookup(PLATFORM_NATIVE_LIBRARY_NAME, Arena.global()));
+    } catch (IllegalArgumentException ex) {
+      String exceptionMessage =
+          String.format(
+              "Couldn't load native library (%s). It wasn't available on the library path.",
+              PLATFORM_NATIVE_LIBRARY_NAME);
+      throw new RuntimeException(exceptionMessage, ex);
+    }
+  }
+
+  /**
+   * Loads the shared library from a path.
+   *
+   * @param library the path to the shared library
+   */
+  public static void loadNativeLibrary(Path library) {
+    try {
+      load(SymbolLookup.libraryLookup(library, Arena.global()));
+    } catch (IllegalArgumentException ex) {
+      String exceptionMessage =
+          String.format("Couldn't load native library (%s). It wasn't available.", library);
+      throw new RuntimeException(exceptionMessage, ex);
+    }
+  }
+
+  private static synchronized void load(SymbolLookup lookup) {
+    if (functions == null) {
+      functions = new Functions(lookup);
+    }
+  }
+
+  /**
+   * Loads the trusted setup from a file. Once loaded, the same setup will be used for all the
+   * crypto native calls. To load a new setup, free the current one by calling {@link
+   * #freeTrustedSetup()} and then load the new one. If no trusted setup has been loaded, all the
+   * crypto native calls will throw a {@link RuntimeException}.
+   *
+   * <p>The file is parsed in Java and its points passed to the library.
+   *
+   * @param file a path to a trusted setup file
+   * @throws CKZGException if there is a crypto error
+   */
+  public static void loadTrustedSetup(String file) {
+    String contents;
+    try {
+      contents = Files.readString(Path.of(file), StandardCharsets.US_ASCII);
+    } catch (IOException ex) {
+      throw new RuntimeException(
+          "Couldn't load Trusted Setup. File might not exist or there is a permission issue.", ex);
+    }
+    loadTrustedSetupFromString(contents);
+  }
+
+  /**
+   * An alternative to {@link #loadTrustedSetup(String)}. Loads the trusted setup from method
+   * parameters instead of a file.
+   *
+   * @param g1 g1 values as bytes
+   * @param g1Count the count of the g1 values
+   * @param g2 g2 values as bytes
+   * @param g2Count the count of the g2 values
+   * @throws CKZGException if there is a crypto error
+   */
+  public static void loadTrustedSetup(byte[] g1, long g1Count, byte[] g2, long g2Count) {
+    Functions f = functions();
+    if (settings != null) {
+      throw new RuntimeException(
+          "Trusted Setup is already loaded. Free it before loading a new one.");
+    }
+    checkSize("Invalid g1 size.", g1.length, g1Count * BYTES_PER_G1);
+    checkSize("Invalid g2 size.", g2.length, g2Count * BYTES_PER_G2);
+
+    Arena arena = Arena.ofShared();
+    MemorySegment s = arena.allocate(KZG_SETTINGS);
+    int ret;
+    try (Arena call = Arena.ofConfined()) {
+      MemorySegment g1Native = call.allocateFrom(JAVA_BYTE, g1);
+      MemorySegment g2Native = call.allocateFrom(JAVA_BYTE, g2);
+      ret = (int) f.loadTrustedSetup.invokeExact(s, g1Native, g1Count, g2Native, g2Count);
+    } catch (Throwable t) {
+      arena.close();
+      throw rethrow(t);
+    }
+    if (ret != C_KZG_OK) {
+      arena.close();
+      throw new CKZGException(ret, "There was an error while loading the Trusted Setup.");
+    }
+    settingsArena = arena;
+    settings = s;
+  }
+
+  /**
+   * An alternative to {@link #loadTrustedSetup(String)}. Loads the trusted setup from a resource.
+   *
+   * @param resource the resource name that contains the trusted setup
+   * @param clazz the class to use to get the resource
+   * @param <T> the type of the class
+   * @throws CKZGException if there is a crypto error
+   * @throws IllegalArgumentException if the resource does not exist
+   */
+  public static <T> void loadTrustedSetupFromResource(String resource, Class<T> clazz) {
+    InputStream is = clazz.getResourceAsStream(resource);
+    if (is == null) {
+      throw new IllegalArgumentException("Resource " + resource + " does not exist.");
+    }
+
+    String contents;
+    try (is) {
+      contents = new String(is.readAllBytes(), StandardCharsets.US_ASCII);
+    } catch (IOException ex) {
+      throw new UncheckedIOException("Error loading trusted setup from resource " + resource, ex);
+    }
+    loadTrustedSetupFromString(contents);
+  }
+
+  /**
+   * Parses a trusted setup in the text format: the number of g1 points, the number of g2 points,
+   * then the points in hex.
+   */
+  private static void loadTrustedSetupFromString(String contents) {
+    String[] fields = contents.trim().split("\\s+");
+    byte[] g1;
+    byte[] g2;
+    int g1Count;
+    int g2Count;
+    try {
+      g1Count = Integer.parseInt(fields[0]);
+      g2Count = Integer.parseInt(fields[1]);
+      if (g1Count < 0 || g2Count < 0 || fields.length != 2L + g1Count + g2Count) {
+        throw new IllegalArgumentException("Wrong number of points");
+      }
+      g1 = parsePoints(fields, 2, g1Count, BYTES_PER_G1);
+      g2 = parsePoints(fields, 2 + g1Count, g2Count, BYTES_PER_G2);
+    } catch (IllegalArgumentException | IndexOutOfBoundsException ex) {
+      throw new CKZGException(
+          C_KZG_BADARGS.errorCode, "There was an error while loading the Trusted Setup.");
+    }
+    loadTrustedSetup(g1, g1Count, g2, g2Count);
+  }
+
+  private static byte[] parsePoints(String[] fields, int offset, int count, int size) {
+    HexFormat hex = HexFormat.of();
+    byte[] points = new byte[count * size];
+    for (int i = 0; i < count; i++) {
+      byte[] point = hex.parseHex(fields[offset + i]);
+      if (point.length != size) {
+        throw new IllegalArgumentException("Wrong point size");
+      }
+      System.arraycopy(point, 0, points, i * size, size);
+    }
+    return points;
+  }
+
+  /**
+   * Free the current trusted setup. This method will throw an exception if no trusted setup has
+   * been loaded.
+   */
+  public static void freeTrustedSetup() {
+    Functions f = functions();
+    MemorySegment s = settings();
+    try {
+      f.freeTrustedSetup.invokeExact(s);
+    } catch (Throwable t) {
+      throw rethrow(t);
+    }
+    settingsArena.close();
+    settingsArena = null;
+    settings = null;
+  }
+
+  /**
+   * Calculates commitment for a given blob
+   *
+   * @param blob blob bytes
+   * @return the commitment
+   * @throws CKZGException if there is a crypto error
+   */
+  public static byte[] blobToKzgCommitment(byte[] blob) {
+    try (Arena arena = Arena.ofConfined()) {
+      MemorySegment out = arena.allocate(BYTES_PER_COMMITMENT);
+      blobToKzgCommitment(out, copy(arena, blob));
+      return out.toArray(JAVA_BYTE);
+    }
+  }
+
+  /**
+   * Calculates commitment for a given blob, without copying it.
+   *
+   * @param out the segment to write the commitment to
+   * @param blob the blob
+   * @throws CKZGException if there is a crypto error
+   */
+  public static void blobToKzgCommitment(MemorySegment out, MemorySegment blob) {
+    Functions f = functions();
+    MemorySegment s = settings();
+    checkSize("Invalid blob size.", blob.byteSize(), BYTES_PER_BLOB);
+    checkSize("Invalid commitment size.", out.byteSize(), BYTES_PER_COMMITMENT);
+
+    int ret;
+    try {
+      ret = (int) f.blobToKzgCommitment.invokeExact(out, blob, s);
+    } catch (Throwable t) {
+      throw rethrow(t);
+    }
+    check(ret, "There was an error in blobToKzgCommitment.");
+  }
+
+  /**
+   * Compute proof at point z for the polynomial represented by blob.
+   *
+   * @param blob blob bytes
+   * @param z_bytes a point
+   * @return an instance of {@link ProofAndY} holding the proof and the value y = f(z)
+   * @throws CKZGException if there is a crypto error
+   */
+  public static ProofAndY computeKzgProof(byte[] blob, byte[] z_bytes) {
+    try (Arena arena = Arena.ofConfined()) {
+      MemorySegment proof = arena.allocate(BYTES_PER_PROOF);
+      MemorySegment y = arena.allocate(BYTES_PER_FIELD_ELEMENT);
+      computeKzgProof(proof, y, copy(arena, blob), copy(arena, z_bytes));
+      return ProofAndY.of(proof.toArray(JAVA_BYTE), y.toArray(JAVA_BYTE));
+    }
+  }
+
+  /**
+   * Compute proof at point z for the polynomial represented by blob, without copying it.
+   *
+   * @param proofOut the segment to write the proof to
+   * @param yOut the segment to write the value y = f(z) to
+   * @param blob the blob
+   * @param z_bytes a point
+   * @throws CKZGException if there is a crypto error
+   */
+  public static void computeKzgProof(
+      MemorySegment proofOut, MemorySegment yOut, MemorySegment blob, MemorySegment z_bytes) {
+    Functions f = functions();
+    MemorySegment s = settings();
+    checkSize("Invalid blob size.", blob.byteSize(), BYTES_PER_BLOB);
+    checkSize("Invalid z size.", z_bytes.byteSize(), BYTES_PER_FIELD_ELEMENT);
+    checkSize("Invalid proof size.", proofOut.byteSize(), BYTES_PER_PROOF);
+    checkSize("Invalid y size.", yOut.byteSize(), BYTES_PER_FIELD_ELEMENT);
+
+    int ret;
+    try {
+      ret = (int) f.computeKzgProof.invokeExact(proofOut, yOut, blob, z_bytes, s);
+    } catch (Throwable t) {
+      throw rethrow(t);
+    }
+    check(ret, "There was an error in computeKzgProof.");
+  }
+
+  /**
+   * Given a blob, return the KZG proof that is used to verify it against the commitment
+   *
+   * @param blob blob bytes
+   * @param commitment_bytes commitment bytes
+   * @return the proof
+   * @throws CKZGException if there is a crypto error
+   */
+  public static byte[] computeBlobKzgProof(byte[] blob, byte[] commitment_bytes) {
+    try (Arena arena = Arena.ofConfined()) {
+      MemorySegment out = arena.allocate(BYTES_PER_PROOF);
+      computeBlobKzgProof(out, copy(arena, blob), copy(arena, commitment_bytes));
+      return out.toArray(JAVA_BYTE);
+    }
+  }
+
+  /**
+   * Given a blob, compute the KZG proof that is used to verify it against the commitment, without
+   * copying it.
+   *
+   * @param out the segment to write the proof to
+   * @param blob the blob
+   * @param commitment_bytes commitment bytes
+   * @throws CKZGException if there is a crypto error
+   */
+  public static void computeBlobKzgProof(
+      MemorySegment out, MemorySegment blob, MemorySegment commitment_bytes) {
+    Functions f = functions();
+    MemorySegment s = settings();
+    checkSize("Invalid blob size.", blob.byteSize(), BYTES_PER_BLOB);
+    checkSize("Invalid commitment size.", commitment_bytes.byteSize(), BYTES_PER_COMMITMENT);
+    checkSize("Invalid proof size.", out.byteSize(), BYTES_PER_PROOF);
+
+    int ret;
+    try {
+      ret = (int) f.computeBlobKzgProof.invokeExact(out, blob, commitment_bytes, s);
+    } catch (Throwable t) {
+      throw rethrow(t);
+    }
+    check(ret, "There was an error in computeBlobKzgProof.");
+  }
+
+  /**
+   * Verify the proof by point evaluation for the given commitment
+   *
+   * @param commitment_bytes commitment bytes
+   * @param z_bytes Z
+   * @param y_bytes Y
+   * @param proof_bytes the proof that needs verifying
+   * @return true if the proof is valid and false otherwise
+   * @throws CKZGException if there is a crypto error
+   */
+  public static boolean verifyKzgProof(
+      byte[] commitment_bytes, byte[] z_bytes, byte[] y_bytes, byte[] proof_bytes) {
+    try (Arena arena = Arena.ofConfined()) {
+      return verifyKzgProof(
+          copy(arena, commitment_bytes),
+          copy(arena, z_bytes),
+          copy(arena, y_bytes),
+          copy(arena, proof_bytes));
+    }
+  }
+
+  /**
+   * Verify the proof by point evaluation for the given commitment, given in segments.
+   *
+   * @param commitment_bytes commitment bytes
+   * @param z_bytes Z
+   * @param y_bytes Y
+   * @param proof_bytes the proof that needs verifying
+   * @return true if the proof is valid and false otherwise
+   * @throws CKZGException if there is a crypto error
+   */
+  public static boolean verifyKzgProof(
+      MemorySegment commitment_bytes,
+      MemorySegment z_bytes,
+      MemorySegment y_bytes,
+      MemorySegment proof_bytes) {
+    Functions f = functions();
+    MemorySegment s = settings();
+    checkSize("Invalid commitment size.", commitment_bytes.byteSize(), BYTES_PER_COMMITMENT);
+    checkSize("Invalid z size.", z_bytes.byteSize(), BYTES_PER_FIELD_ELEMENT);
+    checkSize("Invalid y size.", y_bytes.byteSize(), BYTES_PER_FIELD_ELEMENT);
+    checkSize("Invalid proof size.", proof_bytes.byteSize(), BYTES_PER_PROOF);
+
+    try (Arena arena = Arena.ofConfined()) {
+      MemorySegment out = arena.allocate(JAVA_BOOLEAN);
+      int ret;
+      try {
+        ret =
+            (int)
+                f.verifyKzgProof.invokeExact(
+                    out, commitment_bytes, z_bytes, y_bytes, proof_bytes, s);
+      } catch (Throwable t) {
+        throw rethrow(t);
+      }
+      check(ret, "There was an error in verifyKzgProof.");
+      return out.get(JAVA_BOOLEAN, 0);
+    }
+  }
+
+  /**
+   * Given a blob and a KZG proof, verify that the blob data corresponds to the provided commitment.
+   *
+   * @param blob blob bytes
+   * @param commitment_bytes commitment bytes
+   * @param proof_bytes proof bytes
+   * @return true if the proof is valid and false otherwise
+   * @throws CKZGException if there is a crypto error
+   */
+  public static boolean verifyBlobKzgProof(
+      byte[] blob, byte[] commitment_bytes, byte[] proof_bytes) {
+    try (Arena arena = Arena.ofConfined()) {
+      return verifyBlobKzgProof(
+          copy(arena, blob), copy(arena, commitment_bytes), copy(arena, proof_bytes));
+    }
+  }
+
+  /**
+   * Given a blob and a KZG proof, verify that the blob data corresponds to the provided
+   * commitment, without copying the blob.
+   *
+   * @param blob the blob
+   * @param commitment_bytes commitment bytes
+   * @param proof_bytes proof bytes
+   * @return true if the proof is valid and false otherwise
+   * @throws CKZGException if there is a crypto error
+   */
+  public static boolean verifyBlobKzgProof(
+      MemorySegment blob, MemorySegment commitment_bytes, MemorySegment proof_bytes) {
+    Functions f = functions();
+    MemorySegment s = settings();
+    checkSize("Invalid blob size.", blob.byteSize(), BYTES_PER_BLOB);
+    checkSize("Invalid commitment size.", commitment_bytes.byteSize(), BYTES_PER_COMMITMENT);
+    checkSize("Invalid proof size.", proof_bytes.byteSize(), BYTES_PER_PROOF);
+
+    try (Arena arena = Arena.ofConfined()) {
+      MemorySegment out = arena.allocate(JAVA_BOOLEAN);
+      int ret;
+      try {
+        ret = (int) f.verifyBlobKzgProof.invokeExact(out, blob, commitment_bytes, proof_bytes, s);
+      } catch (Throwable t) {
+        throw rethrow(t);
+      }
+      check(ret, "There was an error in verifyBlobKzgProof.");
+      return out.get(JAVA_BOOLEAN, 0);
+    }
+  }
+
+  /**
+   * Given a list of blobs and blob KZG proofs, verify that they correspond to the provided
+   * commitments.
+   *
+   * @param blobs flattened blobs bytes
+   * @param commitments_bytes flattened commitments bytes
+   * @param proofs_bytes flattened proofs bytes
+   * @param count the number of blobs (should be same as the number of proofs and commitments)
+   * @return true if the proof is valid and false otherwise
+   * @throws CKZGException if there is a crypto error
+   */
+  public static boolean verifyBlobKzgProofBatch(
+      byte[] blobs, byte[] commitments_bytes, byte[] proofs_bytes, long count) {
+    try (Arena arena = Arena.ofConfined()) {
+      return verifyBlobKzgProofBatch(
+          copy(arena, blobs), copy(arena, commitments_bytes), copy(arena, proofs_bytes), count);
+    }
+  }
+
+  /**
+   * Given a list of blobs and blob KZG proofs, verify that they correspond to the provided
+   * commitments, without copying the blobs.
+   *
+   * @param blobs concatenated blobs
+   * @param commitments_bytes concatenated commitments bytes
+   * @param proofs_bytes concatenated proofs bytes
+   * @param count the number of blobs (should be same as the number of proofs and commitments)
+   * @return true if the proof is valid and false otherwise
+   * @throws CKZGException if there is a crypto error
+   */
+  public static boolean verifyBlobKzgProofBatch(
+      MemorySegment blobs,
+      MemorySegment commitments_bytes,
+      MemorySegment proofs_bytes,
+      long count) {
+    Functions f = functions();
+    MemorySegment s = settings();
+    if (count < 0) {
+      throw new CKZGException(C_KZG_BADARGS.errorCode, "Invalid count.");
+    }
+    checkSize("Invalid blobs size.", blobs.byteSize(), count * BYTES_PER_BLOB);
+    checkSize(
+        "Invalid commitments size.", commitments_bytes.byteSize(), count * BYTES_PER_COMMITMENT);
+    checkSize("Invalid proofs size.", proofs_bytes.byteSize(), count * BYTES_PER_PROOF);
+
+    try (Arena arena = Arena.ofConfined()) {
+      MemorySegment out = arena.allocate(JAVA_BOOLEAN);
+      int ret;
+      try {
+        ret =
+            (int)
+                f.verifyBlobKzgProofBatch.invokeExact(
+                    out, blobs, commitments_bytes, proofs_bytes, count, s);
+      } catch (Throwable t) {
+        throw rethrow(t);
+      }
+      check(ret, "There was an error in verifyBlobKzgProofBatch.");
+      return out.get(JAVA_BOOLEAN, 0);
+    }
+  }
+
+  private static Functions functions() {
+    if (functions == null) {
+      throw new RuntimeException("Native library is not loaded.");
+    }
+    return functions;
+  }
+
+  private static MemorySegment settings() {
+    if (settings == null) {
+      throw new RuntimeException(TRUSTED_SETUP_NOT_LOADED);
+    }
+    return settings;
+  }
+
+  private static MemorySegment copy(Arena arena, byte[] bytes) {
+    return arena.allocateFrom(JAVA_BYTE, bytes);
+  }
+
+  private static void checkSize(String prefix, long size, long expectedSize) {
+    if (size != expectedSize) {
+      throw new CKZGException(
+          C_KZG_BADARGS.errorCode,
+          String.format("%s Expected %d bytes but got %d.", prefix, expectedSize, size));
+    }
+  }
+
+  private static void check(int ret, String message) {
+    if (ret != C_KZG_OK) {
+      throw new CKZGException(ret, message);
+    }
+  }
+
+  /** Returns what a downcall threw as unchecked. The functions of the library do not throw. */
+  private static RuntimeException rethrow(Throwable t) {
+    if (t instanceof Error err) {
+      throw err;
+    }
+    if (t instanceof RuntimeException ex) {
+      return ex;
+    }
+    return new RuntimeException(t);
+  }
+
+  /** The downcall handles of the functions of the library. */
+  private static final class Functions {
+    final MethodHandle loadTrustedSetup;
+    final MethodHandle freeTrustedSetup;
+    final MethodHandle blobToKzgCommitment;
+    final MethodHandle computeKzgProof;
+    final MethodHandle computeBlobKzgProof;
+    final MethodHandle verifyKzgProof;
+    final MethodHandle verifyBlobKzgProof;
+    final MethodHandle verifyBlobKzgProofBatch;
+
+    Functions(SymbolLookup lookup) {
+      Linker linker = Linker.nativeLinker();
+      loadTrustedSetup =
+          downcall(
+              linker,
+              lookup,
+              "load_trusted_setup",
+              FunctionDescriptor.of(JAVA_INT, ADDRESS, ADDRESS, JAVA_LONG, ADDRESS, JAVA_LONG));
+      freeTrustedSetup =
+          downcall(linker, lookup, "free_trusted_setup", FunctionDescriptor.ofVoid(ADDRESS));
+      blobToKzgCommitment =
+          downcall(
+              linker,
+              lookup,
+              "blob_to_kzg_commitment",
+              FunctionDescriptor.of(JAVA_INT, ADDRESS, ADDRESS, ADDRESS));
+      computeKzgProof =
+          downcall(
+              linker,
+              lookup,
+              "compute_kzg_proof",
+              FunctionDescriptor.of(JAVA_INT, ADDRESS, ADDRESS, ADDRESS, ADDRESS, ADDRESS));
+      computeBlobKzgProof =
+          downcall(
+              linker,
+              lookup,
+              "compute_blob_kzg_proof",
+              FunctionDescriptor.of(JAVA_INT, ADDRESS, ADDRESS, ADDRESS, ADDRESS));
+      verifyKzgProof =
+          downcall(
+              linker,
+              lookup,
+              "verify_kzg_proof",
+              FunctionDescriptor.of(
+                  JAVA_INT, ADDRESS, ADDRESS, ADDRESS, ADDRESS, ADDRESS, ADDRESS));
+      verifyBlobKzgProof =
+          downcall(
+              linker,
+              lookup,
+              "verify_blob_kzg_proof",
+              FunctionDescriptor.of(JAVA_INT, ADDRESS, ADDRESS, ADDRESS, ADDRESS, ADDRESS));
+      verifyBlobKzgProofBatch =
+          downcall(
+              linker,
+              lookup,
+              "verify_blob_kzg_proof_batch",
+              FunctionDescriptor.of(
+                  JAVA_INT, ADDRESS, ADDRESS, ADDRESS, ADDRESS, JAVA_LONG, ADDRESS));
+    }
+
+    private static MethodHandle downcall(
+        Linker linker, SymbolLookup lookup, String name, FunctionDescriptor descriptor) {
+      MemorySegment symbol =
+          lookup
+              .find(name)
+              .orElseThrow(
+                  () -> new RuntimeException("Couldn't find " + name + " in the native library."));
+      return linker.downcallHandle(symbol, descriptor);
+    }
+  }
+}
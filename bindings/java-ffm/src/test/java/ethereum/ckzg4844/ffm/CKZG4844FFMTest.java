@@ -0,0 +1,343 @@
+package ethereum.ckzg4844.ffm;
+
+import static ethereum.ckzg4844.CKZGException.CKZGError.C_KZG_BADARGS;
+import static java.lang.foreign.ValueLayout.JAVA_BYTE;
+import static org.junit.jupiter.api.Assertions.assertArrayEquals;
+import static org.junit.jupiter.api.Assertions.assertEquals;
+import static org.junit.jupiter.api.Assertions.assertFalse;
+import static org.junit.jupiter.api.Assertions.assertNull;
+import static org.junit.jupiter.api.Assertions.assertThrows;
+import static org.junit.jupiter.api.Assertions.assertTrue;
+
+import ethereum.ckzg4844.CKZGException;
+import ethereum.ckzg4844.LoadTrustedSetupParameters;
+import ethereum.ckzg4844.ProofAndY;
+import ethereum.ckzg4844.TestUtils;
+import ethereum.ckzg4844.test_formats.*;
+import java.lang.foreign.Arena;
+import java.lang.foreign.MemorySegment;
+import java.nio.file.Path;
+import java.util.stream.Stream;
+import org.junit.jupiter.api.Test;
+import org.junit.jupiter.params.ParameterizedTest;
+import org.junit.jupiter.params.provider.MethodSource;
+
+public class CKZG4844FFMTest {
+  private static final String TRUSTED_SETUP_FILE = "../../src/trusted_setup.txt";
+  private static final String TRUSTED_SETUP_RESOURCE = "/trusted-setups/trusted_setup.txt";
+  private static final String OLD_TRUSTED_SETUP_FILE =
+      "../java/src/testFixtures/resources/trusted-setups/trusted_setup_old.txt";
+
+  static {
+    CKZG4844FFM.loadNativeLibrary(Path.of(System.getProperty("ckzg4844.library")));
+  }
+
+  @ParameterizedTest
+  @MethodSource("getBlobToKzgCommitmentTests")
+  public void blobToKzgCommitmentTests(final BlobToKzgCommitmentTest test) {
+    try {
+      byte[] commitment = CKZG4844FFM.blobToKzgCommitment(test.getInput().getBlob());
+      assertArrayEquals(test.getOutput(), commitment);
+    } catch (CKZGException ex) {
+      assertNull(test.getOutput());
+    }
+  }
+
+  @ParameterizedTest
+  @MethodSource("getComputeKzgProofTests")
+  public void computeKzgProofTests(final ComputeKzgProofTest test) {
+    try {
+      ProofAndY proofAndY =
+          CKZG4844FFM.computeKzgProof(test.getInput().getBlob(), test.getInput().getZ());
+      assertArrayEquals(test.getOutput().getProof(), proofAndY.getProof());
+      assertArrayEquals(test.getOutput().getY(), proofAndY.getY());
+    } catch (CKZGException ex) {
+      assertNull(test.getOutput());
+    }
+  }
+
+  @ParameterizedTest
+  @MethodSource("getComputeBlobKzgProofTests")
+  public void computeBlobKzgProofTests(final ComputeBlobKzgProofTest test) {
+    try {
+      byte[] proof =
+          CKZG4844FFM.computeBlobKzgProof(
+              test.getInput().getBlob(), test.getInput().getCommitment());
+      assertArrayEquals(test.getOutput(), proof);
+    } catch (CKZGException ex) {
+      assertNull(test.getOutput());
+    }
+  }
+
+  @ParameterizedTest
+  @MethodSource("getVerifyKzgProofTests")
+  public void verifyKzgProofTests(final VerifyKzgProofTest test) {
+    try {
+      boolean valid =
+          CKZG4844FFM.verifyKzgProof(
+              test.getInput().getCommitment(),
+              test.getInput().getZ(),
+              test.getInput().getY(),
+              test.getInput().getProof());
+      assertEquals(test.getOutput(), valid);
+    } catch (CKZGException ex) {
+      assertNull(test.getOutput());
+    }
+  }
+
+  @ParameterizedTest
+  @MethodSource("getVerifyBlobKzgProofTests")
+  public void verifyBlobKzgProofTests(final VerifyBlobKzgProofTest test) {
+    try {
+      boolean valid =
+          CKZG4844FFM.verifyBlobKzgProof(
+              test.getInput().getBlob(),
+              test.getInput().getCommitment(),
+              test.getInput().getProof());
+      assertEquals(test.getOutput(), valid);
+    } catch (CKZGException ex) {
+      assertNull(test.getOutput());
+    }
+  }
+
+  @ParameterizedTest
+  @MethodSource("getVerifyBlobKzgProofBatchTests")
+  public void verifyBlobKzgProofBatchTests(final VerifyBlobKzgProofBatchTest test) {
+    try {
+      int count = test.getInput().getBlobs().length / CKZG4844FFM.BYTES_PER_BLOB;
+      boolean valid =
+          CKZG4844FFM.verifyBlobKzgProofBatch(
+              test.getInput().getBlobs(),
+              test.getInput().getCommitments(),
+              test.getInput().getProofs(),
+              count);
+      assertEquals(test.getOutput(), valid);
+    } catch (CKZGException ex) {
+      assertNull(test.getOutput());
+    }
+  }
+
+  @Test
+  public void segmentsGiveTheSameResultsAsArrays() {
+    loadTrustedSetup();
+    final int count = 3;
+    try (Arena arena = Arena.ofConfined()) {
+      final MemorySegment blobs = arena.allocate((long) count * CKZG4844FFM.BYTES_PER_BLOB);
+      final MemorySegment commitments =
+          arena.allocate((long) count * CKZG4844FFM.BYTES_PER_COMMITMENT);
+      final MemorySegment proofs = arena.allocate((long) count * CKZG4844FFM.BYTES_PER_PROOF);
+      for (int i = 0; i < count; i++) {
+        final byte[] blobBytes = TestUtils.createRandomBlob();
+        final MemorySegment blob =
+            blobs.asSlice((long) i * CKZG4844FFM.BYTES_PER_BLOB, CKZG4844FFM.BYTES_PER_BLOB);
+        final MemorySegment commitment =
+            commitments.asSlice(
+                (long) i * CKZG4844FFM.BYTES_PER_COMMITMENT, CKZG4844FFM.BYTES_PER_COMMITMENT);
+        final MemorySegment proof =
+            proofs.asSlice((long) i * CKZG4844FFM.BYTES_PER_PROOF, CKZG4844FFM.BYTES_PER_PROOF);
+        MemorySegment.copy(blobBytes, 0, blob, JAVA_BYTE, 0, blobBytes.length);
+
+        CKZG4844FFM.blobToKzgCommitment(commitment, blob);
+        assertArrayEquals(
+            CKZG4844FFM.blobToKzgCommitment(blobBytes), commitment.toArray(JAVA_BYTE));
+        CKZG4844FFM.computeBlobKzgProof(proof, blob, commitment);
+        assertArrayEquals(
+            CKZG4844FFM.computeBlobKzgProof(blobBytes, commitment.toArray(JAVA_BYTE)),
+            proof.toArray(JAVA_BYTE));
+        assertTrue(CKZG4844FFM.verifyBlobKzgProof(blob, commitment, proof));
+      }
+
+      assertTrue(CKZG4844FFM.verifyBlobKzgProofBatch(blobs, commitments, proofs, count));
+      assertTrue(
+          CKZG4844FFM.verifyBlobKzgProofBatch(
+              blobs.toArray(JAVA_BYTE),
+              commitments.toArray(JAVA_BYTE),
+              proofs.toArray(JAVA_BYTE),
+              count));
+
+      final MemorySegment fakeProofs = arena.allocate(proofs.byteSize());
+      fakeProofs.copyFrom(proofs.asSlice(CKZG4844FFM.BYTES_PER_PROOF));
+      fakeProofs
+          .asSlice(proofs.byteSize() - CKZG4844FFM.BYTES_PER_PROOF)
+          .copyFrom(proofs.asSlice(0, CKZG4844FFM.BYTES_PER_PROOF));
+      assertFalse(CKZG4844FFM.verifyBlobKzgProofBatch(blobs, commitments, fakeProofs, count));
+
+      final byte[] z_bytes = TestUtils.randomBLSFieldElementBytes();
+      final MemorySegment z = arena.allocateFrom(JAVA_BYTE, z_bytes);
+      final MemorySegment proof = arena.allocate(CKZG4844FFM.BYTES_PER_PROOF);
+      final MemorySegment y = arena.allocate(CKZG4844FFM.BYTES_PER_FIELD_ELEMENT);
+      final MemorySegment blob = blobs.asSlice(0, CKZG4844FFM.BYTES_PER_BLOB);
+      CKZG4844FFM.computeKzgProof(proof, y, blob, z);
+      final ProofAndY proofAndY = CKZG4844FFM.computeKzgProof(blob.toArray(JAVA_BYTE), z_bytes);
+      assertArrayEquals(proofAndY.getProof(), proof.toArray(JAVA_BYTE));
+      assertArrayEquals(proofAndY.getY(), y.toArray(JAVA_BYTE));
+      assertTrue(
+          CKZG4844FFM.verifyKzgProof(
+              commitments.asSlice(0, CKZG4844FFM.BYTES_PER_COMMITMENT), z, y, proof));
+    }
+    CKZG4844FFM.freeTrustedSetup();
+  }
+
+  @Test
+  public void checkCustomExceptionIsThrownAsExpected() {
+    loadTrustedSetup();
+
+    final byte[] blob = TestUtils.createNonCanonicalBlob();
+
+    final CKZGException exception =
+        assertThrows(CKZGException.class, () -> CKZG4844FFM.blobToKzgCommitment(blob));
+
+    assertEquals(C_KZG_BADARGS, exception.getError());
+    assertEquals("There was an error in blobToKzgCommitment.", exception.getErrorMessage());
+
+    CKZG4844FFM.freeTrustedSetup();
+  }
+
+  @Test
+  public void passingInvalidLengthsThrowsAnException() {
+    loadTrustedSetup();
+
+    CKZGException exception =
+        assertThrows(CKZGException.class, () -> CKZG4844FFM.blobToKzgCommitment(new byte[0]));
+
+    assertEquals(C_KZG_BADARGS, exception.getError());
+    assertEquals(
+        String.format(
+            "Invalid blob size. Expected %d bytes but got 0.", CKZG4844FFM.BYTES_PER_BLOB),
+        exception.getErrorMessage());
+
+    exception =
+        assertThrows(
+            CKZGException.class,
+            () ->
+                CKZG4844FFM.computeBlobKzgProof(
+                    new byte[CKZG4844FFM.BYTES_PER_BLOB], new byte[49]));
+
+    assertEquals(C_KZG_BADARGS, exception.getError());
+    assertEquals(
+        "Invalid commitment size. Expected 48 bytes but got 49.", exception.getErrorMessage());
+
+    exception =
+        assertThrows(
+            CKZGException.class,
+            () ->
+                CKZG4844FFM.verifyBlobKzgProofBatch(
+                    TestUtils.createRandomBlobs(2), new byte[144], new byte[96], 2));
+
+    assertEquals(C_KZG_BADARGS, exception.getError());
+    assertEquals(
+        "Invalid commitments size. Expected 96 bytes but got 144.", exception.getErrorMessage());
+
+    try (Arena arena = Arena.ofConfined()) {
+      final MemorySegment blob = arena.allocate(CKZG4844FFM.BYTES_PER_BLOB);
+      final MemorySegment out = arena.allocate(CKZG4844FFM.BYTES_PER_PROOF + 1);
+      exception =
+          assertThrows(CKZGException.class, () -> CKZG4844FFM.blobToKzgCommitment(out, blob));
+      assertEquals(
+          "Invalid commitment size. Expected 48 bytes but got 49.", exception.getErrorMessage());
+    }
+
+    CKZG4844FFM.freeTrustedSetup();
+  }
+
+  @Test
+  public void throwsIfMethodIsUsedWithoutLoadingTrustedSetup() {
+    final RuntimeException exception =
+        assertThrows(
+            RuntimeException.class,
+            () -> CKZG4844FFM.blobToKzgCommitment(TestUtils.createRandomBlob()));
+
+    assertEquals("Trusted Setup is not loaded.", exception.getMessage());
+  }
+
+  @Test
+  public void throwsIfSetupIsLoadedTwice() {
+    loadTrustedSetup();
+
+    final RuntimeException exception =
+        assertThrows(RuntimeException.class, CKZG4844FFMTest::loadTrustedSetup);
+
+    assertEquals(
+        "Trusted Setup is already loaded. Free it before loading a new one.",
+        exception.getMessage());
+
+    CKZG4844FFM.freeTrustedSetup();
+  }
+
+  @Test
+  public void loadsTrustedSetupFromParametersAndResource() {
+    final LoadTrustedSetupParameters parameters =
+        TestUtils.createLoadTrustedSetupParameters(TRUSTED_SETUP_FILE);
+    CKZG4844FFM.loadTrustedSetup(
+        parameters.getG1(), parameters.getG1Count(), parameters.getG2(), parameters.getG2Count());
+    final byte[] blob = TestUtils.createRandomBlob();
+    final byte[] commitment = CKZG4844FFM.blobToKzgCommitment(blob);
+    CKZG4844FFM.freeTrustedSetup();
+
+    CKZG4844FFM.loadTrustedSetupFromResource(TRUSTED_SETUP_RESOURCE, CKZG4844FFMTest.class);
+    assertArrayEquals(commitment, CKZG4844FFM.blobToKzgCommitment(blob));
+    CKZG4844FFM.freeTrustedSetup();
+  }
+
+  @Test
+  public void shouldThrowExceptionIfTrustedSetupIsNotInLagrangeForm() {
+    CKZGException exception =
+        assertThrows(
+            CKZGException.class, () -> CKZG4844FFM.loadTrustedSetup(OLD_TRUSTED_SETUP_FILE));
+
+    assertEquals(C_KZG_BADARGS, exception.getError());
+  }
+
+  @Test
+  public void shouldThrowExceptionOnIncorrectTrustedSetupParameters() {
+    final LoadTrustedSetupParameters parameters =
+        TestUtils.createLoadTrustedSetupParameters(TRUSTED_SETUP_FILE);
+
+    CKZGException exception =
+        assertThrows(
+            CKZGException.class,
+            () ->
+                CKZG4844FFM.loadTrustedSetup(
+                    parameters.getG1(),
+                    parameters.getG1Count() + 1,
+                    parameters.getG2(),
+                    parameters.getG2Count()));
+    assertEquals(C_KZG_BADARGS, exception.getError());
+    assertTrue(exception.getErrorMessage().contains("Invalid g1 size."));
+  }
+
+  private static void loadTrustedSetup() {
+    CKZG4844FFM.loadTrustedSetup(TRUSTED_SETUP_FILE);
+  }
+
+  private static Stream<BlobToKzgCommitmentTest> getBlobToKzgCommitmentTests() {
+    loadTrustedSetup();
+    return TestUtils.getBlobToKzgCommitmentTests().stream().onClose(CKZG4844FFM::freeTrustedSetup);
+  }
+
+  private static Stream<ComputeKzgProofTest> getComputeKzgProofTests() {
+    loadTrustedSetup();
+    return TestUtils.getComputeKzgProofTests().stream().onClose(CKZG4844FFM::freeTrustedSetup);
+  }
+
+  private static Stream<ComputeBlobKzgProofTest> getComputeBlobKzgProofTests() {
+    loadTrustedSetup();
+    return TestUtils.getComputeBlobKzgProofTests().stream().onClose(CKZG4844FFM::freeTrustedSetup);
+  }
+
+  private static Stream<VerifyKzgProofTest> getVerifyKzgProofTests() {
+    loadTrustedSetup();
+    return TestUtils.getVerifyKzgProofTests().stream().onClose(CKZG4844FFM::freeTrustedSetup);
+  }
+
+  private static Stream<VerifyBlobKzgProofTest> getVerifyBlobKzgProofTests() {
+    loadTrustedSetup();
+    return TestUtils.getVerifyBlobKzgProofTests().stream().onClose(CKZG4844FFM::freeTrustedSetup);
+  }
+
+  private static Stream<VerifyBlobKzgProofBatchTest> getVerifyBlobKzgProofBatchTests() {
+    loadTrustedSetup();
+    return TestUtils.getVerifyBlobKzgProofBatchTests().stream()
+        .onClose(CKZG4844FFM::freeTrustedSetup);
+  }
+}
@@ -9,10 +9,8 @@ export type KZGCommitment = Uint8Array; // 48 bytes
 export type Blob = Uint8Array; // 4096 * 32 bytes
 export type ProofResult = [KZGProof, Bytes32];
 export interface TrustedSetupJson {
-  setup_G1: string[];
-  setup_G2: string[];
-  setup_G1_lagrange: string[];
-  roots_of_unity: string[];
+  g1_lagrange: string[];
+  g2_monomial: string[];
 }
 
 export const BYTES_PER_BLOB: number;
@@ -117,12 +115,20 @@ export function verifyBlobKzgProof(blob: Blob, commitmentBytes: Bytes48, proofBy
  *
  * Note: blobs[0] relates to commitmentBytes[0] and proofBytes[0]
  *
- * @param {Blob}    blobs - An array of serialized blobs to verify
- * @param {Bytes48} commitmentsBytes - An array of serialized commitments to verify
- * @param {Bytes48} proofsBytes - An array of serialized KZG proofs for verification
+ * Each argument can also be one Uint8Array with the concatenation of its
+ * items, which is read without a copy. So is an array of views that are
+ * consecutive in one buffer, such as its subarrays.
+ *
+ * @param {Blob[] | Uint8Array}    blobs - An array of serialized blobs to verify
+ * @param {Bytes48[] | Uint8Array} commitmentsBytes - An array of serialized commitments to verify
+ * @param {Bytes48[] | Uint8Array} proofsBytes - An array of serialized KZG proofs for verification
  *
  * @return {boolean} - true/false depending on batch validity
  *
  * @throws {TypeError} - For invalid arguments or failure of the native library
  */
-export function verifyBlobKzgProofBatch(blobs: Blob[], commitmentsBytes: Bytes48[], proofsBytes: Bytes48[]): boolean;
+export function verifyBlobKzgProofBatch(
+  blobs: Blob[] | Uint8Array,
+  commitmentsBytes: Bytes48[] | Uint8Array,
+  proofsBytes: Bytes48[] | Uint8Array
+): boolean;
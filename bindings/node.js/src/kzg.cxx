@@ -161,6 +161,98 @@ inline Bytes48 *get_bytes48(
     );
 }
 
+/**
+ * Checks for:
+ * - arg is an Array of Uint8Arrays of length `length`, or one Uint8Array
+ *   with their concatenation
+ *
+ * Gets the items of a batch argument as one contiguous array, as the native
+ * library expects them, without copying them where possible. A single
+ * Uint8Array is used in place. So is an Array whose items are consecutive
+ * views of the same memory, such as subarrays of one buffer. Otherwise, the
+ * items are copied into memory allocated in `*copy`, which the caller must
+ * free, even when an exception was raised.
+ *
+ * Designed to raise the correct javascript exception and return false to
+ * the calling context to avoid native stack-frame unwinds.
+ *
+ * @param[in]  env    Passed from calling context
+ * @param[in]  val    Napi::Value to validate and get items from
+ * @param[in]  length Byte length of each item
+ * @param[in]  name   Name of the items for error reporting
+ * @param[out] items  Pointer to the first byte of the first item
+ * @param[out] count  Number of items
+ * @param[out] copy   Memory allocated for a copy of the items, or nullptr
+ *
+ * @return - true if the items are valid, false if an exception was raised
+ */
+bool get_batch(
+    const Napi::Env &env,
+    const Napi::Value &val,
+    size_t length,
+    std::string_view name,
+    uint8_t **items,
+    uint32_t *count,
+    uint8_t **copy
+) {
+    *items = nullptr;
+    *count = 0;
+    *copy = nullptr;
+    if (val.IsTypedArray() &&
+        val.As<Napi::TypedArray>().TypedArrayType() == napi_uint8_array) {
+        Napi::Uint8Array array = val.As<Napi::Uint8Array>();
+        if (array.ByteLength() % length != 0) {
+            std::ostringstream msg;
+            msg << "Expected " << name << " batch to be a multiple of "
+                << length << " bytes";
+            Napi::TypeError::New(env, msg.str()).ThrowAsJavaScriptException();
+            return false;
+        }
+        *items = array.Data();
+        *count = array.ByteLength() / length;
+        return true;
+    }
+
+    Napi::Array array = val.As<Napi::Array>();
+    uint32_t n = array.Length();
+    bool contiguous = true;
+    for (uint32_t index = 0; index < n; index++) {
+        // add HandleScope here to release reference to temp values after each
+        // iteration, the array keeps the items alive
+        Napi::HandleScope scope{env};
+        uint8_t *item = get_bytes(env, array[index], length, name);
+        if (item == nullptr) {
+            return false;
+        }
+        if (index == 0) {
+            *items = item;
+        } else if (item != *items + index * length) {
+            contiguous = false;
+        }
+    }
+    *count = n;
+    if (contiguous) {
+        return true;
+    }
+
+    *copy = (uint8_t *)calloc(n, length);
+    if (*copy == nullptr) {
+        Napi::Error::New(env, "Error while allocating memory for batch")
+            .ThrowAsJavaScriptException();
+        return false;
+    }
+    for (uint32_t index = 0; index < n; index++) {
+        Napi::HandleScope scope{env};
+        uint8_t *item = get_bytes(env, array[index], length, name);
+        if (item == nullptr) {
+            return false;
+        }
+        memcpy(*copy + index * length, item, length);
+    }
+    *items = *copy;
+    return true;
+}
+
 Napi::Value LoadTrustedSetup(const Napi::CallbackInfo &info) {
     Napi::Env env = info.Env();
 
@@ -219,8 +311,12 @@ Napi::Value BlobToKzgCommitment(const Napi::CallbackInfo &info) {
         return env.Null();
     }
 
-    KZGCommitment commitment;
-    C_KZG_RET ret = blob_to_kzg_commitment(&commitment, blob, kzg_settings);
+    Napi::Buffer<uint8_t> commitment = Napi::Buffer<uint8_t>::New(
+        env, BYTES_PER_COMMITMENT
+    );
+    C_KZG_RET ret = blob_to_kzg_commitment(
+        reinterpret_cast<KZGCommitment *>(commitment.Data()), blob, kzg_settings
+    );
     if (ret != C_KZG_OK) {
         std::ostringstream msg;
         msg << "Failed to convert blob to commitment: " << from_c_kzg_ret(ret);
@@ -228,9 +324,7 @@ Napi::Value BlobToKzgCommitment(const Napi::CallbackInfo &info) {
         return env.Undefined();
     }
 
-    return Napi::Buffer<uint8_t>::Copy(
-        env, reinterpret_cast<uint8_t *>(&commitment), BYTES_PER_COMMITMENT
-    );
+    return commitment;
 }
 
 /**
@@ -259,10 +353,18 @@ Napi::Value ComputeKzgProof(const Napi::CallbackInfo &info) {
         return env.Null();
     }
 
-    KZGProof proof;
-    Bytes32 y_out;
+    Napi::Buffer<uint8_t> proof = Napi::Buffer<uint8_t>::New(
+        env, BYTES_PER_PROOF
+    );
+    Napi::Buffer<uint8_t> y_out = Napi::Buffer<uint8_t>::New(
+        env, BYTES_PER_FIELD_ELEMENT
+    );
     C_KZG_RET ret = compute_kzg_proof(
-        &proof, &y_out, blob, z_bytes, kzg_settings
+        reinterpret_cast<KZGProof *>(proof.Data()),
+        reinterpret_cast<Bytes32 *>(y_out.Data()),
+        blob,
+        z_bytes,
+        kzg_settings
     );
 
     if (ret != C_KZG_OK) {
@@ -273,12 +375,8 @@ Napi::Value ComputeKzgProof(const Napi::CallbackInfo &info) {
     }
 
     Napi::Array tuple = Napi::Array::New(env, 2);
-    tuple[(uint32_t)0] = Napi::Buffer<uint8_t>::Copy(
-        env, reinterpret_cast<uint8_t *>(&proof), BYTES_PER_PROOF
-    );
-    tuple[(uint32_t)1] = Napi::Buffer<uint8_t>::Copy(
-        env, reinterpret_cast<uint8_t *>(&y_out), BYTES_PER_FIELD_ELEMENT
-    );
+    tuple[(uint32_t)0] = proof;
+    tuple[(uint32_t)1] = y_out;
     return tuple;
 }
 
@@ -308,9 +406,14 @@ Napi::Value ComputeBlobKzgProof(const Napi::CallbackInfo &info) {
         return env.Null();
     }
 
-    KZGProof proof;
+    Napi::Buffer<uint8_t> proof = Napi::Buffer<uint8_t>::New(
+        env, BYTES_PER_PROOF
+    );
     C_KZG_RET ret = compute_blob_kzg_proof(
-        &proof, blob, commitment_bytes, kzg_settings
+        reinterpret_cast<KZGProof *>(proof.Data()),
+        blob,
+        commitment_bytes,
+        kzg_settings
     );
 
     if (ret != C_KZG_OK) {
@@ -320,9 +423,7 @@ Napi::Value ComputeBlobKzgProof(const Napi::CallbackInfo &info) {
         return env.Undefined();
     }
 
-    return Napi::Buffer<uint8_t>::Copy(
-        env, reinterpret_cast<uint8_t *>(&proof), BYTES_PER_PROOF
-    );
+    return proof;
 }
 
 /**
@@ -428,6 +529,9 @@ Napi::Value VerifyBlobKzgProof(const Napi::CallbackInfo &info) {
  *
  * @remark blobs[0] relates to commitmentBytes[0] and proofBytes[0]
  *
+ * @remark Each argument can also be one Uint8Array with the concatenation of
+ *         its items, which is passed to the native library without a copy
+ *
  * @param[in] {Blob}    blobs - An array of serialized blobs to verify
  * @param[in] {Bytes48} commitmentBytes - An array of serialized commitments to
  *                                        verify
@@ -441,77 +545,75 @@ Napi::Value VerifyBlobKzgProof(const Napi::CallbackInfo &info) {
 Napi::Value VerifyBlobKzgProofBatch(const Napi::CallbackInfo &info) {
     Napi::Env env = info.Env();
     C_KZG_RET ret;
-    Blob *blobs = NULL;
-    Bytes48 *commitments = NULL;
-    Bytes48 *proofs = NULL;
+    uint8_t *blobs = nullptr, *commitments = nullptr, *proofs = nullptr;
+    uint8_t *blobs_copy = nullptr, *commitments_copy = nullptr,
+            *proofs_copy = nullptr;
+    uint32_t blobs_count, commitments_count, proofs_count;
+    bool out;
     Napi::Value result = env.Null();
-    if (!(info[0].IsArray() && info[1].IsArray() && info[2].IsArray())) {
-        Napi::Error::New(
-            env, "Blobs, commitments, and proofs must all be arrays"
-        )
-            .ThrowAsJavaScriptException();
-        return result;
+    for (size_t i = 0; i < 3; i++) {
+        if (!info[i].IsArray() &&
+            !(info[i].IsTypedArray() &&
+              info[i].As<Napi::TypedArray>().TypedArrayType() ==
+                  napi_uint8_array)) {
+            Napi::Error::New(
+                env,
+                "Blobs, commitments, and proofs must all be arrays or "
+                "Uint8Arrays"
+            )
+                .ThrowAsJavaScriptException();
+            return result;
+        }
     }
-    Napi::Array blobs_param = info[0].As<Napi::Array>();
-    Napi::Array commitments_param = info[1].As<Napi::Array>();
-    Napi::Array proofs_param = info[2].As<Napi::Array>();
     KZGSettings *kzg_settings = get_kzg_settings(env, info);
     if (kzg_settings == nullptr) {
         return env.Null();
     }
-    uint32_t count = blobs_param.Length();
-    if (count != commitments_param.Length() || count != proofs_param.Length()) {
+
+    if (!get_batch(
+            env,
+            info[0],
+            BYTES_PER_BLOB,
+            "blob",
+            &blobs,
+            &blobs_count,
+            &blobs_copy
+        ) ||
+        !get_batch(
+            env,
+            info[1],
+            BYTES_PER_COMMITMENT,
+            "commitmentBytes",
+            &commitments,
+            &commitments_count,
+            &commitments_copy
+        ) ||
+        !get_batch(
+            env,
+            info[2],
+            BYTES_PER_PROOF,
+            "proofBytes",
+            &proofs,
+            &proofs_count,
+            &proofs_copy
+        )) {
+        goto out;
+    }
+    if (blobs_count != commitments_count || blobs_count != proofs_count) {
         Napi::Error::New(
             env, "Requires equal number of blobs/commitments/proofs"
         )
             .ThrowAsJavaScriptException();
-        return result;
-    }
-    blobs = (Blob *)calloc(count, sizeof(Blob));
-    if (blobs == nullptr) {
-        Napi::Error::New(env, "Error while allocating memory for blobs")
-            .ThrowAsJavaScriptException();
-        goto out;
-    }
-    commitments = (Bytes48 *)calloc(count, sizeof(Bytes48));
-    if (commitments == nullptr) {
-        Napi::Error::New(env, "Error while allocating memory for commitments")
-            .ThrowAsJavaScriptException();
-        goto out;
-    }
-    proofs = (Bytes48 *)calloc(count, sizeof(Bytes48));
-    if (proofs == nullptr) {
-        Napi::Error::New(env, "Error while allocating memory for proofs")
-            .ThrowAsJavaScriptException();
         goto out;
     }
 
-    for (uint32_t index = 0; index < count; index++) {
-        // add HandleScope here to release reference to temp values
-        // after each iteration since data is being memcpy
-        Napi::HandleScope scope{env};
-        Blob *blob = get_blob(env, blobs_param[index]);
-        if (blob == nullptr) {
-            goto out;
-        }
-        memcpy(&blobs[index], blob, BYTES_PER_BLOB);
-        Bytes48 *commitment = get_bytes48(
-            env, commitments_param[index], "commitmentBytes"
-        );
-        if (commitment == nullptr) {
-            goto out;
-        }
-        memcpy(&commitments[index], commitment, BYTES_PER_COMMITMENT);
-        Bytes48 *proof = get_bytes48(env, proofs_param[index], "proofBytes");
-        if (proof == nullptr) {
-            goto out;
-        }
-        memcpy(&proofs[index], proof, BYTES_PER_PROOF);
-    }
-
-    bool out;
     ret = verify_blob_kzg_proof_batch(
-        &out, blobs, commitments, proofs, count, kzg_settings
+        &out,
+        reinterpret_cast<Blob *>(blobs),
+        reinterpret_cast<Bytes48 *>(commitments),
+        reinterpret_cast<Bytes48 *>(proofs),
+        blobs_count,
+        kzg_settings
     );
 
     if (ret != C_KZG_OK) {
@@ -524,9 +626,9 @@ Napi::Value VerifyBlobKzgProofBatch(const Napi::CallbackInfo &info) {
     result = Napi::Boolean::New(env, out);
 
 out:
-    free(blobs);
-    free(commitments);
-    free(proofs);
+    free(blobs_copy);
+    free(commitments_copy);
+    free(proofs_copy);
     return result;
 }
 
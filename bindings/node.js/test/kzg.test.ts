@@ -556,6 +556,38 @@ describe("C-KZG", () => {
 
     it("zero blobs/commitments/proofs should verify as true", () => {
       expect(verifyBlobKzgProofBatch([], [], [])).toBe(true);
+      expect(verifyBlobKzgProofBatch(new Uint8Array(0), new Uint8Array(0), new Uint8Array(0))).toBe(true);
+    });
+
+    it("should accept concatenated items and views of one buffer", () => {
+      const count = 3;
+      const blobs = Buffer.concat(Array.from({length: count}, generateRandomBlob));
+      const blobViews = Array.from({length: count}, (_, i) =>
+        blobs.subarray(i * BYTES_PER_BLOB, (i + 1) * BYTES_PER_BLOB)
+      );
+      const commitments = blobViews.map((blob) => blobToKzgCommitment(blob));
+      const proofs = blobViews.map((blob, i) => computeBlobKzgProof(blob, commitments[i]));
+
+      expect(verifyBlobKzgProofBatch(blobs, Buffer.concat(commitments), Buffer.concat(proofs))).toBe(true);
+      expect(verifyBlobKzgProofBatch(blobViews, commitments, proofs)).toBe(true);
+      expect(verifyBlobKzgProofBatch(blobs, commitments, Buffer.concat(proofs))).toBe(true);
+      // Views that are not in order are copied, and still paired by index.
+      expect(verifyBlobKzgProofBatch(blobViews.slice().reverse(), commitments, proofs)).toBe(false);
+      expect(
+        verifyBlobKzgProofBatch(blobViews.slice().reverse(), commitments.slice().reverse(), proofs.slice().reverse())
+      ).toBe(true);
+    });
+
+    it("throws as expected when given concatenated items of invalid length", () => {
+      expect(() =>
+        verifyBlobKzgProofBatch(blobBadLength, new Uint8Array(0), new Uint8Array(0))
+      ).toThrowError("Expected blob batch to be a multiple of 131072 bytes");
+      expect(() =>
+        verifyBlobKzgProofBatch(blobValidLength, commitmentBadLength, proofValidLength)
+      ).toThrowError("Expected commitmentBytes batch to be a multiple of 48 bytes");
+      expect(() =>
+        verifyBlobKzgProofBatch(blobValidLength, commitmentValidLength, new Uint8Array(0))
+      ).toThrowError("Requires equal number of blobs/commitments/proofs");
     });
 
     it("mismatching blobs/commitments/proofs should throw error", () => {
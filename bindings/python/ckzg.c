@@ -2,6 +2,13 @@
 #include <Python.h>
 #include "c_kzg_4844.h"
 
+/*
+ * The functions release the GIL while the C library runs, so that other Python
+ * threads can run, including other KZG operations. This is safe because their
+ * arguments are immutable bytes objects and a capsule, which the argument tuple
+ * keeps alive until they return, and the settings are only read.
+ */
+
 static void free_KZGSettings(PyObject *c) {
   KZGSettings *s = PyCapsule_GetPointer(c, "KZGSettings");
   free_trusted_setup(s);
@@ -24,8 +31,11 @@ static PyObject* load_trusted_setup_wrap(PyObject *self, PyObject *args) {
     return PyErr_Format(PyExc_RuntimeError, "error reading trusted setup");
   }
 
-  C_KZG_RET ret = load_trusted_setup_file(s, fp);
+  C_KZG_RET ret;
+  Py_BEGIN_ALLOW_THREADS
+  ret = load_trusted_setup_file(s, fp);
   fclose(fp);
+  Py_END_ALLOW_THREADS
 
   if (ret != C_KZG_OK) {
     free(s);
@@ -52,7 +62,12 @@ static PyObject* blob_to_kzg_commitment_wrap(PyObject *self, PyObject *args) {
 
   Blob *blob = (Blob *)PyBytes_AsString(b);
   KZGCommitment *k = (KZGCommitment *)PyBytes_AsString(out);
-  if (blob_to_kzg_commitment(k, blob, PyCapsule_GetPointer(s, "KZGSettings")) != C_KZG_OK) {
+  const KZGSettings *settings = PyCapsule_GetPointer(s, "KZGSettings");
+  C_KZG_RET ret;
+  Py_BEGIN_ALLOW_THREADS
+  ret = blob_to_kzg_commitment(k, blob, settings);
+  Py_END_ALLOW_THREADS
+  if (ret != C_KZG_OK) {
     Py_DECREF(out);
     return PyErr_Format(PyExc_RuntimeError, "blob_to_kzg_commitment failed");
   }
@@ -86,7 +101,12 @@ static PyObject* compute_kzg_proof_wrap(PyObject *self, PyObject *args) {
   Bytes32 *z_bytes = (Bytes32 *)PyBytes_AsString(z);
   KZGProof *proof = (KZGProof *)PyBytes_AsString(py_proof);
   Bytes32 *y_bytes = (Bytes32 *)PyBytes_AsString(py_y);
-  if (compute_kzg_proof(proof, y_bytes, blob, z_bytes, PyCapsule_GetPointer(s, "KZGSettings")) != C_KZG_OK) {
+  const KZGSettings *settings = PyCapsule_GetPointer(s, "KZGSettings");
+  C_KZG_RET ret;
+  Py_BEGIN_ALLOW_THREADS
+  ret = compute_kzg_proof(proof, y_bytes, blob, z_bytes, settings);
+  Py_END_ALLOW_THREADS
+  if (ret != C_KZG_OK) {
     Py_DECREF(out);
     return PyErr_Format(PyExc_RuntimeError, "compute_kzg_proof failed");
   }
@@ -114,7 +134,12 @@ static PyObject* compute_blob_kzg_proof_wrap(PyObject *self, PyObject *args) {
   Blob *blob = (Blob *)PyBytes_AsString(b);
   Bytes48 *commitment_bytes = (Bytes48 *)PyBytes_AsString(c);
   KZGProof *proof = (KZGProof *)PyBytes_AsString(out);
-  if (compute_blob_kzg_proof(proof, blob, commitment_bytes, PyCapsule_GetPointer(s, "KZGSettings")) != C_KZG_OK) {
+  const KZGSettings *settings = PyCapsule_GetPointer(s, "KZGSettings");
+  C_KZG_RET ret;
+  Py_BEGIN_ALLOW_THREADS
+  ret = compute_blob_kzg_proof(proof, blob, commitment_bytes, settings);
+  Py_END_ALLOW_THREADS
+  if (ret != C_KZG_OK) {
     Py_DECREF(out);
     return PyErr_Format(PyExc_RuntimeError, "compute_blob_kzg_proof failed");
   }
@@ -148,10 +173,14 @@ static PyObject* verify_kzg_proof_wrap(PyObject *self, PyObject *args) {
   const Bytes32 *y_bytes = (Bytes32 *)PyBytes_AsString(y);
   const Bytes48 *proof_bytes = (Bytes48 *)PyBytes_AsString(p);
 
+  const KZGSettings *settings = PyCapsule_GetPointer(s, "KZGSettings");
   bool ok;
-  if (verify_kzg_proof(&ok,
-        commitment_bytes, z_bytes, y_bytes, proof_bytes,
-        PyCapsule_GetPointer(s, "KZGSettings")) != C_KZG_OK) {
+  C_KZG_RET ret;
+  Py_BEGIN_ALLOW_THREADS
+  ret = verify_kzg_proof(&ok,
+      commitment_bytes, z_bytes, y_bytes, proof_bytes, settings);
+  Py_END_ALLOW_THREADS
+  if (ret != C_KZG_OK) {
     return PyErr_Format(PyExc_RuntimeError, "verify_kzg_proof failed");
   }
 
@@ -180,10 +209,14 @@ static PyObject* verify_blob_kzg_proof_wrap(PyObject *self, PyObject *args) {
   const Bytes48 *commitment_bytes = (Bytes48 *)PyBytes_AsString(c);
   const Bytes48 *proof_bytes = (Bytes48 *)PyBytes_AsString(p);
 
+  const KZGSettings *settings = PyCapsule_GetPointer(s, "KZGSettings");
   bool ok;
-  if (verify_blob_kzg_proof(&ok,
-        blob_bytes, commitment_bytes, proof_bytes,
-        PyCapsule_GetPointer(s, "KZGSettings")) != C_KZG_OK) {
+  C_KZG_RET ret;
+  Py_BEGIN_ALLOW_THREADS
+  ret = verify_blob_kzg_proof(&ok,
+      blob_bytes, commitment_bytes, proof_bytes, settings);
+  Py_END_ALLOW_THREADS
+  if (ret != C_KZG_OK) {
     return PyErr_Format(PyExc_RuntimeError, "verify_blob_kzg_proof failed");
   }
 
@@ -224,10 +257,14 @@ static PyObject* verify_blob_kzg_proof_batch_wrap(PyObject *self, PyObject *args
   const Bytes48 *commitments_bytes = (Bytes48 *)PyBytes_AsString(c);
   const Bytes48 *proofs_bytes = (Bytes48 *)PyBytes_AsString(p);
 
+  const KZGSettings *settings = PyCapsule_GetPointer(s, "KZGSettings");
   bool ok;
-  if (verify_blob_kzg_proof_batch(&ok,
-        blobs_bytes, commitments_bytes, proofs_bytes, blobs_count,
-        PyCapsule_GetPointer(s, "KZGSettings")) != C_KZG_OK) {
+  C_KZG_RET ret;
+  Py_BEGIN_ALLOW_THREADS
+  ret = verify_blob_kzg_proof_batch(&ok,
+      blobs_bytes, commitments_bytes, proofs_bytes, blobs_count, settings);
+  Py_END_ALLOW_THREADS
+  if (ret != C_KZG_OK) {
     return PyErr_Format(PyExc_RuntimeError, "verify_blob_kzg_proof_batch failed");
   }
 
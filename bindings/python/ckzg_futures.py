@@ -0,0 +1,35 @@
+"""Run the KZG operations of ckzg on a pool of threads.
+
+The functions of ckzg release the GIL while they run, so several of them can
+run at once on different threads. KZGExecutor is a ThreadPoolExecutor bound to
+a trusted setup, with a method for each operation that submits it to the pool
+and returns a Future of its result.
+"""
+
+from concurrent.futures import ThreadPoolExecutor
+
+import ckzg
+
+
+class KZGExecutor(ThreadPoolExecutor):
+    def __init__(self, ts, max_workers=None):
+        super().__init__(max_workers=max_workers, thread_name_prefix="ckzg")
+        self.ts = ts
+
+    def blob_to_kzg_commitment(self, blob):
+        return self.submit(ckzg.blob_to_kzg_commitment, blob, self.ts)
+
+    def compute_kzg_proof(self, blob, z):
+        return self.submit(ckzg.compute_kzg_proof, blob, z, self.ts)
+
+    def compute_blob_kzg_proof(self, blob, commitment):
+        return self.submit(ckzg.compute_blob_kzg_proof, blob, commitment, self.ts)
+
+    def verify_kzg_proof(self, commitment, z, y, proof):
+        return self.submit(ckzg.verify_kzg_proof, commitment, z, y, proof, self.ts)
+
+    def verify_blob_kzg_proof(self, blob, commitment, proof):
+        return self.submit(ckzg.verify_blob_kzg_proof, blob, commitment, proof, self.ts)
+
+    def verify_blob_kzg_proof_batch(self, blobs, commitments, proofs):
+        return self.submit(ckzg.verify_blob_kzg_proof_batch, blobs, commitments, proofs, self.ts)
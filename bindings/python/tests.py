@@ -2,6 +2,7 @@ import glob
 import yaml
 
 import ckzg
+import ckzg_futures
 
 ###############################################################################
 # Constants
@@ -159,6 +160,32 @@ def test_verify_blob_kzg_proof_batch(ts):
         assert valid == expected_valid, f"{test_file}\n{valid=}\n{expected_valid=}"
 
 
+def test_executor(ts):
+    test_files = glob.glob(VERIFY_BLOB_KZG_PROOF_BATCH_TESTS)
+    assert len(test_files) > 0
+
+    with ckzg_futures.KZGExecutor(ts, max_workers=4) as executor:
+        futures = []
+        for test_file in test_files:
+            with open(test_file, "r") as f:
+                test = yaml.safe_load(f)
+
+            blobs = b"".join(map(bytes_from_hex, test["input"]["blobs"]))
+            commitments = b"".join(map(bytes_from_hex, test["input"]["commitments"]))
+            proofs = b"".join(map(bytes_from_hex, test["input"]["proofs"]))
+            future = executor.verify_blob_kzg_proof_batch(blobs, commitments, proofs)
+            futures.append((test_file, test["output"], future))
+
+        for test_file, expected_valid, future in futures:
+            try:
+                valid = future.result()
+            except:
+                assert expected_valid is None
+                continue
+
+            assert valid == expected_valid, f"{test_file}\n{valid=}\n{expected_valid=}"
+
+
 ###############################################################################
 # Main Logic
 ###############################################################################
@@ -172,5 +199,6 @@ if __name__ == "__main__":
     test_verify_kzg_proof(ts)
     test_verify_blob_kzg_proof(ts)
     test_verify_blob_kzg_proof_batch(ts)
+    test_executor(ts)
 
     print("tests passed")
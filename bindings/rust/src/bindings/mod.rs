@@ -1,5 +1,7 @@
 #![allow(non_camel_case_types, non_snake_case, non_upper_case_globals)]
 
+#[cfg(feature = "tokio")]
+mod nonblocking;
 #[cfg(feature = "serde")]
 mod serde;
 #[cfg(test)]
@@ -0,0 +1,228 @@
+//! Async wrappers for clients running on a `tokio` runtime.
+//!
+//! The operations take from a millisecond to tens of milliseconds for a single blob, and longer
+//! for batches, so running them directly in a task blocks the thread of the executor. These
+//! wrappers run them on the blocking thread pool of the current runtime with
+//! [`tokio::task::spawn_blocking`]. The inputs are moved into the blocking task, so they are taken
+//! by value, and the settings are shared with an [`Arc`].
+
+use super::{Blob, Bytes32, Bytes48, Error, KZGCommitment, KZGProof, KZGSettings};
+use alloc::boxed::Box;
+use alloc::sync::Arc;
+use alloc::vec::Vec;
+
+/// Runs `f` on the blocking thread pool and returns its result. If `f` panics, the panic is
+/// resumed in the caller. The task can only be cancelled by shutting down the runtime, which
+/// drops this future too.
+async fn spawn_blocking<F, T>(f: F) -> T
+where
+    F: FnOnce() -> T + Send + 'static,
+    T: Send + 'static,
+{
+    match tokio::task::spawn_blocking(f).await {
+        Ok(result) => result,
+        Err(err) => std::panic::resume_unwind(err.into_panic()),
+    }
+}
+
+impl KZGCommitment {
+    /// Runs [`KZGCommitment::blob_to_kzg_commitment`] on the blocking thread pool.
+    pub async fn blob_to_kzg_commitment_async(
+        blob: Box<Blob>,
+        kzg_settings: Arc<KZGSettings>,
+    ) -> Result<Self, Error> {
+        spawn_blocking(move || Self::blob_to_kzg_commitment(&blob, &kzg_settings)).await
+    }
+}
+
+impl KZGProof {
+    /// Runs [`KZGProof::compute_kzg_proof`] on the blocking thread pool.
+    pub async fn compute_kzg_proof_async(
+        blob: Box<Blob>,
+        z_bytes: Bytes32,
+        kzg_settings: Arc<KZGSettings>,
+    ) -> Result<(Self, Bytes32), Error> {
+        spawn_blocking(move || Self::compute_kzg_proof(&blob, &z_bytes, &kzg_settings)).await
+    }
+
+    /// Runs [`KZGProof::compute_blob_kzg_proof`] on the blocking thread pool.
+    pub async fn compute_blob_kzg_proof_async(
+        blob: Box<Blob>,
+        commitment_bytes: Bytes48,
+        kzg_settings: Arc<KZGSettings>,
+    ) -> Result<Self, Error> {
+        spawn_blocking(move || {
+            Self::compute_blob_kzg_proof(&blob, &commitment_bytes, &kzg_settings)
+        })
+        .await
+    }
+
+    /// Runs [`KZGProof::verify_kzg_proof`] on the blocking thread pool.
+    pub async fn verify_kzg_proof_async(
+        commitment_bytes: Bytes48,
+        z_bytes: Bytes32,
+        y_bytes: Bytes32,
+        proof_bytes: Bytes48,
+        kzg_settings: Arc<KZGSettings>,
+    ) -> Result<bool, Error> {
+        spawn_blocking(move || {
+            Self::verify_kzg_proof(
+                &commitment_bytes,
+                &z_bytes,
+                &y_bytes,
+                &proof_bytes,
+                &kzg_settings,
+            )
+        })
+        .await
+    }
+
+    /// Runs [`KZGProof::verify_blob_kzg_proof`] on the blocking thread pool.
+    pub async fn verify_blob_kzg_proof_async(
+        blob: Box<Blob>,
+        commitment_bytes: Bytes48,
+        proof_bytes: Bytes48,
+        kzg_settings: Arc<KZGSettings>,
+    ) -> Result<bool, Error> {
+        spawn_blocking(move || {
+            Self::verify_blob_kzg_proof(&blob, &commitment_bytes, &proof_bytes, &kzg_settings)
+        })
+        .await
+    }
+
+    /// Runs [`KZGProof::verify_blob_kzg_proof_batch`] on the blocking thread pool.
+    pub async fn verify_blob_kzg_proof_batch_async(
+        blobs: Vec<Blob>,
+        commitments_bytes: Vec<Bytes48>,
+        proofs_bytes: Vec<Bytes48>,
+        kzg_settings: Arc<KZGSettings>,
+    ) -> Result<bool, Error> {
+        spawn_blocking(move || {
+            Self::verify_blob_kzg_proof_batch(
+                &blobs,
+                &commitments_bytes,
+                &proofs_bytes,
+                &kzg_settings,
+            )
+        })
+        .await
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+    use crate::{BYTES_PER_BLOB, BYTES_PER_FIELD_ELEMENT, FIELD_ELEMENTS_PER_BLOB};
+    use rand::Rng;
+    use std::path::Path;
+
+    fn load_settings() -> Arc<KZGSettings> {
+        let trusted_setup_file = Path::new("src/trusted_setup.txt");
+        Arc::new(KZGSettings::load_trusted_setup_file(trusted_setup_file).unwrap())
+    }
+
+    fn generate_random_blob() -> Box<Blob> {
+        let mut arr = [0u8; BYTES_PER_BLOB];
+        rand::thread_rng().fill(&mut arr[..]);
+        // Ensure that each field element is canonical.
+        for i in 0..FIELD_ELEMENTS_PER_BLOB {
+            arr[i * BYTES_PER_FIELD_ELEMENT] = 0;
+        }
+        Box::new(arr.into())
+    }
+
+    #[tokio::test]
+    async fn test_async_matches_sync() {
+        let kzg_settings = load_settings();
+        let blob = generate_random_blob();
+
+        let commitment =
+            KZGCommitment::blob_to_kzg_commitment_async(blob.clone(), kzg_settings.clone())
+                .await
+                .unwrap();
+        let expected = KZGCommitment::blob_to_kzg_commitment(&blob, &kzg_settings).unwrap();
+        assert_eq!(commitment.bytes, expected.bytes);
+
+        let z_bytes = Bytes32::from([2u8; 32]);
+        let (proof, y_bytes) =
+            KZGProof::compute_kzg_proof_async(blob.clone(), z_bytes, kzg_settings.clone())
+                .await
+                .unwrap();
+        assert!(KZGProof::verify_kzg_proof_async(
+            commitment.to_bytes(),
+            z_bytes,
+            y_bytes,
+            proof.to_bytes(),
+            kzg_settings.clone(),
+        )
+        .await
+        .unwrap());
+
+        let proof = KZGProof::compute_blob_kzg_proof_async(
+            blob.clone(),
+            commitment.to_bytes(),
+            kzg_settings.clone(),
+        )
+        .await
+        .unwrap();
+        assert!(KZGProof::verify_blob_kzg_proof_async(
+            blob,
+            commitment.to_bytes(),
+            proof.to_bytes(),
+            kzg_settings,
+        )
+        .await
+        .unwrap());
+    }
+
+    #[tokio::test(flavor = "multi_thread", worker_threads = 2)]
+    async fn test_async_batch() {
+        let kzg_settings = load_settings();
+        let blobs: Vec<Blob> = (0..4).map(|_| *generate_random_blob()).collect();
+        let commitments: Vec<Bytes48> = blobs
+            .iter()
+            .map(|blob| {
+                KZGCommitment::blob_to_kzg_commitment(blob, &kzg_settings)
+                    .unwrap()
+                    .to_bytes()
+            })
+            .collect();
+        let proofs: Vec<Bytes48> = blobs
+            .iter()
+            .zip(commitments.iter())
+            .map(|(blob, commitment)| {
+                KZGProof::compute_blob_kzg_proof(blob, commitment, &kzg_settings)
+                    .unwrap()
+                    .to_bytes()
+            })
+            .collect();
+
+        // Verifications run concurrently on the blocking pool.
+        let valid = tokio::spawn(KZGProof::verify_blob_kzg_proof_batch_async(
+            blobs.clone(),
+            commitments.clone(),
+            proofs.clone(),
+            kzg_settings.clone(),
+        ));
+        let mut wrong_proofs = proofs.clone();
+        wrong_proofs.swap(0, 1);
+        let invalid = tokio::spawn(KZGProof::verify_blob_kzg_proof_batch_async(
+            blobs.clone(),
+            commitments.clone(),
+            wrong_proofs,
+            kzg_settings.clone(),
+        ));
+        assert!(valid.await.unwrap().unwrap());
+        assert!(!invalid.await.unwrap().unwrap());
+
+        let error = KZGProof::verify_blob_kzg_proof_batch_async(
+            blobs[1..].to_vec(),
+            commitments,
+            proofs,
+            kzg_settings,
+        )
+        .await
+        .unwrap_err();
+        assert!(matches!(error, Error::MismatchLength(_)));
+    }
+}
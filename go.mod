@@ -3,12 +3,22 @@ module github.com/ethereum/c-kzg-4844
 go 1.19
 
 require (
+	github.com/consensys/gnark-crypto v0.10.0
+	github.com/crate-crypto/go-kzg-4844 v0.3.0
+	github.com/ebitengine/purego v0.8.2
 	github.com/stretchr/testify v1.8.1
 	github.com/supranational/blst v0.3.11
+	google.golang.org/protobuf v1.31.0
 	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
+	github.com/bits-and-blooms/bitset v1.5.0 // indirect
+	github.com/consensys/bavard v0.1.13 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/mmcloughlin/addchain v0.4.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/sync v0.1.0 // indirect
+	golang.org/x/sys v0.7.0 // indirect
+	rsc.io/tmplfunc v0.0.3 // indirect
 )
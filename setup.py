@@ -32,6 +32,8 @@ def main():
         long_description=Path("bindings/python/README.md").read_text(),
         long_description_content_type="text/markdown",
         license="Apache-2.0",
+        package_dir={"": "bindings/python"},
+        py_modules=["ckzg_futures"],
         ext_modules=[
             Extension(
                 "ckzg",
@@ -23,6 +23,9 @@
 
 #include <assert.h>
 #include <inttypes.h>
+#ifdef C_KZG_DEBUG_LOG
+#include <stdarg.h>
+#endif
 #include <stdlib.h>
 #include <string.h>
 
@@ -41,11 +44,32 @@
  * Helper macro to release memory allocated on the heap. Unlike free(),
  * c_kzg_free() macro sets the pointer value to NULL after freeing it.
  */
+#ifdef C_KZG_ALLOC_HOOKS
 #define c_kzg_free(p) \
     do { \
+        if ((p) != NULL) c_kzg_alloc_outstanding--; \
         free(p); \
         (p) = NULL; \
     } while (0)
+#else
+#define c_kzg_free(p) \
+    do { \
+        free(p); \
+        (p) = NULL; \
+    } while (0)
+#endif
+
+/**
+ * Helper macro to write a debug message with c_kzg_log(). It compiles to
+ * nothing unless C_KZG_DEBUG_LOG is defined.
+ */
+#ifdef C_KZG_DEBUG_LOG
+#define C_KZG_LOG(...) c_kzg_log(__VA_ARGS__)
+#else
+#define C_KZG_LOG(...) \
+    do { \
+    } while (0)
+#endif
 
 ///////////////////////////////////////////////////////////////////////////////
 // Types
@@ -162,6 +186,72 @@ static const fr_t FR_ONE = {
 // Memory Allocation Functions
 ///////////////////////////////////////////////////////////////////////////////
 
+#ifdef C_KZG_ALLOC_HOOKS
+/*
+ * Test hooks, only compiled in when C_KZG_ALLOC_HOOKS is defined. They are not
+ * thread-safe, so tests using them must not run other operations concurrently.
+ */
+
+/**
+ * The number of allocations that succeed before one fails with C_KZG_MALLOC.
+ * Negative values disable failure injection. Only the allocation that fails
+ * sets it back to -1, so one countdown makes at most one allocation fail.
+ */
+int c_kzg_alloc_failure_countdown = -1;
+
+/** The number of allocations that have not been freed yet. */
+int c_kzg_alloc_outstanding = 0;
+
+/**
+ * Decide whether the allocation being made should fail.
+ *
+ * @retval true  The allocation must fail
+ * @retval false The allocation must proceed
+ */
+static bool c_kzg_alloc_should_fail(void) {
+    if (c_kzg_alloc_failure_countdown < 0) return false;
+    if (c_kzg_alloc_failure_countdown-- > 0) return false;
+    return true;
+}
+#endif
+
+///////////////////////////////////////////////////////////////////////////////
+// Debug Logging Functions
+///////////////////////////////////////////////////////////////////////////////
+
+#ifdef C_KZG_DEBUG_LOG
+/*
+ * Debug logging, only compiled in when C_KZG_DEBUG_LOG is defined. Messages
+ * describe why the trusted setup was rejected and which entry of a batch
+ * could not be verified, so that such failures can be diagnosed without a
+ * debugger.
+ */
+
+/**
+ * The function that receives debug messages, or NULL to drop them. It must be
+ * set before the library is used, and may be called from several threads at
+ * once.
+ */
+void (*c_kzg_log_callback)(const char *msg) = NULL;
+
+/**
+ * Format a debug message and pass it to c_kzg_log_callback, if it is set.
+ * Messages longer than 255 bytes are truncated.
+ *
+ * @param[in] format A printf() format string
+ */
+static void c_kzg_log(const char *format, ...) {
+    char msg[256];
+    va_list args;
+
+    if (c_kzg_log_callback == NULL) return;
+    va_start(args, format);
+    vsnprintf(msg, sizeof(msg), format, args);
+    va_end(args);
+    c_kzg_log_callback(msg);
+}
+#endif
+
 /**
  * Wrapped malloc() that reports failures to allocate.
  *
@@ -173,8 +263,15 @@ static const fr_t FR_ONE = {
 static C_KZG_RET c_kzg_malloc(void **out, size_t size) {
     *out = NULL;
     if (size == 0) return C_KZG_BADARGS;
+#ifdef C_KZG_ALLOC_HOOKS
+    if (c_kzg_alloc_should_fail()) return C_KZG_MALLOC;
+#endif
     *out = malloc(size);
-    return *out != NULL ? C_KZG_OK : C_KZG_MALLOC;
+    if (*out == NULL) return C_KZG_MALLOC;
+#ifdef C_KZG_ALLOC_HOOKS
+    c_kzg_alloc_outstanding++;
+#endif
+    return C_KZG_OK;
 }
 
 /**
@@ -189,8 +286,15 @@ static C_KZG_RET c_kzg_malloc(void **out, size_t size) {
 static C_KZG_RET c_kzg_calloc(void **out, size_t count, size_t size) {
     *out = NULL;
     if (count == 0 || size == 0) return C_KZG_BADARGS;
+#ifdef C_KZG_ALLOC_HOOKS
+    if (c_kzg_alloc_should_fail()) return C_KZG_MALLOC;
+#endif
     *out = calloc(count, size);
-    return *out != NULL ? C_KZG_OK : C_KZG_MALLOC;
+    if (*out == NULL) return C_KZG_MALLOC;
+#ifdef C_KZG_ALLOC_HOOKS
+    c_kzg_alloc_outstanding++;
+#endif
+    return C_KZG_OK;
 }
 
 /**
@@ -1411,11 +1515,17 @@ C_KZG_RET verify_blob_kzg_proof_batch(
         ret = bytes_to_kzg_commitment(
             &commitments_g1[i], &commitments_bytes[i]
         );
-        if (ret != C_KZG_OK) goto out;
+        if (ret != C_KZG_OK) {
+            C_KZG_LOG("verify_blob_kzg_proof_batch: invalid commitment %zu", i);
+            goto out;
+        }
 
         /* Convert each blob from bytes to a poly */
         ret = blob_to_polynomial(&polynomial, &blobs[i]);
-        if (ret != C_KZG_OK) goto out;
+        if (ret != C_KZG_OK) {
+            C_KZG_LOG("verify_blob_kzg_proof_batch: invalid blob %zu", i);
+            goto out;
+        }
 
         compute_challenge(
             &evaluation_challenges_fr[i], &blobs[i], &commitments_g1[i]
@@ -1427,12 +1537,20 @@ C_KZG_RET verify_blob_kzg_proof_batch(
         if (ret != C_KZG_OK) goto out;
 
         ret = bytes_to_kzg_proof(&proofs_g1[i], &proofs_bytes[i]);
-        if (ret != C_KZG_OK) goto out;
+        if (ret != C_KZG_OK) {
+            C_KZG_LOG("verify_blob_kzg_proof_batch: invalid proof %zu", i);
+            goto out;
+        }
     }
 
     ret = verify_kzg_proof_batch(
         ok, commitments_g1, evaluation_challenges_fr, ys_fr, proofs_g1, n, s
     );
+    if (ret != C_KZG_OK) {
+        C_KZG_LOG("verify_blob_kzg_proof_batch: error %d", (int)ret);
+    } else if (!*ok) {
+        C_KZG_LOG("verify_blob_kzg_proof_batch: %zu proofs do not verify", n);
+    }
 
 out:
     c_kzg_free(commitments_g1);
@@ -1688,8 +1806,11 @@ C_KZG_RET load_trusted_setup(
     out->g2_values = NULL;
 
     /* Sanity check in case this is called directly */
-    CHECK(n1 == TRUSTED_SETUP_NUM_G1_POINTS);
-    CHECK(n2 == TRUSTED_SETUP_NUM_G2_POINTS);
+    if (n1 != TRUSTED_SETUP_NUM_G1_POINTS ||
+        n2 != TRUSTED_SETUP_NUM_G2_POINTS) {
+        C_KZG_LOG("load_trusted_setup: wrong point counts %zu, %zu", n1, n2);
+        return C_KZG_BADARGS;
+    }
 
     /* 1<<max_scale is the smallest power of 2 >= n1 */
     uint32_t max_scale = 0;
@@ -1714,6 +1835,11 @@ C_KZG_RET load_trusted_setup(
             &g1_affine, &g1_bytes[BYTES_PER_G1 * i]
         );
         if (err != BLST_SUCCESS) {
+            C_KZG_LOG(
+                "load_trusted_setup: invalid g1 point %" PRIu64 ": %d",
+                i,
+                (int)err
+            );
             ret = C_KZG_BADARGS;
             goto out_error;
         }
@@ -1727,6 +1853,11 @@ C_KZG_RET load_trusted_setup(
             &g2_affine, &g2_bytes[BYTES_PER_G2 * i]
         );
         if (err != BLST_SUCCESS) {
+            C_KZG_LOG(
+                "load_trusted_setup: invalid g2 point %" PRIu64 ": %d",
+                i,
+                (int)err
+            );
             ret = C_KZG_BADARGS;
             goto out_error;
         }
@@ -1735,7 +1866,10 @@ C_KZG_RET load_trusted_setup(
 
     /* Make sure the trusted setup was loaded in Lagrange form */
     ret = is_trusted_setup_in_lagrange_form(out, n1, n2);
-    if (ret != C_KZG_OK) goto out_error;
+    if (ret != C_KZG_OK) {
+        C_KZG_LOG("load_trusted_setup: g1 points are not in Lagrange form");
+        goto out_error;
+    }
 
     /* Compute roots of unity and permute the G1 trusted setup */
     ret = compute_roots_of_unity(out->roots_of_unity, max_scale);